@@ -579,7 +579,34 @@ func testGreenSpaces(ctx context.Context, coords Coordinates) TestResult {
 }
 
 func testAHN(ctx context.Context, coords Coordinates) TestResult {
-	return testWFS(ctx, "AHN_HEIGHT_MODEL_API_URL", "AHN Height Model", coords)
+	name := "AHN Height Model"
+	endpoint := os.Getenv("AHN_HEIGHT_MODEL_API_URL")
+	if endpoint == "" {
+		return TestResult{Name: name, Success: false, Message: "AHN_HEIGHT_MODEL_API_URL not set"}
+	}
+
+	// The backend samples the AHN4 DTM/DSM coverages from the WCS
+	capURL := strings.SplitN(endpoint, "?", 2)[0] + "?service=WCS&request=GetCapabilities&version=2.0.1"
+	body, status, err := doGet(ctx, capURL)
+	if err != nil {
+		return TestResult{Name: name, URL: capURL, Success: false, Message: fmt.Sprintf("GetCapabilities failed: %v", err)}
+	}
+	if status != 200 {
+		return TestResult{Name: name, URL: capURL, Success: false, Message: fmt.Sprintf("GetCapabilities HTTP %d", status)}
+	}
+	for _, coverage := range []string{"dtm_05m", "dsm_05m"} {
+		if !strings.Contains(string(body), coverage) {
+			return TestResult{Name: name, URL: capURL, Success: false, Message: fmt.Sprintf("Coverage %s not offered", coverage)}
+		}
+	}
+
+	return TestResult{
+		Name:    name,
+		URL:     capURL,
+		Success: true,
+		Message: "WCS service operational",
+		Details: "Coverages: dtm_05m, dsm_05m",
+	}
 }
 
 func testLandUse(ctx context.Context, coords Coordinates) TestResult {
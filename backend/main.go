@@ -1,19 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
-	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/app"
 	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
-	"github.com/iman-hussain/nethaddress/backend/pkg/handlers"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
 	"github.com/iman-hussain/nethaddress/backend/pkg/routes"
-	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
-	"github.com/rs/cors"
 )
 
 // Build-time variables (injected by ldflags during build)
@@ -34,38 +35,28 @@ func main() {
 	}
 	logutil.Info("Configuration loaded successfully")
 
-	// Initialize Redis client for caching
-	var cacheService *cache.CacheService
-	if cfg.RedisURL != "" {
-		var err error
-		cacheService, err = cache.NewCacheService(cfg.RedisURL)
-		if err != nil {
-			logutil.Warnf("Warning: could not connect to Redis: %v, caching disabled", err)
-			cacheService = nil
-		} else {
-			logutil.Info("Redis caching enabled")
-		}
+	// Initialize cache (Redis, in-memory or both, falling back to memory if Redis is unavailable)
+	cacheService, err := cache.New(cfg.CacheBackend, cfg.RedisURL, cfg.CacheMemoryMaxEntries)
+	if err != nil {
+		logutil.Fatalf("FATAL: could not initialize cache: %v", err)
 	}
-
-	// Fallback: create a no-op aggregator if cache is not available
 	if cacheService == nil {
-		logutil.Warn("Redis not configured, using direct API calls (no caching)")
+		logutil.Warn("Caching disabled, using direct API calls")
+	} else {
+		logutil.Infof("Caching enabled (%T)", cacheService)
 	}
 
-	// Initialize API client
-	apiClient := apiclient.NewApiClient(&http.Client{
-		Timeout: 30 * time.Second,
-	}, cfg)
-
-	// Initialize aggregator
-	propertyAggregator := aggregator.NewPropertyAggregator(apiClient, cacheService, cfg)
+	// SIGINT/SIGTERM start a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Initialize scoring engine
-	scoringEngine := scoring.NewEnhancedScoringEngine()
-
-	// Initialize handlers
-	propertyHandler := handlers.NewPropertyHandler(propertyAggregator, scoringEngine, apiClient, cfg)
-	searchHandler := handlers.NewSearchHandler(apiClient, cfg)
+	// Build shared dependencies and handlers once; invalid scoring profiles, cost
+	// rules or tax rates are fatal rather than silently replaced by the built-ins
+	application, err := app.New(cfg, cacheService, nil)
+	if err != nil {
+		logutil.Fatalf("FATAL: %v", err)
+	}
+	application.Start(ctx)
 
 	// Set build info for routes
 	routes.SetBuildInfo(BuildCommit, BuildDate)
@@ -81,33 +72,7 @@ func main() {
 	}
 	routes.SetFrontendBuildInfo(frontendCommit, frontendDate)
 
-	// Initialize router
-	router := routes.NewRouter(propertyHandler, searchHandler, cacheService)
-
-	// Setup routes
-	mux := http.NewServeMux()
-	router.SetupRoutes(mux)
-
-	// Register SSE endpoint directly (or add to router)
-	// Since router.SetupRoutes might not expose everything, let's check routes.go or just add here.
-	// Adding here is safest if we have access to handler instance.
-	// But `propertyHandler` and `searchHandler` are local variables.
-	mux.HandleFunc("/api/search/stream", searchHandler.HandleSearchStream)
-
-	// CORS middleware
-	allowedOrigins := []string{"http://localhost:3000"}
-	if cfg.FrontendOrigin != "" {
-		allowedOrigins = append(allowedOrigins, cfg.FrontendOrigin)
-	}
-	logutil.Infof("CORS allowed origins: %v", allowedOrigins)
-	c := cors.New(cors.Options{
-		AllowedOrigins:   allowedOrigins,
-		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Admin-Secret"},
-		AllowCredentials: false,
-		Debug:            false,
-	})
-	handler := c.Handler(mux)
+	handler := application.Handler()
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -115,12 +80,18 @@ func main() {
 	}
 	logutil.Infof("Server will listen on port %s", port)
 
+	// Request contexts outlive the signal so in-flight requests can drain; they
+	// are only cancelled if draining exceeds the shutdown timeout
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
 	srv := &http.Server{
 		Addr:         "0.0.0.0:" + port,
 		Handler:      handler,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 60 * time.Second,
 		IdleTimeout:  120 * time.Second,
+		BaseContext:  func(net.Listener) context.Context { return requestCtx },
 	}
 
 	// Start server
@@ -128,15 +99,56 @@ func main() {
 	logutil.Info("Endpoints available:")
 	logutil.Info("   GET  /                                  - API information")
 	logutil.Info("   GET  /healthz                           - Health check")
+	logutil.Info("   GET  /metrics                           - Prometheus metrics")
 	logutil.Info("   GET  /search                            - Legacy search")
 	logutil.Info("   GET  /api/search/stream                 - Real-time search stream (SSE)")
+	logutil.Info("   GET  /api/address/suggest               - Address autocomplete")
 	logutil.Info("   GET  /api/property                      - Full property data")
 	logutil.Info("   GET  /api/property/scores               - Property scores")
 	logutil.Info("   GET  /api/property/recommendations      - Recommendations")
 	logutil.Info("   GET  /api/property/analysis             - Complete analysis")
+	logutil.Info("   GET  /api/property/at                   - Property analysis at coordinates")
+	logutil.Info("   GET  /api/property/rent                 - WWS rental valuation")
+	logutil.Info("   GET  /api/property/costs                - Purchase costs and mortgage")
+	logutil.Info("   GET  /api/property/taxes                - Municipal housing taxes")
+	logutil.Info("   GET  /api/scoring/profiles              - Scoring profiles")
+	logutil.Info("   GET  /api/area/{code}                   - Neighbourhood analysis")
+	logutil.Info("   POST /api/compare                       - Compare properties")
+	logutil.Info("   POST /api/batch                         - Create batch analysis job")
+	logutil.Info("   GET  /api/batch/{id}                    - Batch job status")
+	logutil.Info("   GET  /api/batch/{id}/results            - Batch job results (JSON/CSV)")
+	logutil.Info("   GET  /admin/circuit-breakers            - Circuit breaker states (admin)")
+	logutil.Info("   POST /admin/circuit-breakers/reset      - Reset circuit breakers (admin)")
 
 	logutil.Infof("Server ready, listening on 0.0.0.0:%s", port)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		logutil.Fatalf("Server failed: %v", err)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logutil.Fatalf("Server failed: %v", err)
+		}
+		return
+	case <-ctx.Done():
+	}
+	stop()
+
+	// Stop accepting connections and wait for in-flight requests and SSE streams
+	logutil.Infof("Shutdown signal received, draining connections (timeout %s)", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logutil.Warnf("Graceful shutdown incomplete (%v); cancelling remaining requests", err)
+		cancelRequests()
+		srv.Close()
+	}
+
+	// Wait for batch workers, then close the cache (Redis connection)
+	if err := application.Shutdown(shutdownCtx); err != nil {
+		logutil.Warnf("Failed to close cache: %v", err)
 	}
+	logutil.Info("Server stopped")
 }
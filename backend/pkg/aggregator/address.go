@@ -0,0 +1,55 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// SuggestAddresses returns ranked address candidates for a partial query, cached
+// briefly since the same prefixes are requested repeatedly while typing
+func (pa *PropertyAggregator) SuggestAddresses(ctx context.Context, query string, limit int) ([]models.AddressSuggestion, error) {
+	key := cache.CacheKey{}.SuggestKey(query, limit)
+	if pa.cache != nil {
+		var cached []models.AddressSuggestion
+		if pa.getCached(ctx, cacheFamilySuggest, key, &cached) {
+			return cached, nil
+		}
+	}
+
+	suggestions, err := pa.apiClient.SuggestAddresses(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if pa.cache != nil {
+		if err := pa.cache.Set(ctx, key, suggestions, cache.SuggestTTL); err != nil {
+			logutil.Warnf("Failed to cache address suggestions: %v", err)
+		}
+	}
+	return suggestions, nil
+}
+
+// ResolveAddressID resolves a Locatieserver address ID to its BAG record, including
+// the postcode and house number used to aggregate it
+func (pa *PropertyAggregator) ResolveAddressID(ctx context.Context, id string) (*models.BAGData, error) {
+	key := cache.CacheKey{}.AddressLookupKey(id)
+	if pa.cache != nil {
+		var cached models.BAGData
+		if pa.getCached(ctx, cacheFamilyAddress, key, &cached) {
+			return &cached, nil
+		}
+	}
+
+	bagData, err := pa.apiClient.LookupAddress(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if pa.cache != nil {
+		if err := pa.cache.Set(ctx, key, bagData, cache.PropertyDataTTL); err != nil {
+			logutil.Warnf("Failed to cache address lookup: %v", err)
+		}
+	}
+	return bagData, nil
+}
@@ -1,85 +1,140 @@
 package aggregator
 
-import "github.com/iman-hussain/nethaddress/backend/pkg/config"
+import (
+	"context"
+	"time"
 
-// countEnabledSources calculates the total number of expected API calls based on configuration
-func (pa *PropertyAggregator) countEnabledSources(cfg *config.Config) int {
-	count := 0
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+)
 
-	// Free APIs (Always enabled)
-	count += 1 // BAG Address (already fetched but part of process)
-	count += 1 // KNMI Weather
-	count += 1 // KNMI Solar
-	count += 1 // Luchtmeetnet Air Quality
-	count += 1 // CBS Population
-	count += 1 // CBS Square Statistics
-	count += 1 // BRO Soil Map
-	count += 1 // NDW Traffic
-	count += 1 // openOV Public Transport
-	count += 1 // Flood Risk
-	count += 1 // Green Spaces
-	count += 1 // Education Facilities
-	count += 1 // Facilities & Amenities
-	count += 1 // AHN Height Model
-	count += 1 // Monument Status
-	count += 1 // PDOK Platform
-	count += 1 // Land Use & Zoning
+// progressTotal calculates the number of progress steps: BAG, every registered source and the AI summary
+func progressTotal(sources []DataSource) int {
+	return 1 + len(sources) + 1
+}
 
-	// Freemium/Premium APIs (Check keys/config)
-	if cfg.KadasterObjectInfoApiKey != "" {
-		count++
-	}
-	if cfg.AltumWOZApiKey != "" {
-		count++ // WOZ
-	}
-	if cfg.AltumTransactionApiKey != "" {
-		count++ // Transactions
+// coordinateSources returns the sources that can run without a BAG address
+func coordinateSources(sources []DataSource) []DataSource {
+	var out []DataSource
+	for _, src := range sources {
+		if src.Requires()&(RequiresBAGID|RequiresPandID) == 0 {
+			out = append(out, src)
+		}
 	}
-	if cfg.AltumEnergyApiKey != "" {
-		count++ // Energy
-	}
-	if cfg.AltumSustainabilityApiKey != "" {
-		count++ // Sustainability
+	return out
+}
+
+// areaContext is the postcode-level cache entry holding area data shared between addresses
+type areaContext struct {
+	Data      ComprehensivePropertyData `json:"data"`
+	FetchedAt map[string]time.Time      `json:"fetchedAt"`
+}
+
+// fresh returns the cached value for src if it is area-level and within its cache TTL
+func (ac *areaContext) fresh(src DataSource, now time.Time) (interface{}, bool) {
+	if ac == nil || src.CacheTTL() <= 0 {
+		return nil, false
 	}
-	if cfg.MatrixianApiKey != "" {
-		count++
+	fetchedAt, ok := ac.FetchedAt[src.Name()]
+	if !ok || now.Sub(fetchedAt) > src.CacheTTL() {
+		return nil, false
 	}
-	if cfg.SkyGeoApiKey != "" {
-		count++
+	return src.Value(&ac.Data)
+}
+
+// meta returns the cached provenance envelope for src, marked as a cache hit
+func (ac *areaContext) meta(src DataSource) *SourceMeta {
+	if m, ok := ac.Data.Provenance[src.Name()]; ok && m != nil {
+		return m.cachedCopy()
 	}
-	if cfg.SchipholApiKey != "" {
-		count++
+	// Entries cached before provenance was recorded
+	return &SourceMeta{Status: StatusOK, FetchedAt: ac.FetchedAt[src.Name()], CacheHit: true, Dataset: src.Dataset()}
+}
+
+// merge returns a new context entry combining still-fresh cached values with refreshed ones
+func (ac *areaContext) merge(sources []DataSource, data *ComprehensivePropertyData, refreshed map[string]time.Time) *areaContext {
+	now := time.Now()
+	updated := &areaContext{
+		Data:      ComprehensivePropertyData{AggregatedAt: now, Provenance: make(map[string]*SourceMeta)},
+		FetchedAt: make(map[string]time.Time),
 	}
-	if cfg.StratopoApiKey != "" {
-		count++
+	for _, src := range sources {
+		if src.CacheTTL() <= 0 {
+			continue
+		}
+		if fetchedAt, ok := refreshed[src.Name()]; ok {
+			if value, ok := src.Value(data); ok {
+				src.Apply(&updated.Data, value)
+				updated.FetchedAt[src.Name()] = fetchedAt
+				if m, ok := data.Provenance[src.Name()]; ok {
+					updated.Data.Provenance[src.Name()] = m
+				}
+			}
+			continue
+		}
+		if value, ok := ac.fresh(src, now); ok {
+			src.Apply(&updated.Data, value)
+			updated.FetchedAt[src.Name()] = ac.FetchedAt[src.Name()]
+			if m, ok := ac.Data.Provenance[src.Name()]; ok {
+				updated.Data.Provenance[src.Name()] = m
+			}
+		}
 	}
+	return updated
+}
 
-	// Specifics that might be conditional in fetchers
-	// Noise Pollution (Freemium - usually enabled?)
-	count++
-
-	// Soil Physicals (WUR)
-	// Check if configured (url usually set)
-	count++
-
-	// Soil Quality
-	count++
+// ttl returns the longest cache TTL of the entries, so each source expires individually
+func (ac *areaContext) ttl(sources []DataSource) time.Duration {
+	var longest time.Duration
+	for _, src := range sources {
+		if _, ok := ac.FetchedAt[src.Name()]; ok && src.CacheTTL() > longest {
+			longest = src.CacheTTL()
+		}
+	}
+	return longest
+}
 
-	// Parking
-	count++
+// Cache key families reported in cache hit/miss metrics
+const (
+	cacheFamilyAggregated = "aggregated"
+	cacheFamilyContext    = "context"
+	cacheFamilyAISummary  = "ai-summary"
+	cacheFamilySuggest    = "suggest"
+	cacheFamilyAddress    = "address"
+	cacheFamilyArea       = "area"
+)
 
-	// Water Quality
-	if cfg.DigitalDeltaApiKey != "" {
-		count++
+// getCached reads a cache entry, counting hits and misses for its key family
+func (pa *PropertyAggregator) getCached(ctx context.Context, family, key string, dest interface{}) bool {
+	if err := pa.cache.Get(ctx, key, dest); err != nil {
+		metrics.CacheRequests.Inc(family, "miss")
+		return false
 	}
+	metrics.CacheRequests.Inc(family, "hit")
+	return true
+}
 
-	// Safety
-	if cfg.CBSApiKey != "" {
-		count++
+// loadAreaContext retrieves the area data cached for a postcode, or nil on a miss
+func (pa *PropertyAggregator) loadAreaContext(ctx context.Context, postcode string) *areaContext {
+	if pa.cache == nil {
+		return nil
 	}
+	var cached areaContext
+	if !pa.getCached(ctx, cacheFamilyContext, cache.CacheKey{}.ContextKey(postcode), &cached) {
+		return nil
+	}
+	logutil.Debugf("[AGGREGATOR] Context cache hit for %s (%d sources)", postcode, len(cached.FetchedAt))
+	return &cached
+}
 
-	// Building Permits
-	count++
-
-	return count
+// saveAreaContext stores the area data for a postcode
+func (pa *PropertyAggregator) saveAreaContext(ctx context.Context, postcode string, ac *areaContext) {
+	ttl := ac.ttl(Sources())
+	if ttl <= 0 {
+		return
+	}
+	if err := pa.cache.Set(ctx, cache.CacheKey{}.ContextKey(postcode), ac, ttl); err != nil {
+		logutil.Warnf("Failed to cache context data: %v", err)
+	}
 }
@@ -2,10 +2,14 @@ package aggregator
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
@@ -209,3 +213,77 @@ func TestAggregatePropertyData_ErrorHandling(t *testing.T) {
 		t.Error("DataSources should include 'BAG' even when other APIs fail")
 	}
 }
+
+// hangingUpstream answers BAG and the neighbourhood lookup, and holds every
+// other request open until its context is cancelled
+func hangingUpstream(pending *atomic.Int32) *http.Client {
+	return &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		switch {
+		case req.URL.Host == "bag.test":
+			body = `{"response":{"docs":[{"id":"adr-1","verblijfsobject_id":"0363010000123456","weergavenaam":"Teststraat 10, 1234AB Testdorp","centroide_ll":"POINT(4.8952 52.3702)"}]}}`
+		case strings.Contains(req.URL.Path, "gebiedsindelingen"):
+			body = `{"type":"FeatureCollection","features":[]}`
+		default:
+			pending.Add(1)
+			defer pending.Add(-1)
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+	})}
+}
+
+func TestAggregatePropertyData_PhaseTimeoutStopsFetches(t *testing.T) {
+	defer func(d time.Duration) { phaseTimeout = d }(phaseTimeout)
+	phaseTimeout = 100 * time.Millisecond
+
+	var pending atomic.Int32
+	cfg := &config.Config{BagApiURL: "http://bag.test"}
+	agg := NewPropertyAggregator(apiclient.NewApiClient(hangingUpstream(&pending), cfg), nil, cfg)
+
+	start := time.Now()
+	data, err := agg.AggregatePropertyData(context.Background(), "1234AB", "10")
+	if err != nil {
+		t.Fatalf("Expected partial data after the phase timeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected aggregation to stop shortly after the phase timeout, took %s", elapsed)
+	}
+	if data.BAGID != "0363010000123456" {
+		t.Errorf("Expected BAG data to be kept, got %q", data.BAGID)
+	}
+	// Every fetch has returned before the result is handed out
+	if n := pending.Load(); n != 0 {
+		t.Errorf("Expected no upstream requests left running, got %d", n)
+	}
+}
+
+func TestAggregatePropertyData_CancelStopsFetches(t *testing.T) {
+	var pending atomic.Int32
+	cfg := &config.Config{BagApiURL: "http://bag.test"}
+	agg := NewPropertyAggregator(apiclient.NewApiClient(hangingUpstream(&pending), cfg), nil, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Cancel once sources are being fetched
+		for pending.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+
+	_, err := agg.AggregatePropertyData(ctx, "1234AB", "10")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	// The shared run is cancelled too, so its fetches unwind promptly
+	deadline := time.Now().Add(5 * time.Second)
+	for pending.Load() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := pending.Load(); n != 0 {
+		t.Errorf("Expected upstream requests to be cancelled, %d still running", n)
+	}
+}
@@ -0,0 +1,186 @@
+package aggregator
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// areaGreenRadius is the radius in metres searched for green space around each sample point
+const areaGreenRadius = 500
+
+// AreaAnalysis combines the CBS key figures of a buurt, wijk or postcode-4 area with
+// point-based statistics averaged over sample points spread across its polygon
+type AreaAnalysis struct {
+	Area *models.AreaData `json:"area"`
+
+	GreenPercentage float64  `json:"greenPercentage"` // mean over sample points, 0-100
+	AmenitiesScore  float64  `json:"amenitiesScore"`  // mean over sample points, 0-100
+	FloodRiskShare  float64  `json:"floodRiskShare"`  // share of sample points in a flood risk zone, 0-1
+	FloodZones      []string `json:"floodZones,omitempty"`
+
+	// Samples counts the sample points with real data per source; 0 means unknown
+	Samples map[string]int `json:"samples"`
+
+	AISummary    *models.GeminiSummary `json:"aiSummary,omitempty"`
+	AggregatedAt time.Time             `json:"aggregatedAt"`
+	Errors       map[string]string     `json:"errors,omitempty"`
+}
+
+// AggregateArea analyses a CBS buurtcode, wijkcode or postcode-4 area as a whole.
+// Returns apiclient.ErrInvalidAreaCode or apiclient.ErrAreaNotFound for unusable codes.
+func (pa *PropertyAggregator) AggregateArea(ctx context.Context, code string) (*AreaAnalysis, error) {
+	_, code, err := apiclient.ParseAreaCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cache.CacheKey{}.AreaKey(code)
+	if pa.cache != nil {
+		var cached AreaAnalysis
+		if pa.getCached(ctx, cacheFamilyArea, key, &cached) {
+			return &cached, nil
+		}
+	}
+
+	// Concurrent requests for the same area share one analysis
+	result, err, shared := pa.flights.do(ctx, key, func() (interface{}, error) {
+		return pa.analyseArea(ctx, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+	analysis := result.(*AreaAnalysis)
+
+	if pa.cache != nil && !shared {
+		if err := pa.cache.Set(ctx, key, analysis, cache.PropertyDataTTL); err != nil {
+			logutil.Warnf("[AGGREGATOR] Failed to cache area analysis: %v", err)
+		}
+	}
+	return analysis, nil
+}
+
+// analyseArea fetches the area, samples the point-based sources across it and
+// generates the area summary
+func (pa *PropertyAggregator) analyseArea(ctx context.Context, code string) (*AreaAnalysis, error) {
+	cfg := pa.config
+	area, err := pa.apiClient.FetchArea(ctx, cfg, code)
+	if err != nil {
+		return nil, err
+	}
+	logutil.Debugf("[AGGREGATOR] Analysing area %s (%s) at %d sample points", code, area.Name, len(area.SamplePoints))
+
+	analysis := &AreaAnalysis{
+		Area:         area,
+		Samples:      map[string]int{},
+		AggregatedAt: time.Now(),
+		Errors:       map[string]string{},
+	}
+
+	sampleCtx, cancel := context.WithTimeout(ctx, phaseTimeout)
+	defer cancel()
+
+	var (
+		wg         sync.WaitGroup
+		green      []*models.GreenSpacesData
+		facilities []*models.FacilitiesData
+		floods     []*models.FloodRiskData
+	)
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		green = sampleArea(sampleCtx, area.SamplePoints, func(ctx context.Context, lat, lon float64) (*models.GreenSpacesData, error) {
+			return pa.apiClient.FetchGreenSpacesData(ctx, cfg, lat, lon, areaGreenRadius)
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		facilities = sampleArea(sampleCtx, area.SamplePoints, func(ctx context.Context, lat, lon float64) (*models.FacilitiesData, error) {
+			return pa.apiClient.FetchFacilitiesData(ctx, cfg, lat, lon)
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		floods = sampleArea(sampleCtx, area.SamplePoints, func(ctx context.Context, lat, lon float64) (*models.FloodRiskData, error) {
+			return pa.apiClient.FetchFloodRiskData(ctx, cfg, lat, lon)
+		})
+	}()
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, g := range green {
+		analysis.GreenPercentage += g.GreenPercentage / float64(len(green))
+	}
+	for _, f := range facilities {
+		analysis.AmenitiesScore += f.AmenitiesScore / float64(len(facilities))
+	}
+	zones := map[string]bool{}
+	for _, f := range floods {
+		if f.RiskLevel == "Low" {
+			continue
+		}
+		analysis.FloodRiskShare += 1 / float64(len(floods))
+		if f.FloodZone != "" {
+			zones[f.FloodZone] = true
+		}
+	}
+	for zone := range zones {
+		analysis.FloodZones = append(analysis.FloodZones, zone)
+	}
+	sort.Strings(analysis.FloodZones)
+
+	for name, n := range map[string]int{"Green Spaces": len(green), "Facilities & Amenities": len(facilities), "Flood Risk": len(floods)} {
+		analysis.Samples[name] = n
+		if n == 0 {
+			analysis.Errors[name] = "no data at any sample point"
+		}
+	}
+
+	// Summarise the figures only; the outline would crowd out the statistics in the prompt
+	promptArea := *area
+	promptArea.GeoJSON = ""
+	promptArea.SamplePoints = nil
+	promptData := *analysis
+	promptData.Area = &promptArea
+	summary, err := pa.apiClient.GenerateAreaSummary(ctx, cfg, promptData)
+	if err != nil {
+		analysis.Errors["Gemini AI"] = err.Error()
+	} else {
+		analysis.AISummary = summary
+	}
+	return analysis, ctx.Err()
+}
+
+// sampleArea fetches a point-based source at every sample point concurrently and
+// returns the values that are real measurements, skipping errors and placeholders
+func sampleArea[T any](ctx context.Context, points [][2]float64, fetch func(ctx context.Context, lat, lon float64) (T, error)) []T {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		values []T
+	)
+	for _, pt := range points {
+		wg.Add(1)
+		go func(lon, lat float64) {
+			defer wg.Done()
+			fetchCtx, prov := apiclient.WithProvenance(ctx)
+			v, err := fetch(fetchCtx, lat, lon)
+			if err != nil || prov.Status() != StatusOK {
+				return
+			}
+			mu.Lock()
+			values = append(values, v)
+			mu.Unlock()
+		}(pt[0], pt[1])
+	}
+	wg.Wait()
+	return values
+}
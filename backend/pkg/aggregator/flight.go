@@ -0,0 +1,201 @@
+package aggregator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+)
+
+// flightGroup deduplicates concurrent calls with the same key: the first caller
+// runs fn and later callers wait for and share its result (like x/sync/singleflight)
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done chan struct{}
+	val  interface{}
+	err  error
+}
+
+// do runs fn once per key at a time. shared reports whether the result came
+// from another caller; waiting callers give up when their ctx is done.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (val interface{}, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.val, c.err, true
+		case <-ctx.Done():
+			return nil, ctx.Err(), true
+		}
+	}
+	c := &flightCall{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	// Release waiters even if fn panics; the panic still propagates to the caller
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("panic in shared call %s: %v", key, r)
+			g.finish(key, c)
+			panic(r)
+		}
+		g.finish(key, c)
+	}()
+
+	c.val, c.err = fn()
+	return c.val, c.err, false
+}
+
+func (g *flightGroup) finish(key string, c *flightCall) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(c.done)
+}
+
+// aggregation is an in-flight AggregatePropertyDataWithOptions run shared by
+// every concurrent caller for the same address. Progress events are broadcast
+// to all subscribers, and replayed to callers that attach mid-run.
+type aggregation struct {
+	done   chan struct{}
+	data   *ComprehensivePropertyData
+	err    error
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	refs   int
+	events []ProgressEvent
+	subs   []chan<- ProgressEvent
+}
+
+// publish records a progress event and forwards it to all subscribers without blocking
+func (a *aggregation) publish(ev ProgressEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events = append(a.events, ev)
+	for _, ch := range a.subs {
+		select {
+		case ch <- ev:
+		default:
+			// Buffer full, skip update (client eventually gets complete)
+		}
+	}
+}
+
+// subscribe attaches ch, first replaying the events published so far
+func (a *aggregation) subscribe(ch chan<- ProgressEvent) {
+	if ch == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, ev := range a.events {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+	a.subs = append(a.subs, ch)
+}
+
+// leave detaches ch and cancels the run once no caller is waiting for it
+func (a *aggregation) leave(ch chan<- ProgressEvent) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, sub := range a.subs {
+		if sub == ch {
+			a.subs = append(a.subs[:i], a.subs[i+1:]...)
+			break
+		}
+	}
+	a.refs--
+	if a.refs == 0 {
+		a.cancel()
+	}
+}
+
+// aggregations tracks in-flight aggregation runs by flight key
+type aggregations struct {
+	mu   sync.Mutex
+	runs map[string]*aggregation
+}
+
+// join returns the in-flight run for key, or registers a new one. leader is
+// true when the caller must start the run, using the returned run context.
+// A run every caller has left is already cancelled, so it is replaced.
+func (as *aggregations) join(ctx context.Context, key string) (a *aggregation, runCtx context.Context, leader bool) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if as.runs == nil {
+		as.runs = make(map[string]*aggregation)
+	}
+
+	if a, ok := as.runs[key]; ok {
+		a.mu.Lock()
+		live := a.refs > 0
+		if live {
+			a.refs++
+		}
+		a.mu.Unlock()
+		if live {
+			return a, nil, false
+		}
+	}
+
+	// The run outlives the leader's request if others are still waiting, so it
+	// is only cancelled when every caller has left
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	a = &aggregation{done: make(chan struct{}), cancel: cancel, refs: 1}
+	as.runs[key] = a
+	return a, runCtx, true
+}
+
+// finish stores the result, removes the run unless it was replaced and releases waiters
+func (as *aggregations) finish(key string, a *aggregation, data *ComprehensivePropertyData, err error) {
+	as.mu.Lock()
+	if as.runs[key] == a {
+		delete(as.runs, key)
+	}
+	as.mu.Unlock()
+
+	a.data, a.err = data, err
+	close(a.done)
+	a.cancel()
+}
+
+// aggregationKey is the flight key for an aggregation. Cache bypasses and
+// requests with user API keys only share runs with identical requests.
+func aggregationKey(postcode, houseNumber string, bypassCache bool, userKeys map[string]string) string {
+	key := cache.CacheKey{}.AggregatedKey(postcode, houseNumber)
+	if bypassCache {
+		key += ":bypass"
+	}
+	if len(userKeys) > 0 {
+		names := make([]string, 0, len(userKeys))
+		for name := range userKeys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var b strings.Builder
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s=%s\n", name, userKeys[name])
+		}
+		sum := sha256.Sum256([]byte(b.String()))
+		key += ":keys:" + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
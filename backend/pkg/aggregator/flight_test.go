@@ -0,0 +1,173 @@
+package aggregator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroup_Coalesces(t *testing.T) {
+	var g flightGroup
+	var calls int32
+	release := make(chan struct{})
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make([]interface{}, callers)
+	started := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		// Leader blocks until every follower has joined
+		defer wg.Done()
+		results[0], _, _ = g.do(context.Background(), "k", func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-release
+			return "value", nil
+		})
+	}()
+	<-started
+
+	for i := 1; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var shared bool
+			results[i], _, shared = g.do(context.Background(), "k", func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				return "other", nil
+			})
+			if !shared {
+				t.Errorf("Caller %d: expected shared result", i)
+			}
+		}(i)
+	}
+
+	// Give followers time to block on the in-flight call
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected fn to run once, ran %d times", calls)
+	}
+	for i, r := range results {
+		if r != "value" {
+			t.Errorf("Caller %d: expected shared value, got %v", i, r)
+		}
+	}
+
+	// Once finished, the key runs again
+	v, _, shared := g.do(context.Background(), "k", func() (interface{}, error) { return "again", nil })
+	if v != "again" || shared {
+		t.Errorf("Expected a fresh call after completion, got %v (shared=%v)", v, shared)
+	}
+}
+
+func TestFlightGroup_WaiterCancellation(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go g.do(context.Background(), "k", func() (interface{}, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err, _ := g.do(ctx, "k", func() (interface{}, error) { return nil, nil }); err != context.Canceled {
+		t.Errorf("Expected waiter to give up with context.Canceled, got %v", err)
+	}
+}
+
+func TestAggregation_ReplayAndCancel(t *testing.T) {
+	var as aggregations
+	first, runCtx, leader := as.join(context.Background(), "addr")
+	if !leader || runCtx == nil {
+		t.Fatal("Expected first caller to lead the run")
+	}
+	first.publish(ProgressEvent{Source: "BAG", Completed: 1})
+
+	second, _, leader := as.join(context.Background(), "addr")
+	if leader || second != first {
+		t.Fatal("Expected second caller to join the in-flight run")
+	}
+
+	// A late subscriber receives the events published before it attached
+	ch := make(chan ProgressEvent, 10)
+	second.subscribe(ch)
+	second.publish(ProgressEvent{Source: "CBS", Completed: 2})
+	if got := len(ch); got != 2 {
+		t.Fatalf("Expected replayed and live events, got %d", got)
+	}
+	if ev := <-ch; ev.Source != "BAG" {
+		t.Errorf("Expected replay first, got %q", ev.Source)
+	}
+
+	// The run survives while any caller is still waiting
+	first.leave(nil)
+	if runCtx.Err() != nil {
+		t.Error("Expected run to continue while a caller is waiting")
+	}
+	second.leave(ch)
+	if runCtx.Err() == nil {
+		t.Error("Expected run to be cancelled once every caller left")
+	}
+	second.publish(ProgressEvent{Source: "late"})
+	if len(ch) != 1 {
+		t.Error("Expected no events after unsubscribing")
+	}
+
+	as.finish("addr", first, nil, nil)
+	if _, _, leader := as.join(context.Background(), "addr"); !leader {
+		t.Error("Expected a new run after the previous one finished")
+	}
+}
+
+func TestAggregation_JoinAfterCancel(t *testing.T) {
+	var as aggregations
+	first, firstCtx, _ := as.join(context.Background(), "addr")
+
+	// The leader disconnects before its run has finished
+	first.leave(nil)
+	if firstCtx.Err() == nil {
+		t.Fatal("Expected the run to be cancelled once its only caller left")
+	}
+
+	second, secondCtx, leader := as.join(context.Background(), "addr")
+	if !leader || second == first || secondCtx.Err() != nil {
+		t.Fatal("Expected a caller arriving after cancellation to start a new run")
+	}
+
+	// The cancelled run finishing must not remove its replacement
+	as.finish("addr", first, nil, context.Canceled)
+	third, _, leader := as.join(context.Background(), "addr")
+	if leader || third != second {
+		t.Error("Expected later callers to join the replacement run")
+	}
+}
+
+func TestAggregationKey(t *testing.T) {
+	base := aggregationKey("1234AB", "1", false, nil)
+	if aggregationKey("1234AB", "1", false, map[string]string{}) != base {
+		t.Error("Expected empty user keys to share the default run")
+	}
+	if aggregationKey("1234AB", "1", true, nil) == base {
+		t.Error("Expected cache bypass to use its own run")
+	}
+
+	a := aggregationKey("1234AB", "1", false, map[string]string{"KNMI": "a", "Gemini": "b"})
+	b := aggregationKey("1234AB", "1", false, map[string]string{"Gemini": "b", "KNMI": "a"})
+	c := aggregationKey("1234AB", "1", false, map[string]string{"KNMI": "other", "Gemini": "b"})
+	if a != b {
+		t.Error("Expected key order not to matter")
+	}
+	if a == base || a == c {
+		t.Error("Expected different user keys to use separate runs")
+	}
+}
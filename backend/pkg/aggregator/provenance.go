@@ -0,0 +1,86 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+)
+
+// SourceMeta is the provenance envelope recorded for each source's payload
+type SourceMeta struct {
+	Status      string    `json:"status"` // "ok", "empty", "fallback", "error", "not_configured", "circuit_open"
+	Message     string    `json:"message,omitempty"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+	CacheHit    bool      `json:"cacheHit"`
+	UpstreamURL string    `json:"upstreamUrl,omitempty"`
+	Dataset     string    `json:"dataset,omitempty"`
+	LatencyMs   int64     `json:"latencyMs"`
+}
+
+// newSourceMeta builds the envelope for a completed fetch from its recorded provenance
+func newSourceMeta(prov *apiclient.Provenance, dataset string, fetchErr error, fetchedAt time.Time) *SourceMeta {
+	meta := &SourceMeta{
+		Status:      prov.Status(),
+		Message:     prov.Reason(),
+		FetchedAt:   fetchedAt,
+		UpstreamURL: prov.UpstreamURL(),
+		Dataset:     dataset,
+		LatencyMs:   prov.Latency().Milliseconds(),
+	}
+	if fetchErr != nil {
+		// Hard failures keep a more specific status (e.g. not_configured) if one was marked
+		if meta.Status == StatusOK {
+			meta.Status = StatusError
+		}
+		meta.Message = fetchErr.Error()
+	}
+	return meta
+}
+
+// cachedCopy returns a copy of the envelope marked as served from cache
+func (m *SourceMeta) cachedCopy() *SourceMeta {
+	cp := *m
+	cp.CacheHit = true
+	return &cp
+}
+
+// SourceStatus returns the provenance status recorded for a source, or "" if none was recorded
+func (d *ComprehensivePropertyData) SourceStatus(name string) string {
+	if meta, ok := d.Provenance[name]; ok && meta != nil {
+		return meta.Status
+	}
+	return ""
+}
+
+// Trusted returns a shallow copy of data with placeholder, empty and fallback
+// payloads removed, so consumers such as scoring only see real measurements.
+// Sources without recorded provenance (e.g. older cache entries) are kept as-is.
+func (d *ComprehensivePropertyData) Trusted() *ComprehensivePropertyData {
+	if d == nil || len(d.Provenance) == 0 {
+		return d
+	}
+
+	cp := *d
+	if d.Extra != nil {
+		cp.Extra = make(map[string]interface{}, len(d.Extra))
+		for k, v := range d.Extra {
+			cp.Extra[k] = v
+		}
+	}
+
+	for _, src := range Sources() {
+		if status := d.SourceStatus(src.Name()); status != "" && status != StatusOK {
+			src.Apply(&cp, nil)
+		}
+	}
+	return &cp
+}
+
+// markCacheHit flags every envelope as served from cache
+func (d *ComprehensivePropertyData) markCacheHit() {
+	for name, meta := range d.Provenance {
+		if meta != nil {
+			d.Provenance[name] = meta.cachedCopy()
+		}
+	}
+}
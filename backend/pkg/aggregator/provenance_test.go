@@ -0,0 +1,96 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func TestNewSourceMeta(t *testing.T) {
+	_, prov := apiclient.WithProvenance(context.Background())
+	now := time.Now()
+
+	meta := newSourceMeta(prov, "CBS 85039NED", nil, now)
+	if meta.Status != StatusOK || meta.Dataset != "CBS 85039NED" || !meta.FetchedAt.Equal(now) {
+		t.Errorf("Unexpected meta for successful fetch: %+v", meta)
+	}
+
+	meta = newSourceMeta(prov, "", errors.New("boom"), now)
+	if meta.Status != StatusError || meta.Message != "boom" {
+		t.Errorf("Expected error meta, got %+v", meta)
+	}
+
+	cached := meta.cachedCopy()
+	if !cached.CacheHit || meta.CacheHit {
+		t.Error("Expected cachedCopy to mark only the copy as a cache hit")
+	}
+}
+
+func TestTrusted_DropsSyntheticValues(t *testing.T) {
+	data := &ComprehensivePropertyData{
+		Weather:     &models.KNMIWeatherData{Temperature: 12},
+		FloodRisk:   &models.FloodRiskData{RiskLevel: "Low"},
+		Elevation:   &models.AHNHeightData{Elevation: -2},
+		GreenSpaces: &models.GreenSpacesData{TotalGreenArea: 500},
+		Provenance: map[string]*SourceMeta{
+			"KNMI Weather":     {Status: StatusOK},
+			"Flood Risk":       {Status: StatusFallback},
+			"AHN Height Model": {Status: StatusNotConfigured},
+		},
+	}
+
+	trusted := data.Trusted()
+	if trusted.Weather == nil {
+		t.Error("Expected ok weather data to be kept")
+	}
+	if trusted.FloodRisk != nil || trusted.Elevation != nil {
+		t.Error("Expected fallback and not_configured payloads to be dropped")
+	}
+	if trusted.GreenSpaces == nil {
+		t.Error("Expected data without provenance to be kept")
+	}
+	if data.FloodRisk == nil || data.Elevation == nil {
+		t.Error("Expected original data to be left untouched")
+	}
+
+	// Data without provenance (e.g. older cache entries) is returned as-is
+	legacy := &ComprehensivePropertyData{FloodRisk: &models.FloodRiskData{RiskLevel: "Low"}}
+	if legacy.Trusted() != legacy {
+		t.Error("Expected data without provenance to be returned unchanged")
+	}
+}
+
+func TestAreaContext_Meta(t *testing.T) {
+	weather := &source[*models.KNMIWeatherData]{
+		name:  "Test Weather",
+		ttl:   time.Hour,
+		field: func(d *ComprehensivePropertyData) **models.KNMIWeatherData { return &d.Weather },
+	}
+	fetchedAt := time.Now().Add(-time.Minute)
+
+	ac := &areaContext{
+		Data: ComprehensivePropertyData{
+			Weather:    &models.KNMIWeatherData{Temperature: 12},
+			Provenance: map[string]*SourceMeta{"Test Weather": {Status: StatusOK, LatencyMs: 40}},
+		},
+		FetchedAt: map[string]time.Time{"Test Weather": fetchedAt},
+	}
+	meta := ac.meta(weather)
+	if !meta.CacheHit || meta.LatencyMs != 40 {
+		t.Errorf("Expected cached copy of stored meta, got %+v", meta)
+	}
+	if ac.Data.Provenance["Test Weather"].CacheHit {
+		t.Error("Expected stored meta to be left untouched")
+	}
+
+	// Entries cached before provenance was recorded get a synthesised envelope
+	ac.Data.Provenance = nil
+	meta = ac.meta(weather)
+	if meta.Status != StatusOK || !meta.CacheHit || !meta.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("Unexpected envelope for legacy entry: %+v", meta)
+	}
+}
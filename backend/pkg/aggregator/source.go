@@ -0,0 +1,243 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+// Tier classifies a data source by how it is accessed
+type Tier string
+
+const (
+	TierFree     Tier = "free"
+	TierFreemium Tier = "freemium"
+	TierPremium  Tier = "premium"
+)
+
+// Phase determines when a data source is fetched during aggregation
+type Phase int
+
+const (
+	// PhaseArea covers fast, area-level sources (environment, transport, demographics)
+	PhaseArea Phase = iota + 1
+	// PhaseProperty covers property-specific and risk sources
+	PhaseProperty
+	// PhaseSupplemental covers energy, platforms and other supplemental sources
+	PhaseSupplemental
+)
+
+// phases lists all phases in execution order
+var phases = []Phase{PhaseArea, PhaseProperty, PhaseSupplemental}
+
+// String returns the phase name used in logs and metrics
+func (p Phase) String() string {
+	switch p {
+	case PhaseArea:
+		return "area"
+	case PhaseProperty:
+		return "property"
+	case PhaseSupplemental:
+		return "supplemental"
+	}
+	return fmt.Sprintf("phase%d", int(p))
+}
+
+// Requirement flags the location identifiers a source needs before it can be fetched
+type Requirement uint8
+
+const (
+	RequiresBAGID Requirement = 1 << iota
+	RequiresCoordinates
+	RequiresNeighborhoodCode
+	RequiresRegionCode
+	RequiresPandID
+)
+
+// Result statuses reported for sources, shared with the apiclient provenance
+const (
+	StatusOK            = apiclient.StatusOK
+	StatusEmpty         = apiclient.StatusEmpty
+	StatusFallback      = apiclient.StatusFallback
+	StatusError         = apiclient.StatusError
+	StatusNotConfigured = apiclient.StatusNotConfigured
+	StatusCircuitOpen   = apiclient.StatusCircuitOpen
+)
+
+// SourceRequest carries the location identifiers resolved from BAG before sources run
+type SourceRequest struct {
+	Config            *config.Config
+	Postcode          string
+	HouseNumber       string // huisnummer with letter and toevoeging
+	BAGID             string
+	VerblijfsobjectID string
+	PandID            string
+	Lat               float64
+	Lon               float64
+	NeighborhoodCode  string
+	RegionCode        string
+
+	// shareScope lets concurrent requests in the same postcode share area-level
+	// fetches; empty when the request's config differs (user API keys)
+	shareScope string
+}
+
+// missing returns a reason when the request lacks an identifier the source requires
+func (r SourceRequest) missing(req Requirement) string {
+	switch {
+	case req&RequiresBAGID != 0 && r.BAGID == "":
+		return "BAG ID not available"
+	case req&RequiresPandID != 0 && r.PandID == "":
+		return "BAG pand ID not available"
+	case req&RequiresCoordinates != 0 && r.Lat == 0 && r.Lon == 0:
+		return "coordinates not available"
+	case req&RequiresNeighborhoodCode != 0 && r.NeighborhoodCode == "":
+		return "neighborhood code not available"
+	case req&RequiresRegionCode != 0 && r.RegionCode == "":
+		return "region code not available"
+	}
+	return ""
+}
+
+// DataSource is a single upstream dataset contributing to ComprehensivePropertyData
+type DataSource interface {
+	// Name is the display name used in progress events, errors and API results
+	Name() string
+	Tier() Tier
+	Phase() Phase
+	// CacheTTL is how long area-level data may be shared between addresses in
+	// the same postcode. Zero means the data is property-specific.
+	CacheTTL() time.Duration
+	Requires() Requirement
+	// Dataset identifies the upstream dataset or version, or "" if unknown
+	Dataset() string
+	Fetch(ctx context.Context, client *apiclient.ApiClient, req SourceRequest) (interface{}, error)
+	// Apply stores a fetched value on data; a nil value clears it. Callers must hold the data lock.
+	Apply(data *ComprehensivePropertyData, value interface{})
+	// Value returns the source's data from data, or false if it is absent
+	Value(data *ComprehensivePropertyData) (interface{}, bool)
+	// Unavailable returns the status and message reported when no data is present
+	Unavailable() (status, message string)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []DataSource
+)
+
+// Register adds a data source to the registry. It panics on duplicate names,
+// since sources are registered from init functions.
+func Register(ds DataSource) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, existing := range registry {
+		if existing.Name() == ds.Name() {
+			panic(fmt.Sprintf("aggregator: data source %q registered twice", ds.Name()))
+		}
+	}
+	registry = append(registry, ds)
+}
+
+// Sources returns all registered data sources in registration order
+func Sources() []DataSource {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]DataSource, len(registry))
+	copy(out, registry)
+	return out
+}
+
+// source is the standard DataSource backed by an ApiClient fetch function.
+// When field is nil the value is stored in ComprehensivePropertyData.Extra,
+// so a new dataset can be added without touching the struct.
+type source[T any] struct {
+	name        string
+	tier        Tier
+	phase       Phase
+	ttl         time.Duration
+	requires    Requirement
+	unavailable string // StatusError or StatusNotConfigured
+	message     string
+	dataset     string // upstream dataset/version identifier, if known
+	fetch       func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (T, error)
+	field       func(data *ComprehensivePropertyData) *T
+}
+
+func (s *source[T]) Name() string            { return s.name }
+func (s *source[T]) Tier() Tier              { return s.tier }
+func (s *source[T]) Phase() Phase            { return s.phase }
+func (s *source[T]) CacheTTL() time.Duration { return s.ttl }
+func (s *source[T]) Requires() Requirement   { return s.requires }
+func (s *source[T]) Dataset() string         { return s.dataset }
+
+func (s *source[T]) Unavailable() (string, string) {
+	if s.unavailable == "" {
+		return StatusError, s.message
+	}
+	return s.unavailable, s.message
+}
+
+func (s *source[T]) Fetch(ctx context.Context, client *apiclient.ApiClient, req SourceRequest) (interface{}, error) {
+	v, err := s.fetch(ctx, client, req)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty(v) {
+		return nil, fmt.Errorf("no data returned")
+	}
+	return v, nil
+}
+
+func (s *source[T]) Apply(data *ComprehensivePropertyData, value interface{}) {
+	if s.field == nil {
+		if value == nil {
+			delete(data.Extra, s.name)
+			return
+		}
+		if data.Extra == nil {
+			data.Extra = make(map[string]interface{})
+		}
+		data.Extra[s.name] = value
+		return
+	}
+	if value == nil {
+		var zero T
+		*s.field(data) = zero
+		return
+	}
+	if v, ok := value.(T); ok {
+		*s.field(data) = v
+	}
+}
+
+func (s *source[T]) Value(data *ComprehensivePropertyData) (interface{}, bool) {
+	if s.field == nil {
+		v, ok := data.Extra[s.name]
+		return v, ok && !isEmpty(v)
+	}
+	v := *s.field(data)
+	if isEmpty(v) {
+		return nil, false
+	}
+	return v, true
+}
+
+// isEmpty reports whether v is nil, a nil pointer or an empty slice/map
+func isEmpty(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func TestRegistry_SourcesAreComplete(t *testing.T) {
+	sources := Sources()
+	if len(sources) == 0 {
+		t.Fatal("Expected registered data sources")
+	}
+
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		if seen[src.Name()] {
+			t.Errorf("Duplicate source name %q", src.Name())
+		}
+		seen[src.Name()] = true
+
+		switch src.Tier() {
+		case TierFree, TierFreemium, TierPremium:
+		default:
+			t.Errorf("Source %q has invalid tier %q", src.Name(), src.Tier())
+		}
+		if src.Phase() < PhaseArea || src.Phase() > PhaseSupplemental {
+			t.Errorf("Source %q has invalid phase %d", src.Name(), src.Phase())
+		}
+		if status, message := src.Unavailable(); status == "" || message == "" {
+			t.Errorf("Source %q has no unavailable status/message", src.Name())
+		}
+	}
+
+	for _, name := range []string{"KNMI Weather", "Altum WOZ", "Flood Risk", "AHN Height Model", "Land Use & Zoning"} {
+		if !seen[name] {
+			t.Errorf("Expected source %q to be registered", name)
+		}
+	}
+
+	if got, want := progressTotal(sources), len(sources)+2; got != want {
+		t.Errorf("Expected progress total %d (BAG + sources + AI), got %d", want, got)
+	}
+}
+
+func TestSource_ApplyAndValue(t *testing.T) {
+	src := &source[*models.FloodRiskData]{
+		name: "Test Flood",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.FloodRiskData, error) {
+			return nil, nil
+		},
+		field: func(d *ComprehensivePropertyData) **models.FloodRiskData { return &d.FloodRisk },
+	}
+
+	data := &ComprehensivePropertyData{}
+	if _, ok := src.Value(data); ok {
+		t.Error("Expected no value before Apply")
+	}
+
+	// A nil result without error is reported as missing data
+	if _, err := src.Fetch(context.Background(), nil, SourceRequest{}); err == nil {
+		t.Error("Expected error for empty fetch result")
+	}
+
+	src.Apply(data, &models.FloodRiskData{RiskLevel: "Low"})
+	value, ok := src.Value(data)
+	if !ok || value.(*models.FloodRiskData).RiskLevel != "Low" {
+		t.Errorf("Expected applied flood risk value, got %v", value)
+	}
+	if status, _ := src.Unavailable(); status != StatusError {
+		t.Errorf("Expected default unavailable status %q, got %q", StatusError, status)
+	}
+}
+
+func TestSource_ExtraField(t *testing.T) {
+	src := &source[map[string]int]{name: "Test Extra"}
+	data := &ComprehensivePropertyData{}
+
+	src.Apply(data, map[string]int{"count": 3})
+	value, ok := src.Value(data)
+	if !ok {
+		t.Fatal("Expected value stored in Extra")
+	}
+	if value.(map[string]int)["count"] != 3 {
+		t.Errorf("Unexpected Extra value: %v", value)
+	}
+}
+
+func TestSourceRequest_Missing(t *testing.T) {
+	req := SourceRequest{Lat: 52.37, Lon: 4.89}
+	if reason := req.missing(RequiresCoordinates); reason != "" {
+		t.Errorf("Expected coordinates to satisfy requirement, got %q", reason)
+	}
+	if reason := req.missing(RequiresNeighborhoodCode); reason == "" {
+		t.Error("Expected missing neighborhood code to be reported")
+	}
+	if reason := req.missing(RequiresBAGID | RequiresCoordinates); reason != "BAG ID not available" {
+		t.Errorf("Expected missing BAG ID, got %q", reason)
+	}
+}
+
+func TestAreaContext_FreshAndMerge(t *testing.T) {
+	weather := &source[*models.KNMIWeatherData]{
+		name:  "Test Weather",
+		ttl:   30 * time.Minute,
+		field: func(d *ComprehensivePropertyData) **models.KNMIWeatherData { return &d.Weather },
+	}
+	green := &source[*models.GreenSpacesData]{
+		name:  "Test Green",
+		ttl:   24 * time.Hour,
+		field: func(d *ComprehensivePropertyData) **models.GreenSpacesData { return &d.GreenSpaces },
+	}
+	flood := &source[*models.FloodRiskData]{
+		name:  "Test Flood",
+		field: func(d *ComprehensivePropertyData) **models.FloodRiskData { return &d.FloodRisk },
+	}
+	sources := []DataSource{weather, green, flood}
+
+	now := time.Now()
+	ac := &areaContext{
+		Data: ComprehensivePropertyData{
+			Weather:     &models.KNMIWeatherData{Temperature: 12},
+			GreenSpaces: &models.GreenSpacesData{TotalGreenArea: 500},
+		},
+		FetchedAt: map[string]time.Time{
+			"Test Weather": now.Add(-time.Hour),
+			"Test Green":   now.Add(-time.Hour),
+		},
+	}
+
+	if _, ok := ac.fresh(weather, now); ok {
+		t.Error("Expected weather older than its TTL to be stale")
+	}
+	if _, ok := ac.fresh(green, now); !ok {
+		t.Error("Expected green spaces within TTL to be fresh")
+	}
+	if _, ok := ac.fresh(flood, now); ok {
+		t.Error("Expected property-specific source never to be served from area cache")
+	}
+
+	var nilContext *areaContext
+	if _, ok := nilContext.fresh(green, now); ok {
+		t.Error("Expected nil context to miss")
+	}
+
+	// Weather refreshed, green spaces kept from the cache
+	data := &ComprehensivePropertyData{Weather: &models.KNMIWeatherData{Temperature: 15}}
+	updated := ac.merge(sources, data, map[string]time.Time{"Test Weather": now})
+	if updated.Data.Weather == nil || updated.Data.Weather.Temperature != 15 {
+		t.Errorf("Expected refreshed weather in merged context, got %+v", updated.Data.Weather)
+	}
+	if updated.Data.GreenSpaces == nil || !updated.FetchedAt["Test Green"].Equal(now.Add(-time.Hour)) {
+		t.Error("Expected cached green spaces to keep their original fetch time")
+	}
+	if got := updated.ttl(sources); got != 24*time.Hour {
+		t.Errorf("Expected context TTL of 24h, got %v", got)
+	}
+}
+
+func TestSource_ApplyNilClears(t *testing.T) {
+	field := &source[*models.FloodRiskData]{
+		name:  "Test Flood",
+		field: func(d *ComprehensivePropertyData) **models.FloodRiskData { return &d.FloodRisk },
+	}
+	extra := &source[map[string]int]{name: "Test Extra"}
+
+	data := &ComprehensivePropertyData{}
+	field.Apply(data, &models.FloodRiskData{RiskLevel: "Low"})
+	extra.Apply(data, map[string]int{"count": 3})
+
+	field.Apply(data, nil)
+	extra.Apply(data, nil)
+	if _, ok := field.Value(data); ok {
+		t.Error("Expected field to be cleared")
+	}
+	if _, ok := extra.Value(data); ok {
+		t.Error("Expected Extra entry to be cleared")
+	}
+}
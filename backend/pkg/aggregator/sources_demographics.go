@@ -0,0 +1,65 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Demographics & Neighborhood sources
+func init() {
+	Register(&source[*models.CBSPopulationData]{
+		name:     "CBS Population",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.DemographicsDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch population data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.CBSPopulationData, error) {
+			return c.FetchCBSPopulationData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.CBSPopulationData { return &d.Population },
+	})
+
+	Register(&source[*models.CBSSquareStatsData]{
+		name:     "CBS Square Statistics",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.DemographicsDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch square stats",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.CBSSquareStatsData, error) {
+			return c.FetchCBSSquareStats(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.CBSSquareStatsData { return &d.SquareStats },
+	})
+
+	Register(&source[*models.CBSStatLineData]{
+		name:     "CBS StatLine",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		requires: RequiresRegionCode,
+		message:  "Failed to fetch StatLine data",
+		dataset:  "CBS 84286NED",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.CBSStatLineData, error) {
+			return c.FetchCBSStatLineData(ctx, req.Config, req.RegionCode)
+		},
+		field: func(d *ComprehensivePropertyData) **models.CBSStatLineData { return &d.StatLineData },
+	})
+
+	// Legacy CBS neighbourhood data
+	Register(&source[*models.CBSData]{
+		name:     "CBS",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		requires: RequiresNeighborhoodCode,
+		message:  "Failed to fetch CBS data",
+		dataset:  "CBS 85039NED",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.CBSData, error) {
+			return c.FetchCBSData(ctx, req.Config, req.NeighborhoodCode)
+		},
+		field: func(d *ComprehensivePropertyData) **models.CBSData { return &d.CBSData },
+	})
+}
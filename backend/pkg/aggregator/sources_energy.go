@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Energy & Sustainability sources
+func init() {
+	Register(&source[*models.EnergyLabelData]{
+		name:     "EP-Online Energy Label",
+		tier:     TierFree,
+		phase:    PhaseProperty,
+		requires: RequiresBAGID,
+		message:  "No registered energy label",
+		dataset:  "EP-Online PandEnergielabel v5",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.EnergyLabelData, error) {
+			if req.VerblijfsobjectID != "" {
+				return c.FetchEnergyLabel(ctx, req.Config, req.VerblijfsobjectID)
+			}
+			return c.FetchEnergyLabelByAddress(ctx, req.Config, req.Postcode, req.HouseNumber)
+		},
+		field: func(d *ComprehensivePropertyData) **models.EnergyLabelData { return &d.EnergyLabel },
+	})
+
+	Register(&source[*models.EnergyClimateData]{
+		name:        "Altum Energy & Climate",
+		tier:        TierPremium,
+		phase:       PhaseSupplemental,
+		requires:    RequiresBAGID,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.EnergyClimateData, error) {
+			return c.FetchEnergyClimateData(ctx, req.Config, req.BAGID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.EnergyClimateData { return &d.EnergyClimate },
+	})
+
+	Register(&source[*models.SustainabilityData]{
+		name:        "Altum Sustainability",
+		tier:        TierPremium,
+		phase:       PhaseSupplemental,
+		requires:    RequiresBAGID,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.SustainabilityData, error) {
+			return c.FetchSustainabilityData(ctx, req.Config, req.BAGID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.SustainabilityData { return &d.Sustainability },
+	})
+}
@@ -0,0 +1,117 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Weather, Climate, Soil & Environmental Quality sources
+func init() {
+	Register(&source[*models.KNMIWeatherData]{
+		name:     "KNMI Weather",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.WeatherDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch weather data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.KNMIWeatherData, error) {
+			return c.FetchKNMIWeatherData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.KNMIWeatherData { return &d.Weather },
+	})
+
+	Register(&source[*models.KNMISolarData]{
+		name:     "KNMI Solar",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.WeatherDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch solar data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.KNMISolarData, error) {
+			return c.FetchKNMISolarData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.KNMISolarData { return &d.SolarPotential },
+	})
+
+	Register(&source[*models.WURSoilData]{
+		name:        "WUR Soil Physicals",
+		tier:        TierFreemium,
+		phase:       PhaseArea,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API agreement required",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.WURSoilData, error) {
+			return c.FetchWURSoilData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.WURSoilData { return &d.SoilData },
+	})
+
+	Register(&source[*models.SubsidenceData]{
+		name:        "SkyGeo Subsidence",
+		tier:        TierPremium,
+		phase:       PhaseArea,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.SubsidenceData, error) {
+			return c.FetchSubsidenceData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.SubsidenceData { return &d.Subsidence },
+	})
+
+	Register(&source[*models.SoilQualityData]{
+		name:        "Soil Quality",
+		tier:        TierFreemium,
+		phase:       PhaseArea,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key required",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.SoilQualityData, error) {
+			return c.FetchSoilQualityData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.SoilQualityData { return &d.SoilQuality },
+	})
+
+	Register(&source[*models.BROSoilMapData]{
+		name:     "BRO Soil Map",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.StaticDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch BRO data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.BROSoilMapData, error) {
+			return c.FetchBROSoilMapData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.BROSoilMapData { return &d.BROSoilMap },
+	})
+
+	Register(&source[*models.AirQualityData]{
+		name:     "Luchtmeetnet Air Quality",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.AirQualityDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch air quality data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.AirQualityData, error) {
+			return c.FetchAirQualityData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.AirQualityData { return &d.AirQuality },
+	})
+
+	Register(&source[*models.NoisePollutionData]{
+		name:        "Noise Pollution",
+		tier:        TierFreemium,
+		phase:       PhaseArea,
+		ttl:         cache.PropertyDataTTL,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.NoisePollutionData, error) {
+			return c.FetchNoisePollutionData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.NoisePollutionData { return &d.NoisePollution },
+	})
+}
@@ -0,0 +1,77 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Infrastructure & Facilities sources
+func init() {
+	Register(&source[*models.GreenSpacesData]{
+		name:     "Green Spaces",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.PropertyDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch green spaces",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.GreenSpacesData, error) {
+			return c.FetchGreenSpacesData(ctx, req.Config, req.Lat, req.Lon, 1000)
+		},
+		field: func(d *ComprehensivePropertyData) **models.GreenSpacesData { return &d.GreenSpaces },
+	})
+
+	Register(&source[*models.EducationData]{
+		name:     "Education Facilities",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.PropertyDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch education data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.EducationData, error) {
+			return c.FetchEducationData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.EducationData { return &d.Education },
+	})
+
+	Register(&source[*models.BuildingPermitsData]{
+		name:        "Building Permits",
+		tier:        TierFreemium,
+		phase:       PhaseArea,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API varies by region",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.BuildingPermitsData, error) {
+			return c.FetchBuildingPermitsData(ctx, req.Config, req.Lat, req.Lon, 1000)
+		},
+		field: func(d *ComprehensivePropertyData) **models.BuildingPermitsData { return &d.BuildingPermits },
+	})
+
+	Register(&source[*models.FacilitiesData]{
+		name:     "Facilities & Amenities",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.PropertyDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch facilities",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.FacilitiesData, error) {
+			return c.FetchFacilitiesData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.FacilitiesData { return &d.Facilities },
+	})
+
+	Register(&source[*models.AHNHeightData]{
+		name:     "AHN Height Model",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch elevation data",
+		dataset:  "AHN4 DTM/DSM 0.5m",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.AHNHeightData, error) {
+			return c.FetchAHNHeightData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.AHNHeightData { return &d.Elevation },
+	})
+}
@@ -0,0 +1,51 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Traffic & Mobility sources
+func init() {
+	Register(&source[[]models.NDWTrafficData]{
+		name:     "NDW Traffic",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.TrafficDataTTL,
+		requires: RequiresCoordinates,
+		message:  "No traffic data available",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) ([]models.NDWTrafficData, error) {
+			return c.FetchNDWTrafficData(ctx, req.Config, req.Lat, req.Lon, 1000)
+		},
+		field: func(d *ComprehensivePropertyData) *[]models.NDWTrafficData { return &d.TrafficData },
+	})
+
+	Register(&source[*models.OpenOVTransportData]{
+		name:     "openOV Public Transport",
+		tier:     TierFree,
+		phase:    PhaseArea,
+		ttl:      cache.PropertyDataTTL,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch transport data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.OpenOVTransportData, error) {
+			return c.FetchOpenOVData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.OpenOVTransportData { return &d.PublicTransport },
+	})
+
+	Register(&source[*models.ParkingData]{
+		name:        "Parking Availability",
+		tier:        TierFreemium,
+		phase:       PhaseArea,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API varies by municipality",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.ParkingData, error) {
+			return c.FetchParkingData(ctx, req.Config, req.Lat, req.Lon, 500)
+		},
+		field: func(d *ComprehensivePropertyData) **models.ParkingData { return &d.ParkingData },
+	})
+}
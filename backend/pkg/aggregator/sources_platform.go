@@ -0,0 +1,51 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Comprehensive Platform sources
+func init() {
+	// The cadastral parcel is specific to the address, so it isn't shared across the postcode
+	Register(&source[*models.PDOKPlatformData]{
+		name:     "PDOK Platform",
+		tier:     TierFree,
+		phase:    PhaseSupplemental,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch PDOK data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.PDOKPlatformData, error) {
+			return c.FetchPDOKPlatformData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.PDOKPlatformData { return &d.PDOKData },
+	})
+
+	Register(&source[*models.StratopoEnvironmentData]{
+		name:        "Stratopo Environment",
+		tier:        TierPremium,
+		phase:       PhaseSupplemental,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.StratopoEnvironmentData, error) {
+			return c.FetchStratopoEnvironmentData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.StratopoEnvironmentData { return &d.StratopoEnvironment },
+	})
+
+	// Zoning is resolved at the exact point, so it has no ttl and isn't shared across the postcode
+	Register(&source[*models.LandUseData]{
+		name:        "Land Use & Zoning",
+		tier:        TierFreemium,
+		phase:       PhaseSupplemental,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.LandUseData, error) {
+			return c.FetchLandUseData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.LandUseData { return &d.LandUse },
+	})
+}
@@ -0,0 +1,98 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Property & Land Data sources
+func init() {
+	Register(&source[*models.KadasterObjectInfo]{
+		name:        "Kadaster Object Info",
+		tier:        TierPremium,
+		phase:       PhaseProperty,
+		requires:    RequiresBAGID,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.KadasterObjectInfo, error) {
+			return c.FetchKadasterObjectInfo(ctx, req.Config, req.BAGID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.KadasterObjectInfo { return &d.KadasterInfo },
+	})
+
+	Register(&source[*models.AltumWOZData]{
+		name:        "Altum WOZ",
+		tier:        TierPremium,
+		phase:       PhaseProperty,
+		requires:    RequiresBAGID,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.AltumWOZData, error) {
+			return c.FetchAltumWOZData(ctx, req.Config, req.BAGID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.AltumWOZData { return &d.WOZData },
+	})
+
+	Register(&source[*models.MatrixianPropertyValue]{
+		name:        "Matrixian Property Value+",
+		tier:        TierPremium,
+		phase:       PhaseProperty,
+		requires:    RequiresBAGID | RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.MatrixianPropertyValue, error) {
+			return c.FetchPropertyValuePlus(ctx, req.Config, req.BAGID, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.MatrixianPropertyValue { return &d.MarketValuation },
+	})
+
+	Register(&source[*models.TransactionHistory]{
+		name:        "Altum Transactions",
+		tier:        TierPremium,
+		phase:       PhaseProperty,
+		requires:    RequiresBAGID,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.TransactionHistory, error) {
+			return c.FetchTransactionHistory(ctx, req.Config, req.BAGID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.TransactionHistory { return &d.TransactionHistory },
+	})
+
+	Register(&source[*models.MonumentData]{
+		name:     "Monument Status",
+		tier:     TierFree,
+		phase:    PhaseProperty,
+		requires: RequiresPandID,
+		message:  "No monument data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.MonumentData, error) {
+			data, err := c.FetchMonumentData(ctx, req.Config, req.PandID)
+			if err != nil || (req.Lat == 0 && req.Lon == 0) {
+				return data, err
+			}
+			// Protected areas are area-based, so a failed lookup only loses the gezicht
+			area, err := c.FetchProtectedArea(ctx, req.Config, req.Lat, req.Lon)
+			if err != nil {
+				logutil.Debugf("[Monument] Protected area lookup failed: %v", err)
+			}
+			data.ProtectedArea = area
+			return data, nil
+		},
+		field: func(d *ComprehensivePropertyData) **models.MonumentData { return &d.MonumentStatus },
+	})
+
+	Register(&source[*models.BAGBuildingData]{
+		name:     "BAG Building",
+		tier:     TierFree,
+		phase:    PhaseProperty,
+		requires: RequiresPandID,
+		message:  "No BAG building data",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.BAGBuildingData, error) {
+			return c.FetchBAGBuildingData(ctx, req.Config, req.VerblijfsobjectID, req.PandID)
+		},
+		field: func(d *ComprehensivePropertyData) **models.BAGBuildingData { return &d.BAGBuilding },
+	})
+}
@@ -0,0 +1,62 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Water, Safety & Aviation risk sources
+func init() {
+	Register(&source[*models.FloodRiskData]{
+		name:     "Flood Risk",
+		tier:     TierFree,
+		phase:    PhaseProperty,
+		requires: RequiresCoordinates,
+		message:  "Failed to fetch flood risk",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.FloodRiskData, error) {
+			return c.FetchFloodRiskData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.FloodRiskData { return &d.FloodRisk },
+	})
+
+	Register(&source[*models.WaterQualityData]{
+		name:        "Digital Delta Water Quality",
+		tier:        TierFreemium,
+		phase:       PhaseProperty,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "Water authority account required",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.WaterQualityData, error) {
+			return c.FetchWaterQualityData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.WaterQualityData { return &d.WaterQuality },
+	})
+
+	Register(&source[*models.SafetyData]{
+		name:        "CBS Safety Experience",
+		tier:        TierFreemium,
+		phase:       PhaseProperty,
+		requires:    RequiresNeighborhoodCode,
+		unavailable: StatusNotConfigured,
+		message:     "API key required",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.SafetyData, error) {
+			return c.FetchSafetyData(ctx, req.Config, req.NeighborhoodCode)
+		},
+		field: func(d *ComprehensivePropertyData) **models.SafetyData { return &d.Safety },
+	})
+
+	Register(&source[*models.SchipholFlightData]{
+		name:        "Schiphol Flight Noise",
+		tier:        TierPremium,
+		phase:       PhaseProperty,
+		requires:    RequiresCoordinates,
+		unavailable: StatusNotConfigured,
+		message:     "API key not configured",
+		fetch: func(ctx context.Context, c *apiclient.ApiClient, req SourceRequest) (*models.SchipholFlightData, error) {
+			return c.FetchSchipholFlightData(ctx, req.Config, req.Lat, req.Lon)
+		},
+		field: func(d *ComprehensivePropertyData) **models.SchipholFlightData { return &d.SchipholFlights },
+	})
+}
@@ -0,0 +1,353 @@
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// AHN4 coverages published by the PDOK WCS (0.5 m resolution)
+const (
+	ahnTerrainCoverage = "dtm_05m" // ground level, no data under buildings and water
+	ahnSurfaceCoverage = "dsm_05m" // highest surface including buildings and trees
+	ahnSource          = "AHN4 0.5m DTM/DSM (PDOK WCS)"
+)
+
+// Sampling windows around the parcel point, in RD metres
+const (
+	ahnAreaRadius         = 100.0 // surrounding area for slope and relative height
+	ahnAreaCells          = 41    // area is resampled to 5 m cells
+	ahnPointRadius        = 1.0   // native-resolution window at the point itself
+	ahnSlopeRadius        = 25.0  // cells used to fit the terrain plane
+	ahnGroundSearchRadius = 15.0  // nearest ground level when the point is under a building
+	ahnMinBuildingHeight  = 2.0   // DSM-DTM differences below this are terrain noise
+	ahnMaxCoverageBytes   = 16 << 20
+)
+
+// unavailableAHNHeightData returns an explicit unavailable result instead of an estimate
+func unavailableAHNHeightData(reason string) *models.AHNHeightData {
+	return &models.AHNHeightData{
+		Available:         false,
+		UnavailableReason: reason,
+		Surrounding:       []float64{},
+	}
+}
+
+// FetchAHNHeightData samples the AHN4 terrain (DTM) and surface (DSM) models around a location
+// Documentation: https://www.pdok.nl/ogc-webservices/-/article/actueel-hoogtebestand-nederland-ahn
+func (c *ApiClient) FetchAHNHeightData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.AHNHeightData, error) {
+	if cfg.AHNHeightModelApiURL == "" {
+		markNotConfigured(ctx, "AHNHeightModelApiURL")
+		return unavailableAHNHeightData("AHN height model not configured"), nil
+	}
+
+	x, y := wgs84ToRD(lat, lon)
+	logutil.Debugf("[AHN] Sampling around RD %.1f, %.1f", x, y)
+
+	area, err := c.fetchAHNCoverage(ctx, cfg.AHNHeightModelApiURL, ahnTerrainCoverage, x, y, ahnAreaRadius, ahnAreaCells)
+	if err != nil {
+		logutil.Debugf("[AHN] Terrain area request failed: %v", err)
+		markFailed(ctx, err)
+		return unavailableAHNHeightData("AHN terrain model request failed"), nil
+	}
+
+	// Point windows are best effort: the area grid still gives ground level and slope
+	ground, err := c.fetchAHNCoverage(ctx, cfg.AHNHeightModelApiURL, ahnTerrainCoverage, x, y, ahnPointRadius, 0)
+	if err != nil {
+		logutil.Debugf("[AHN] Terrain point request failed: %v", err)
+	}
+	surface, err := c.fetchAHNCoverage(ctx, cfg.AHNHeightModelApiURL, ahnSurfaceCoverage, x, y, ahnPointRadius, 0)
+	if err != nil {
+		logutil.Debugf("[AHN] Surface point request failed: %v", err)
+	}
+
+	result := analyseAHN(area, ground, surface)
+	if !result.Available {
+		markEmpty(ctx, result.UnavailableReason)
+		return result, nil
+	}
+
+	logutil.Debugf("[AHN] Result: elevation=%.2f, slope=%.2f, relative=%.2f", result.Elevation, result.TerrainSlope, result.RelativeHeight)
+	return result, nil
+}
+
+// fetchAHNCoverage requests a square window of an AHN coverage as GeoTIFF.
+// When cells > 0 the window is resampled to cells x cells.
+func (c *ApiClient) fetchAHNCoverage(ctx context.Context, baseURL, coverage string, x, y, radius float64, cells int) (*rasterGrid, error) {
+	params := url.Values{}
+	params.Set("service", "WCS")
+	params.Set("version", "2.0.1")
+	params.Set("request", "GetCoverage")
+	params.Set("coverageId", coverage)
+	params.Set("format", "image/tiff")
+	params.Add("subset", fmt.Sprintf("x(%.2f,%.2f)", x-radius, x+radius))
+	params.Add("subset", fmt.Sprintf("y(%.2f,%.2f)", y-radius, y+radius))
+	if cells > 0 {
+		params.Set("scalesize", fmt.Sprintf("x(%d),y(%d)", cells, cells))
+	}
+
+	sep := "?"
+	if strings.Contains(baseURL, "?") {
+		sep = "&"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+sep+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doAs("AHN", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", coverage, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ahnMaxCoverageBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	// WCS exception reports are XML, sometimes with a 200 status
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		msg := string(body)
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		return nil, fmt.Errorf("%s exception: %s", coverage, msg)
+	}
+
+	return decodeGeoTIFF(body)
+}
+
+// analyseAHN derives elevation, slope, relative height and building height from the sampled grids.
+// area is required; ground and surface are the native-resolution point windows and may be nil.
+func analyseAHN(area, ground, surface *rasterGrid) *models.AHNHeightData {
+	if area == nil || area.Width == 0 || area.Height == 0 {
+		return unavailableAHNHeightData("no AHN terrain data at this location")
+	}
+
+	cellX := 2 * ahnAreaRadius / float64(area.Width)
+	cellY := 2 * ahnAreaRadius / float64(area.Height)
+	centreCol, centreRow := float64(area.Width-1)/2, float64(area.Height-1)/2
+
+	// Collect ground cells with their offset from the point in metres (north up)
+	type cell struct{ dx, dy, z float64 }
+	var cells []cell
+	for row := 0; row < area.Height; row++ {
+		for col := 0; col < area.Width; col++ {
+			if z, ok := area.at(col, row); ok {
+				cells = append(cells, cell{(float64(col) - centreCol) * cellX, (centreRow - float64(row)) * cellY, z})
+			}
+		}
+	}
+	if len(cells) == 0 {
+		return unavailableAHNHeightData("no AHN terrain data at this location")
+	}
+
+	// Ground level: the point itself, else the average of nearby ground (DTM has gaps under buildings)
+	elevation, ok := meanValid(ground)
+	if !ok {
+		var sum float64
+		var n int
+		for _, c := range cells {
+			if math.Hypot(c.dx, c.dy) <= ahnGroundSearchRadius {
+				sum += c.z
+				n++
+			}
+		}
+		if n == 0 {
+			return unavailableAHNHeightData("no AHN ground level near this location")
+		}
+		elevation = sum / float64(n)
+	}
+
+	// Surrounding statistics and slope from a least-squares plane z = a*dx + b*dy + c
+	minZ, maxZ, sumZ := math.Inf(1), math.Inf(-1), 0.0
+	var n, sx, sy, sz, sxx, syy, sxy, sxz, syz float64
+	for _, c := range cells {
+		minZ, maxZ, sumZ = math.Min(minZ, c.z), math.Max(maxZ, c.z), sumZ+c.z
+		if math.Hypot(c.dx, c.dy) > ahnSlopeRadius {
+			continue
+		}
+		n++
+		sx, sy, sz = sx+c.dx, sy+c.dy, sz+c.z
+		sxx, syy, sxy = sxx+c.dx*c.dx, syy+c.dy*c.dy, sxy+c.dx*c.dy
+		sxz, syz = sxz+c.dx*c.z, syz+c.dy*c.z
+	}
+	avgZ := sumZ / float64(len(cells))
+
+	var slope float64
+	if a, b, ok := solvePlane(n, sx, sy, sz, sxx, syy, sxy, sxz, syz); ok {
+		slope = math.Atan(math.Hypot(a, b)) * 180 / math.Pi
+	}
+
+	result := &models.AHNHeightData{
+		Available:      true,
+		Elevation:      round2(elevation),
+		TerrainSlope:   round2(slope),
+		RelativeHeight: round2(elevation - avgZ),
+		SurroundingMin: round2(minZ),
+		SurroundingMax: round2(maxZ),
+		SurroundingAvg: round2(avgZ),
+		FloodRisk:      ahnFloodRisk(elevation),
+		ViewPotential:  ahnViewPotential(elevation - avgZ),
+		Surrounding:    compassSamples(area),
+		Source:         ahnSource,
+	}
+
+	// Building height: highest surface at the point minus ground level
+	if top, ok := maxValid(surface); ok {
+		height := top - elevation
+		if height < ahnMinBuildingHeight {
+			height = 0
+		}
+		top, height = round2(top), round2(height)
+		result.SurfaceElevation = &top
+		result.BuildingHeight = &height
+	}
+
+	return result
+}
+
+// solvePlane solves the least-squares normal equations for z = a*x + b*y + c
+func solvePlane(n, sx, sy, sz, sxx, syy, sxy, sxz, syz float64) (a, b float64, ok bool) {
+	if n < 3 {
+		return 0, 0, false
+	}
+	// | sxx sxy sx | |a|   | sxz |
+	// | sxy syy sy | |b| = | syz |
+	// | sx  sy  n  | |c|   | sz  |
+	det := sxx*(syy*n-sy*sy) - sxy*(sxy*n-sy*sx) + sx*(sxy*sy-syy*sx)
+	if math.Abs(det) < 1e-9 {
+		return 0, 0, false
+	}
+	a = (sxz*(syy*n-sy*sy) - sxy*(syz*n-sy*sz) + sx*(syz*sy-syy*sz)) / det
+	b = (sxx*(syz*n-sz*sy) - sxz*(sxy*n-sy*sx) + sx*(sxy*sz-syz*sx)) / det
+	return a, b, true
+}
+
+// compassSamples returns the valid ground levels at the corners and edge midpoints of the grid
+func compassSamples(g *rasterGrid) []float64 {
+	midCol, midRow := g.Width/2, g.Height/2
+	lastCol, lastRow := g.Width-1, g.Height-1
+	points := [][2]int{
+		{midCol, 0}, {lastCol, 0}, {lastCol, midRow}, {lastCol, lastRow},
+		{midCol, lastRow}, {0, lastRow}, {0, midRow}, {0, 0},
+	}
+	samples := []float64{}
+	for _, p := range points {
+		if z, ok := g.at(p[0], p[1]); ok {
+			samples = append(samples, round2(z))
+		}
+	}
+	return samples
+}
+
+// meanValid averages the valid cells of a grid
+func meanValid(g *rasterGrid) (float64, bool) {
+	if g == nil {
+		return 0, false
+	}
+	var sum float64
+	var n int
+	for row := 0; row < g.Height; row++ {
+		for col := 0; col < g.Width; col++ {
+			if z, ok := g.at(col, row); ok {
+				sum += z
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+// maxValid returns the highest valid cell of a grid
+func maxValid(g *rasterGrid) (float64, bool) {
+	if g == nil {
+		return 0, false
+	}
+	top, found := math.Inf(-1), false
+	for row := 0; row < g.Height; row++ {
+		for col := 0; col < g.Width; col++ {
+			if z, ok := g.at(col, row); ok {
+				top, found = math.Max(top, z), true
+			}
+		}
+	}
+	return top, found
+}
+
+// ahnFloodRisk classifies ground level relative to NAP
+func ahnFloodRisk(elevation float64) string {
+	switch {
+	case elevation < -2.0:
+		return "High"
+	case elevation < 1.0:
+		return "Medium"
+	}
+	return "Low"
+}
+
+// ahnViewPotential classifies height relative to the surrounding terrain
+func ahnViewPotential(relative float64) string {
+	switch {
+	case relative > 5:
+		return "Excellent"
+	case relative > 2:
+		return "Good"
+	case relative > -1:
+		return "Fair"
+	}
+	return "Poor"
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// wgs84ToRD converts WGS84 coordinates to Rijksdriehoek (EPSG:28992) metres using
+// the standard polynomial approximation, accurate to about a metre within the Netherlands
+func wgs84ToRD(lat, lon float64) (x, y float64) {
+	dLat := 0.36 * (lat - 52.15517440)
+	dLon := 0.36 * (lon - 5.38720621)
+
+	x = 155000 +
+		190094.945*dLon +
+		-11832.228*dLat*dLon +
+		-114.221*dLat*dLat*dLon +
+		-32.391*dLon*dLon*dLon +
+		-0.705*dLat +
+		-2.340*dLat*dLat*dLat*dLon +
+		-0.608*dLat*dLon*dLon*dLon +
+		-0.008*dLon*dLon +
+		0.148*dLat*dLat*dLon*dLon*dLon
+
+	y = 463000 +
+		309056.544*dLat +
+		3638.893*dLon*dLon +
+		73.077*dLat*dLat +
+		-157.984*dLat*dLon*dLon +
+		59.788*dLat*dLat*dLat +
+		0.433*dLon +
+		-6.439*dLat*dLat*dLon*dLon +
+		-0.032*dLat*dLon +
+		0.092*dLon*dLon*dLon*dLon +
+		-0.054*dLat*dLon*dLon*dLon*dLon
+
+	return x, y
+}
@@ -0,0 +1,280 @@
+package apiclient
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+// encodeTestTIFF builds a little-endian float32 TIFF with a single strip, or
+// deflate-compressed tiles when tile > 0
+func encodeTestTIFF(t *testing.T, width, height int, values []float32, tile int, noData string) []byte {
+	t.Helper()
+
+	blockW, blockH := width, height
+	if tile > 0 {
+		blockW, blockH = tile, tile
+	}
+	across, down := (width+blockW-1)/blockW, (height+blockH-1)/blockH
+
+	var blocks [][]byte
+	for by := 0; by < down; by++ {
+		for bx := 0; bx < across; bx++ {
+			raw := new(bytes.Buffer)
+			for row := 0; row < blockH; row++ {
+				for col := 0; col < blockW; col++ {
+					x, y := bx*blockW+col, by*blockH+row
+					v := float32(math.NaN())
+					if x < width && y < height {
+						v = values[y*width+x]
+					}
+					binary.Write(raw, binary.LittleEndian, v)
+				}
+			}
+			if tile == 0 {
+				blocks = append(blocks, raw.Bytes())
+				continue
+			}
+			compressed := new(bytes.Buffer)
+			zw := zlib.NewWriter(compressed)
+			zw.Write(raw.Bytes())
+			zw.Close()
+			blocks = append(blocks, compressed.Bytes())
+		}
+	}
+
+	// Layout: header, block data, offset/count arrays, no-data text, IFD
+	buf := new(bytes.Buffer)
+	buf.Write([]byte{'I', 'I', 42, 0, 0, 0, 0, 0})
+	var offsets, counts []uint32
+	for _, b := range blocks {
+		offsets = append(offsets, uint32(buf.Len()))
+		counts = append(counts, uint32(len(b)))
+		buf.Write(b)
+	}
+	offsetsAt := uint32(buf.Len())
+	binary.Write(buf, binary.LittleEndian, offsets)
+	countsAt := uint32(buf.Len())
+	binary.Write(buf, binary.LittleEndian, counts)
+	noDataAt := uint32(buf.Len())
+	buf.WriteString(noData + "\x00")
+
+	type entry struct {
+		tag, typ     uint16
+		count, value uint32
+	}
+	arrayValue := func(at uint32, vals []uint32) uint32 {
+		if len(vals) == 1 {
+			return vals[0]
+		}
+		return at
+	}
+	compression := uint32(1)
+	offsetTag, countTag := uint16(tiffTagStripOffsets), uint16(tiffTagStripByteCounts)
+	entries := []entry{
+		{tiffTagImageWidth, 4, 1, uint32(width)},
+		{tiffTagImageLength, 4, 1, uint32(height)},
+		{tiffTagBitsPerSample, 3, 1, 32},
+	}
+	if tile > 0 {
+		compression = 8
+		offsetTag, countTag = tiffTagTileOffsets, tiffTagTileByteCounts
+	}
+	entries = append(entries, entry{tiffTagCompression, 3, 1, compression})
+	if tile == 0 {
+		entries = append(entries,
+			entry{offsetTag, 4, uint32(len(offsets)), arrayValue(offsetsAt, offsets)},
+			entry{tiffTagSamplesPerPixel, 3, 1, 1},
+			entry{tiffTagRowsPerStrip, 4, 1, uint32(height)},
+			entry{countTag, 4, uint32(len(counts)), arrayValue(countsAt, counts)},
+		)
+	} else {
+		entries = append(entries,
+			entry{tiffTagSamplesPerPixel, 3, 1, 1},
+			entry{tiffTagTileWidth, 3, 1, uint32(tile)},
+			entry{tiffTagTileLength, 3, 1, uint32(tile)},
+			entry{offsetTag, 4, uint32(len(offsets)), arrayValue(offsetsAt, offsets)},
+			entry{countTag, 4, uint32(len(counts)), arrayValue(countsAt, counts)},
+		)
+	}
+	entries = append(entries, entry{tiffTagSampleFormat, 3, 1, 3})
+	if noData != "" {
+		entries = append(entries, entry{tiffTagGDALNoData, 2, uint32(len(noData) + 1), noDataAt})
+	}
+
+	ifdAt := uint32(buf.Len())
+	binary.Write(buf, binary.LittleEndian, uint16(len(entries)))
+	for _, e := range entries {
+		binary.Write(buf, binary.LittleEndian, e.tag)
+		binary.Write(buf, binary.LittleEndian, e.typ)
+		binary.Write(buf, binary.LittleEndian, e.count)
+		if e.typ == 3 && e.count == 1 {
+			binary.Write(buf, binary.LittleEndian, uint16(e.value))
+			binary.Write(buf, binary.LittleEndian, uint16(0))
+		} else {
+			binary.Write(buf, binary.LittleEndian, e.value)
+		}
+	}
+	binary.Write(buf, binary.LittleEndian, uint32(0))
+
+	out := buf.Bytes()
+	binary.LittleEndian.PutUint32(out[4:8], ifdAt)
+	return out
+}
+
+func TestDecodeGeoTIFF(t *testing.T) {
+	values := []float32{1, 2, 3, -9999, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	for _, tc := range []struct {
+		name string
+		tile int
+	}{
+		{"strip", 0},
+		{"deflate tiles", 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			grid, err := decodeGeoTIFF(encodeTestTIFF(t, 4, 3, values, tc.tile, "-9999"))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if grid.Width != 4 || grid.Height != 3 {
+				t.Fatalf("Expected 4x3 grid, got %dx%d", grid.Width, grid.Height)
+			}
+			if v, ok := grid.at(1, 2); !ok || v != 10 {
+				t.Errorf("Expected 10 at (1,2), got %v (%v)", v, ok)
+			}
+			if _, ok := grid.at(3, 0); ok {
+				t.Error("Expected no-data cell to be invalid")
+			}
+			if _, ok := grid.at(4, 0); ok {
+				t.Error("Expected out-of-range cell to be invalid")
+			}
+		})
+	}
+
+	if _, err := decodeGeoTIFF([]byte("<ExceptionReport/>")); err == nil {
+		t.Error("Expected error for non-TIFF input")
+	}
+}
+
+func TestFetchAHNHeightData(t *testing.T) {
+	const cells = ahnAreaCells
+	area := make([]float32, cells*cells)
+	for row := 0; row < cells; row++ {
+		for col := 0; col < cells; col++ {
+			area[row*cells+col] = 0.1 * float32(col) // rises 0.1 m per cell to the east
+		}
+	}
+	noData := float32(math.MaxFloat32)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("request") != "GetCoverage" || len(q["subset"]) != 2 {
+			t.Errorf("Unexpected WCS request: %s", r.URL.RawQuery)
+		}
+		switch {
+		case q.Get("coverageId") == ahnTerrainCoverage && q.Get("scalesize") != "":
+			w.Write(encodeTestTIFF(t, cells, cells, area, 0, ""))
+		case q.Get("coverageId") == ahnTerrainCoverage:
+			// The point is under a building, so the DTM has no data there
+			w.Write(encodeTestTIFF(t, 2, 2, []float32{noData, noData, noData, noData}, 0, ""))
+		case q.Get("coverageId") == ahnSurfaceCoverage:
+			w.Write(encodeTestTIFF(t, 2, 2, []float32{11.5, 12, 11.8, 11.9}, 0, ""))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AHNHeightModelApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	data, err := client.FetchAHNHeightData(ctx, cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !data.Available || prov.Status() != StatusOK {
+		t.Fatalf("Expected available data, got %+v (%s)", data, prov.Status())
+	}
+
+	// Ground level falls back to nearby DTM cells, centred on column 20
+	if math.Abs(data.Elevation-2.0) > 0.01 {
+		t.Errorf("Expected elevation 2.0, got %.2f", data.Elevation)
+	}
+	if math.Abs(data.RelativeHeight) > 0.01 {
+		t.Errorf("Expected relative height 0, got %.2f", data.RelativeHeight)
+	}
+	if data.SurroundingMin != 0 || math.Abs(data.SurroundingMax-4.0) > 0.01 {
+		t.Errorf("Expected surrounding range 0-4m, got %.2f-%.2f", data.SurroundingMin, data.SurroundingMax)
+	}
+
+	// 0.1 m per 200/41 m cell is a gradient of 0.0205, about 1.17 degrees
+	wantSlope := math.Atan(0.1/(2*ahnAreaRadius/cells)) * 180 / math.Pi
+	if math.Abs(data.TerrainSlope-wantSlope) > 0.01 {
+		t.Errorf("Expected slope %.2f, got %.2f", wantSlope, data.TerrainSlope)
+	}
+
+	if data.BuildingHeight == nil || math.Abs(*data.BuildingHeight-10.0) > 0.01 {
+		t.Errorf("Expected building height 10m, got %v", data.BuildingHeight)
+	}
+	if data.FloodRisk != "Low" {
+		t.Errorf("Expected flood risk 'Low', got '%s'", data.FloodRisk)
+	}
+	if len(data.Surrounding) != 8 {
+		t.Errorf("Expected 8 compass samples, got %d", len(data.Surrounding))
+	}
+}
+
+func TestFetchAHNHeightData_Unavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0"?><ows:ExceptionReport>out of bounds</ows:ExceptionReport>`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{AHNHeightModelApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	data, err := client.FetchAHNHeightData(ctx, cfg, 50.85, 5.69)
+	if err != nil {
+		t.Fatalf("Expected soft failure, got %v", err)
+	}
+	if data.Available || data.UnavailableReason == "" {
+		t.Errorf("Expected explicit unavailable state, got %+v", data)
+	}
+	if data.Elevation != 0 || data.BuildingHeight != nil {
+		t.Error("Expected no estimated values when unavailable")
+	}
+	if prov.Status() != StatusError {
+		t.Errorf("Expected status %q, got %q", StatusError, prov.Status())
+	}
+
+	// Not configured is reported without calling out
+	ctx, prov = WithProvenance(context.Background())
+	data, _ = client.FetchAHNHeightData(ctx, &config.Config{}, 52.0907, 5.1214)
+	if data.Available || prov.Status() != StatusNotConfigured {
+		t.Errorf("Expected not_configured, got available=%v status=%q", data.Available, prov.Status())
+	}
+}
+
+func TestWGS84ToRD(t *testing.T) {
+	// Amersfoort is the origin of the RD grid
+	x, y := wgs84ToRD(52.15517440, 5.38720621)
+	if math.Abs(x-155000) > 0.01 || math.Abs(y-463000) > 0.01 {
+		t.Errorf("Expected RD origin, got %.2f, %.2f", x, y)
+	}
+
+	// Westertoren, Amsterdam: the reference point used to validate the approximation
+	x, y = wgs84ToRD(52.37453253, 4.88352559)
+	if math.Abs(x-120700.723) > 1 || math.Abs(y-487525.501) > 1 {
+		t.Errorf("Expected Westertoren at 120700.7, 487525.5, got %.1f, %.1f", x, y)
+	}
+}
@@ -12,6 +12,7 @@ import (
 // Documentation: https://docs.altum.ai/english/apis/woz-api
 func (c *ApiClient) FetchAltumWOZData(ctx context.Context, cfg *config.Config, bagID string) (*models.AltumWOZData, error) {
 	if cfg.AltumWOZApiURL == "" {
+		markNotConfigured(ctx, "AltumWOZApiURL")
 		return nil, fmt.Errorf("AltumWOZApiURL not configured")
 	}
 
@@ -29,6 +30,7 @@ func (c *ApiClient) FetchAltumWOZData(ctx context.Context, cfg *config.Config, b
 // Documentation: https://docs.altum.ai/english/apis/transaction-api
 func (c *ApiClient) FetchTransactionHistory(ctx context.Context, cfg *config.Config, bagID string) (*models.TransactionHistory, error) {
 	if cfg.AltumTransactionApiURL == "" {
+		markNotConfigured(ctx, "AltumTransactionApiURL")
 		return nil, fmt.Errorf("AltumTransactionApiURL not configured")
 	}
 
@@ -0,0 +1,231 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Default PDOK CBS postcode-4 endpoint (free, no auth required)
+const defaultCBSPostcode4ApiURL = "https://api.pdok.nl/cbs/postcode4/ogc/v1"
+
+// Area levels accepted by FetchArea
+const (
+	AreaLevelBuurt = "buurt"
+	AreaLevelWijk  = "wijk"
+	AreaLevelPC4   = "pc4"
+)
+
+// Area lookup errors
+var (
+	ErrInvalidAreaCode = errors.New("invalid area code, expected a CBS buurtcode (BU03440000), wijkcode (WK034400) or 4-digit postcode (3541)")
+	ErrAreaNotFound    = errors.New("area not found")
+)
+
+var (
+	buurtCodePattern = regexp.MustCompile(`^BU[0-9]{8}$`)
+	wijkCodePattern  = regexp.MustCompile(`^WK[0-9]{6}$`)
+	pc4Pattern       = regexp.MustCompile(`^[1-9][0-9]{3}$`)
+)
+
+// areaSampleGrid is the number of grid cells per side tried when spreading sample
+// points over an area; maxAreaSamples caps how many of them are kept
+const (
+	areaSampleGrid = 6
+	maxAreaSamples = 4
+)
+
+type cbsAreaResponse struct {
+	Features []struct {
+		Properties struct {
+			Buurtnaam    string `json:"buurtnaam"`
+			Wijknaam     string `json:"wijknaam"`
+			Gemeentenaam string `json:"gemeentenaam"`
+			// CBS marks suppressed or unknown figures with negative sentinel values
+			AantalInwoners        *float64 `json:"aantal_inwoners"`
+			AantalHuishoudens     *float64 `json:"aantal_huishoudens"`
+			AantalPartHuishoudens *float64 `json:"aantal_part_huishoudens"`
+			Bevolkingsdichtheid   *float64 `json:"bevolkingsdichtheid_inwoners_per_km2"`
+			Woningwaarde          *float64 `json:"gemiddelde_woningwaarde"`      // x1000 EUR
+			WOZWaardeWoning       *float64 `json:"gemiddelde_woz_waarde_woning"` // x1000 EUR
+			Inkomen               *float64 `json:"gemiddeld_gestandaardiseerd_inkomen_van_huishoudens"`
+		} `json:"properties"`
+		Geometry json.RawMessage `json:"geometry"`
+	} `json:"features"`
+}
+
+// ParseAreaCode normalises a CBS buurtcode, wijkcode or postcode-4 ("bu03440000",
+// "3541") and returns its level. Returns ErrInvalidAreaCode for anything else.
+func ParseAreaCode(code string) (level, normalized string, err error) {
+	normalized = strings.ToUpper(strings.TrimSpace(code))
+	switch {
+	case buurtCodePattern.MatchString(normalized):
+		return AreaLevelBuurt, normalized, nil
+	case wijkCodePattern.MatchString(normalized):
+		return AreaLevelWijk, normalized, nil
+	case pc4Pattern.MatchString(normalized):
+		return AreaLevelPC4, normalized, nil
+	}
+	return "", "", ErrInvalidAreaCode
+}
+
+// FetchArea retrieves the outline and key figures of a buurt or wijk from the CBS
+// wijken-en-buurten dataset, or of a postcode-4 area from the CBS postcode-4 dataset,
+// with sample points spread across the polygon for point-based sources
+// Documentation: https://api.pdok.nl/cbs/wijken-en-buurten-2024/ogc/v1
+func (c *ApiClient) FetchArea(ctx context.Context, cfg *config.Config, code string) (*models.AreaData, error) {
+	level, code, err := ParseAreaCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	buurtenURL := defaultCBSBuurtenApiURL
+	if cfg.CBSAreaApiURL != "" {
+		buurtenURL = strings.TrimRight(cfg.CBSAreaApiURL, "/")
+	}
+	postcode4URL := defaultCBSPostcode4ApiURL
+	if cfg.CBSPostcode4ApiURL != "" {
+		postcode4URL = strings.TrimRight(cfg.CBSPostcode4ApiURL, "/")
+	}
+
+	var itemsURL string
+	switch level {
+	case AreaLevelBuurt:
+		itemsURL = fmt.Sprintf("%s/collections/buurten/items?buurtcode=%s&f=json&limit=1", buurtenURL, url.QueryEscape(code))
+	case AreaLevelWijk:
+		itemsURL = fmt.Sprintf("%s/collections/wijken/items?wijkcode=%s&f=json&limit=1", buurtenURL, url.QueryEscape(code))
+	case AreaLevelPC4:
+		itemsURL = fmt.Sprintf("%s/collections/postcode4/items?postcode=%s&f=json&limit=1", postcode4URL, url.QueryEscape(code))
+	}
+	logutil.Debugf("[CBS Area] Request URL: %s", itemsURL)
+
+	var resp cbsAreaResponse
+	if err := c.GetJSON(ctx, "CBS Area", itemsURL, nil, &resp); err != nil {
+		return nil, fmt.Errorf("CBS area request failed: %w", err)
+	}
+	if len(resp.Features) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAreaNotFound, code)
+	}
+
+	f := resp.Features[0]
+	polygons, err := parsePolygons(f.Geometry)
+	if err != nil {
+		return nil, fmt.Errorf("area %s has no usable geometry: %w", code, err)
+	}
+
+	props := f.Properties
+	area := &models.AreaData{
+		Code:              code,
+		Level:             level,
+		Name:              code,
+		MunicipalityName:  props.Gemeentenaam,
+		GeoJSON:           string(f.Geometry),
+		SurfaceArea:       math.Round(polygonsArea(polygons)),
+		Population:        int(cbsFigure(props.AantalInwoners)),
+		Households:        int(cbsFigure(props.AantalHuishoudens)),
+		PopulationDensity: cbsFigure(props.Bevolkingsdichtheid),
+		AverageIncome:     cbsFigure(props.Inkomen) * 1000,
+		AverageWOZ:        cbsFigure(props.Woningwaarde) * 1000,
+	}
+	switch {
+	case level == AreaLevelBuurt && props.Buurtnaam != "":
+		area.Name = props.Buurtnaam
+	case level == AreaLevelWijk && props.Wijknaam != "":
+		area.Name = props.Wijknaam
+	}
+	if area.Households == 0 {
+		area.Households = int(cbsFigure(props.AantalPartHuishoudens))
+	}
+	if area.AverageWOZ == 0 {
+		area.AverageWOZ = cbsFigure(props.WOZWaardeWoning) * 1000
+	}
+	if area.PopulationDensity == 0 && area.Population > 0 && area.SurfaceArea > 0 {
+		area.PopulationDensity = math.Round(float64(area.Population) / (area.SurfaceArea / 1e6))
+	}
+
+	area.SamplePoints = samplePolygons(polygons, areaSampleGrid, maxAreaSamples)
+	area.Centroid = area.SamplePoints[0]
+	lon, lat := boundsCentre(polygons)
+	if polygonsContain(polygons, lon, lat) {
+		area.Centroid = [2]float64{lon, lat}
+	}
+
+	logutil.Debugf("[CBS Area] %s %s (%s): %d inhabitants, %.0f m², %d sample points",
+		level, code, area.Name, area.Population, area.SurfaceArea, len(area.SamplePoints))
+	return area, nil
+}
+
+// cbsFigure returns a CBS figure, or 0 when it is missing or a negative sentinel
+func cbsFigure(v *float64) float64 {
+	if v == nil || *v < 0 {
+		return 0
+	}
+	return *v
+}
+
+// boundsCentre returns the centre of the bounding box of the polygons' outer rings
+func boundsCentre(polygons []polygon) (lon, lat float64) {
+	minLon, minLat, maxLon, maxLat := polygonBounds(polygons)
+	return (minLon + maxLon) / 2, (minLat + maxLat) / 2
+}
+
+func polygonBounds(polygons []polygon) (minLon, minLat, maxLon, maxLat float64) {
+	minLon, minLat = math.Inf(1), math.Inf(1)
+	maxLon, maxLat = math.Inf(-1), math.Inf(-1)
+	for _, p := range polygons {
+		if len(p) == 0 {
+			continue
+		}
+		for _, pt := range p[0] {
+			minLon, maxLon = math.Min(minLon, pt[0]), math.Max(maxLon, pt[0])
+			minLat, maxLat = math.Min(minLat, pt[1]), math.Max(maxLat, pt[1])
+		}
+	}
+	return minLon, minLat, maxLon, maxLat
+}
+
+// samplePolygons spreads up to max points evenly over the polygons by testing the
+// centres of a grid×grid division of their bounding box. If no cell centre falls
+// inside (a very narrow area), the first vertex of the outline is used.
+func samplePolygons(polygons []polygon, grid, max int) [][2]float64 {
+	minLon, minLat, maxLon, maxLat := polygonBounds(polygons)
+	stepLon := (maxLon - minLon) / float64(grid)
+	stepLat := (maxLat - minLat) / float64(grid)
+
+	var inside [][2]float64
+	for row := 0; row < grid; row++ {
+		for col := 0; col < grid; col++ {
+			lon := minLon + (float64(col)+0.5)*stepLon
+			lat := minLat + (float64(row)+0.5)*stepLat
+			if polygonsContain(polygons, lon, lat) {
+				inside = append(inside, [2]float64{lon, lat})
+			}
+		}
+	}
+	if len(inside) == 0 {
+		for _, p := range polygons {
+			if len(p) > 0 && len(p[0]) > 0 {
+				return [][2]float64{p[0][0]}
+			}
+		}
+		return [][2]float64{{minLon, minLat}}
+	}
+	if len(inside) <= max {
+		return inside
+	}
+
+	samples := make([][2]float64, max)
+	for i := range samples {
+		samples[i] = inside[i*len(inside)/max]
+	}
+	return samples
+}
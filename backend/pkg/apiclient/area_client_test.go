@@ -0,0 +1,115 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+// An L-shaped buurt of roughly 1 x 1 km with the north-east quarter cut out
+const buurtFixture = `{
+	"type": "FeatureCollection",
+	"features": [{
+		"type": "Feature",
+		"properties": {"buurtcode": "BU03440000", "buurtnaam": "Binnenstad", "gemeentenaam": "Utrecht",
+			"aantal_inwoners": 4200, "aantal_huishoudens": 2900, "bevolkingsdichtheid_inwoners_per_km2": -99997,
+			"gemiddelde_woningwaarde": 512, "gemiddeld_gestandaardiseerd_inkomen_van_huishoudens": 41.5},
+		"geometry": {"type": "Polygon", "coordinates": [[[5.11, 52.08], [5.125, 52.08], [5.125, 52.0845], [5.1175, 52.0845],
+			[5.1175, 52.089], [5.11, 52.089], [5.11, 52.08]]]}
+	}]
+}`
+
+func TestParseAreaCode(t *testing.T) {
+	tests := []struct {
+		code, level, normalized string
+	}{
+		{"BU03440000", AreaLevelBuurt, "BU03440000"},
+		{" bu03440000 ", AreaLevelBuurt, "BU03440000"},
+		{"WK034400", AreaLevelWijk, "WK034400"},
+		{"3541", AreaLevelPC4, "3541"},
+		{"0123", "", ""},
+		{"3541ED", "", ""},
+		{"GM0344", "", ""},
+		{"BU0344", "", ""},
+	}
+	for _, tt := range tests {
+		level, normalized, err := ParseAreaCode(tt.code)
+		if tt.level == "" {
+			if !errors.Is(err, ErrInvalidAreaCode) {
+				t.Errorf("ParseAreaCode(%q): expected ErrInvalidAreaCode, got %v", tt.code, err)
+			}
+			continue
+		}
+		if err != nil || level != tt.level || normalized != tt.normalized {
+			t.Errorf("ParseAreaCode(%q) = %q, %q, %v; want %q, %q", tt.code, level, normalized, err, tt.level, tt.normalized)
+		}
+	}
+}
+
+func TestFetchArea(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query().Get("buurtcode")
+		w.Write([]byte(buurtFixture))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CBSAreaApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	area, err := client.FetchArea(context.Background(), cfg, "bu03440000")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if path != "/collections/buurten/items" || query != "BU03440000" {
+		t.Errorf("Unexpected request %s?buurtcode=%s", path, query)
+	}
+	if area.Name != "Binnenstad" || area.Level != AreaLevelBuurt || area.MunicipalityName != "Utrecht" {
+		t.Errorf("Unexpected area identity: %+v", area)
+	}
+	if area.Population != 4200 || area.Households != 2900 || area.AverageWOZ != 512000 || area.AverageIncome != 41500 {
+		t.Errorf("Unexpected figures: %+v", area)
+	}
+	// The suppressed density is derived from the population and surface area instead
+	if area.SurfaceArea < 600000 || area.SurfaceArea > 1000000 {
+		t.Errorf("Expected a surface area of roughly 0.8 km², got %.0f m²", area.SurfaceArea)
+	}
+	if area.PopulationDensity < 4000 || area.PopulationDensity > 7000 {
+		t.Errorf("Expected a derived density around 5000/km², got %.0f", area.PopulationDensity)
+	}
+
+	polygons, _ := parsePolygons([]byte(`{"type": "Polygon", "coordinates": [[[5.11, 52.08], [5.125, 52.08], [5.125, 52.0845],
+		[5.1175, 52.0845], [5.1175, 52.089], [5.11, 52.089], [5.11, 52.08]]]}`))
+	if len(area.SamplePoints) == 0 || len(area.SamplePoints) > maxAreaSamples {
+		t.Fatalf("Expected 1-%d sample points, got %d", maxAreaSamples, len(area.SamplePoints))
+	}
+	for _, pt := range append(area.SamplePoints, area.Centroid) {
+		if !polygonsContain(polygons, pt[0], pt[1]) {
+			t.Errorf("Point %v lies outside the area", pt)
+		}
+	}
+}
+
+func TestFetchArea_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections/postcode4/items" || r.URL.Query().Get("postcode") != "9999" {
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`{"type": "FeatureCollection", "features": []}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CBSPostcode4ApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	if _, err := client.FetchArea(context.Background(), cfg, "9999"); !errors.Is(err, ErrAreaNotFound) {
+		t.Errorf("Expected ErrAreaNotFound, got %v", err)
+	}
+	if _, err := client.FetchArea(context.Background(), cfg, "nowhere"); !errors.Is(err, ErrInvalidAreaCode) {
+		t.Errorf("Expected ErrInvalidAreaCode, got %v", err)
+	}
+}
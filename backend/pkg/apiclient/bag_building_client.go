@@ -0,0 +1,120 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Default PDOK BAG OGC API endpoint (free, no auth required)
+const defaultBAGOGCApiURL = "https://api.pdok.nl/kadaster/bag/ogc/v2"
+
+// maxVerblijfsobjecten caps the units listed per pand; larger complexes report this many
+const maxVerblijfsobjecten = 1000
+
+type bagPandResponse struct {
+	Features []struct {
+		Properties struct {
+			Identificatie string `json:"identificatie"`
+			Bouwjaar      int    `json:"bouwjaar"`
+			Status        string `json:"status"`
+			Gebruiksdoel  string `json:"gebruiksdoel"`
+		} `json:"properties"`
+		Geometry json.RawMessage `json:"geometry"`
+	} `json:"features"`
+}
+
+type bagVerblijfsobjectResponse struct {
+	Features []struct {
+		Properties struct {
+			Identificatie string  `json:"identificatie"`
+			Gebruiksdoel  string  `json:"gebruiksdoel"`
+			Oppervlakte   float64 `json:"oppervlakte"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// FetchBAGBuildingData retrieves the BAG pand attributes (bouwjaar, status, footprint)
+// and, when verblijfsobjectID is set, the gebruiksdoel and oppervlakte of that unit
+// Documentation: https://api.pdok.nl/kadaster/bag/ogc/v2
+func (c *ApiClient) FetchBAGBuildingData(ctx context.Context, cfg *config.Config, verblijfsobjectID, pandID string) (*models.BAGBuildingData, error) {
+	if !bagIDPattern.MatchString(pandID) {
+		err := fmt.Errorf("invalid BAG pand ID %q", pandID)
+		markFailed(ctx, err)
+		return nil, err
+	}
+
+	baseURL := defaultBAGOGCApiURL
+	if cfg.BAGOGCApiURL != "" {
+		baseURL = strings.TrimRight(cfg.BAGOGCApiURL, "/")
+	}
+
+	var pand bagPandResponse
+	pandURL := fmt.Sprintf("%s/collections/pand/items?identificatie=%s&f=json", baseURL, url.QueryEscape(pandID))
+	if err := c.GetJSON(ctx, "BAG OGC", pandURL, nil, &pand); err != nil {
+		markFailed(ctx, err)
+		return nil, fmt.Errorf("BAG pand request failed: %w", err)
+	}
+	if len(pand.Features) == 0 {
+		markEmpty(ctx, "pand not found in BAG")
+		return nil, fmt.Errorf("pand %s not found", pandID)
+	}
+
+	p := pand.Features[0]
+	result := &models.BAGBuildingData{
+		PandID:           pandID,
+		ConstructionYear: p.Properties.Bouwjaar,
+		Status:           p.Properties.Status,
+		UsageFunctions:   splitGebruiksdoel(p.Properties.Gebruiksdoel),
+	}
+	if len(p.Geometry) > 0 && string(p.Geometry) != "null" {
+		result.FootprintGeoJSON = string(p.Geometry)
+		if polygons, err := parsePolygons(p.Geometry); err == nil {
+			result.FootprintArea = math.Round(polygonsArea(polygons))
+		}
+	}
+
+	// The units in the pand give both the count and the requested unit's details
+	var units bagVerblijfsobjectResponse
+	unitsURL := fmt.Sprintf("%s/collections/verblijfsobject/items?pand_identificatie=%s&f=json&limit=%d",
+		baseURL, url.QueryEscape(pandID), maxVerblijfsobjecten)
+	if err := c.GetJSON(ctx, "BAG OGC", unitsURL, nil, &units); err != nil {
+		markFailed(ctx, err)
+		return nil, fmt.Errorf("BAG verblijfsobject request failed: %w", err)
+	}
+	result.UnitsInBuilding = len(units.Features)
+
+	for _, u := range units.Features {
+		if u.Properties.Identificatie != verblijfsobjectID || verblijfsobjectID == "" {
+			continue
+		}
+		result.VerblijfsobjectID = verblijfsobjectID
+		result.FloorArea = u.Properties.Oppervlakte
+		if usage := splitGebruiksdoel(u.Properties.Gebruiksdoel); len(usage) > 0 {
+			result.UsageFunctions = usage
+		}
+		break
+	}
+
+	logutil.Debugf("[BAG OGC] Pand %s: bouwjaar %d, %s, %d units, vbo %s %.0fm²",
+		pandID, result.ConstructionYear, result.Status, result.UnitsInBuilding, result.VerblijfsobjectID, result.FloorArea)
+	return result, nil
+}
+
+// splitGebruiksdoel splits BAG's comma-separated gebruiksdoel list
+func splitGebruiksdoel(s string) []string {
+	out := []string{}
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+func TestFetchBAGBuildingData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/collections/pand/items":
+			if r.URL.Query().Get("identificatie") != "0344100000031316" {
+				t.Errorf("Unexpected pand filter %q", r.URL.RawQuery)
+			}
+			// Roughly 10m x 11m footprint
+			w.Write([]byte(`{"type": "FeatureCollection", "features": [{
+				"properties": {"identificatie": "0344100000031316", "bouwjaar": 1932,
+					"status": "Pand in gebruik", "gebruiksdoel": "woonfunctie, winkelfunctie"},
+				"geometry": {"type": "Polygon", "coordinates": [[
+					[5.12100, 52.09060], [5.12115, 52.09060], [5.12115, 52.09070], [5.12100, 52.09070], [5.12100, 52.09060]
+				]]}
+			}]}`))
+		case "/collections/verblijfsobject/items":
+			if r.URL.Query().Get("pand_identificatie") != "0344100000031316" {
+				t.Errorf("Unexpected verblijfsobject filter %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"type": "FeatureCollection", "features": [
+				{"properties": {"identificatie": "0344010000067870", "gebruiksdoel": "winkelfunctie", "oppervlakte": 85}},
+				{"properties": {"identificatie": "0344010000067871", "gebruiksdoel": "woonfunctie", "oppervlakte": 72}}
+			]}`))
+		default:
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BAGOGCApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	data, err := client.FetchBAGBuildingData(context.Background(), cfg, "0344010000067871", "0344100000031316")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.ConstructionYear != 1932 || data.Status != "Pand in gebruik" || data.UnitsInBuilding != 2 {
+		t.Errorf("Unexpected pand attributes: %+v", data)
+	}
+	if data.VerblijfsobjectID != "0344010000067871" || data.FloorArea != 72 {
+		t.Errorf("Unexpected verblijfsobject attributes: %+v", data)
+	}
+	if len(data.UsageFunctions) != 1 || data.UsageFunctions[0] != "woonfunctie" {
+		t.Errorf("Expected the unit's gebruiksdoel, got %v", data.UsageFunctions)
+	}
+	if data.FootprintArea < 100 || data.FootprintArea > 125 || data.FootprintGeoJSON == "" {
+		t.Errorf("Unexpected footprint %.0fm²", data.FootprintArea)
+	}
+}
+
+func TestFetchBAGBuildingData_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"type": "FeatureCollection", "features": []}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BAGOGCApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	if _, err := client.FetchBAGBuildingData(ctx, cfg, "", "0344100000031316"); err == nil {
+		t.Fatal("Expected error for unknown pand")
+	}
+	if prov.Status() != StatusEmpty {
+		t.Errorf("Expected %q provenance, got %q", StatusEmpty, prov.Status())
+	}
+}
@@ -2,6 +2,7 @@ package apiclient
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -63,3 +64,99 @@ func TestFetchBAGData_RealAPI(t *testing.T) {
 		t.Errorf("Coordinates not parsed correctly: %v", bagData.Coordinates)
 	}
 }
+
+// newBAGUnitsServer serves the units at Teststraat 12: 12, 12A, 12B-1 and 12B-2
+func newBAGUnitsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "postcode:1234AB AND huisnummer:12" {
+			t.Errorf("Expected query on postcode and huisnummer, got %q", q)
+		}
+		w.Write([]byte(`{"response": {"docs": [
+			{"id": "adr-1", "weergavenaam": "Teststraat 12, 1234AB Testdorp", "huisnummer": 12, "postcode": "1234AB",
+			 "verblijfsobject_id": "0001", "centroide_ll": "POINT(4.8952 52.3702)"},
+			{"id": "adr-2", "weergavenaam": "Teststraat 12A, 1234AB Testdorp", "huisnummer": 12, "huisletter": "A", "postcode": "1234AB",
+			 "verblijfsobject_id": "0002", "centroide_ll": "POINT(4.8952 52.3702)"},
+			{"id": "adr-3", "weergavenaam": "Teststraat 12B-1, 1234AB Testdorp", "huisnummer": 12, "huisletter": "B", "huisnummertoevoeging": "1",
+			 "postcode": "1234AB", "verblijfsobject_id": "0003", "centroide_ll": "POINT(4.8952 52.3702)"},
+			{"id": "adr-4", "weergavenaam": "Teststraat 12B-2, 1234AB Testdorp", "huisnummer": 12, "huisletter": "B", "huisnummertoevoeging": "2",
+			 "postcode": "1234AB", "verblijfsobject_id": "0004", "centroide_ll": "POINT(4.8952 52.3702)"}
+		]}}`))
+	}))
+}
+
+func TestFetchBAGData_HouseLetterAndAddition(t *testing.T) {
+	server := newBAGUnitsServer(t)
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	tests := map[string]string{
+		"12":    "0001",
+		"12a":   "0002",
+		"12-A":  "0002", // toevoeging written for the huisletter
+		"12B-2": "0004",
+		"12 b1": "0003",
+	}
+	for houseNumber, want := range tests {
+		bagData, err := client.FetchBAGData(context.Background(), "1234 ab", houseNumber)
+		if err != nil {
+			t.Errorf("FetchBAGData(%q) failed: %v", houseNumber, err)
+			continue
+		}
+		if bagData.VerblijfsobjectID != want {
+			t.Errorf("FetchBAGData(%q) matched %s, want %s", houseNumber, bagData.VerblijfsobjectID, want)
+		}
+	}
+}
+
+func TestFetchBAGData_Ambiguous(t *testing.T) {
+	server := newBAGUnitsServer(t)
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	_, err := client.FetchBAGData(context.Background(), "1234AB", "12B")
+	var ambiguous *AmbiguousAddressError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Expected AmbiguousAddressError, got %v", err)
+	}
+	if ambiguous.Address != "1234AB 12B" || len(ambiguous.Candidates) != 4 {
+		t.Fatalf("Unexpected ambiguity: %+v", ambiguous)
+	}
+	if c := ambiguous.Candidates[3]; c.ID != "adr-4" || c.HouseNumber != "12B-2" || c.Postcode != "1234AB" {
+		t.Errorf("Unexpected candidate: %+v", c)
+	}
+
+	if _, err := client.FetchBAGData(context.Background(), "1234AB", "12 OR 1"); err == nil {
+		t.Error("Expected invalid house number to be rejected")
+	}
+}
+
+func TestFetchBAGData_SingleUnit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": {"docs": [
+			{"id": "adr-1", "weergavenaam": "Teststraat 12-1, 1234AB Testdorp", "huisnummer": 12, "huisnummertoevoeging": "1",
+			 "postcode": "1234AB", "verblijfsobject_id": "0001", "centroide_ll": "POINT(4.8952 52.3702)"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	// A suffix the only unit lacks is not found rather than ambiguous
+	_, err := client.FetchBAGData(context.Background(), "1234AB", "12Z")
+	var ambiguous *AmbiguousAddressError
+	if !errors.Is(err, ErrAddressNotFound) || errors.As(err, &ambiguous) {
+		t.Fatalf("Expected ErrAddressNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "12-1") {
+		t.Errorf("Expected the error to name the existing unit, got %v", err)
+	}
+
+	if bagData, err := client.FetchBAGData(context.Background(), "1234AB", "12"); err != nil || bagData.VerblijfsobjectID != "0001" {
+		t.Errorf("Expected the bare huisnummer to resolve to the only unit, got %+v, %v", bagData, err)
+	}
+}
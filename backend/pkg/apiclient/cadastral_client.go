@@ -0,0 +1,170 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Default PDOK Kadastrale Kaart OGC API endpoint (free, no auth required)
+const defaultKadastraleKaartApiURL = "https://api.pdok.nl/kadaster/brk-kadastrale-kaart/ogc/v5"
+
+type perceelResponse struct {
+	Features []struct {
+		Properties struct {
+			LokaalID                 string      `json:"identificatie_lokaal_id"`
+			KadastraleGemeenteCode   string      `json:"kadastrale_gemeente_code"`
+			KadastraleGemeenteWaarde string      `json:"kadastrale_gemeente_waarde"`
+			AKRKadastraleGemeente    string      `json:"akr_kadastrale_gemeente_code"`
+			Sectie                   string      `json:"sectie"`
+			Perceelnummer            json.Number `json:"perceelnummer"`
+			KadastraleGrootte        json.Number `json:"kadastrale_grootte_waarde"`
+		} `json:"properties"`
+		Geometry json.RawMessage `json:"geometry"`
+	} `json:"features"`
+}
+
+// FetchCadastralParcel resolves the cadastral parcel containing the coordinates, with
+// its polygon and area. Returns nil if no parcel contains the point.
+// Documentation: https://api.pdok.nl/kadaster/brk-kadastrale-kaart/ogc/v5
+func (c *ApiClient) FetchCadastralParcel(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.CadastralInfo, error) {
+	baseURL := defaultKadastraleKaartApiURL
+	if cfg.KadastraleKaartApiURL != "" {
+		baseURL = cfg.KadastraleKaartApiURL
+	}
+
+	// A ~1m box returns the parcel under the point plus any neighbour touching it
+	delta := 0.00001
+	bbox := fmt.Sprintf("%.6f,%.6f,%.6f,%.6f", lon-delta, lat-delta, lon+delta, lat+delta)
+	url := fmt.Sprintf("%s/collections/perceel/items?bbox=%s&f=json&limit=10", baseURL, bbox)
+
+	var resp perceelResponse
+	if err := c.GetJSON(ctx, "Kadastrale Kaart", url, nil, &resp); err != nil {
+		return nil, fmt.Errorf("kadastrale kaart request failed: %w", err)
+	}
+
+	logutil.Debugf("[Kadastrale Kaart] %d parcel candidates near (%f, %f)", len(resp.Features), lat, lon)
+
+	for _, f := range resp.Features {
+		polygons, err := parsePolygons(f.Geometry)
+		if err != nil {
+			logutil.Debugf("[Kadastrale Kaart] Skipping parcel %s: %v", f.Properties.LokaalID, err)
+			continue
+		}
+		if !polygonsContain(polygons, lon, lat) {
+			continue
+		}
+
+		p := f.Properties
+		code := p.AKRKadastraleGemeente
+		if code == "" {
+			code = p.KadastraleGemeenteCode
+		}
+		registered, _ := p.KadastraleGrootte.Float64()
+		return &models.CadastralInfo{
+			ParcelID:         p.LokaalID,
+			Municipality:     p.KadastraleGemeenteWaarde,
+			MunicipalityCode: code,
+			Section:          p.Sectie,
+			ParcelNumber:     p.Perceelnummer.String(),
+			Area:             math.Round(polygonsArea(polygons)),
+			RegisteredArea:   registered,
+			GeoJSON:          string(f.Geometry),
+		}, nil
+	}
+	return nil, nil
+}
+
+// polygon is a GeoJSON polygon: an outer ring followed by holes, as [lon, lat] positions
+type polygon [][][2]float64
+
+// parsePolygons decodes a GeoJSON Polygon or MultiPolygon geometry
+func parsePolygons(raw json.RawMessage) ([]polygon, error) {
+	var geom struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(raw, &geom); err != nil {
+		return nil, err
+	}
+	switch geom.Type {
+	case "Polygon":
+		var p polygon
+		if err := json.Unmarshal(geom.Coordinates, &p); err != nil {
+			return nil, err
+		}
+		return []polygon{p}, nil
+	case "MultiPolygon":
+		var ps []polygon
+		if err := json.Unmarshal(geom.Coordinates, &ps); err != nil {
+			return nil, err
+		}
+		return ps, nil
+	}
+	return nil, fmt.Errorf("unsupported geometry type %q", geom.Type)
+}
+
+// polygonsContain reports whether the point lies inside any polygon (outside its holes)
+func polygonsContain(polygons []polygon, lon, lat float64) bool {
+	for _, p := range polygons {
+		if len(p) == 0 || !ringContains(p[0], lon, lat) {
+			continue
+		}
+		inHole := false
+		for _, hole := range p[1:] {
+			if ringContains(hole, lon, lat) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// ringContains is a ray-casting point-in-ring test
+func ringContains(ring [][2]float64, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// polygonsArea returns the area in m², projecting to RD so the shoelace formula works in metres
+func polygonsArea(polygons []polygon) float64 {
+	total := 0.0
+	for _, p := range polygons {
+		for i, ring := range p {
+			a := ringArea(ring)
+			if i == 0 {
+				total += a
+			} else {
+				total -= a
+			}
+		}
+	}
+	return total
+}
+
+func ringArea(ring [][2]float64) float64 {
+	sum := 0.0
+	for i := range ring {
+		x1, y1 := wgs84ToRD(ring[i][1], ring[i][0])
+		next := ring[(i+1)%len(ring)]
+		x2, y2 := wgs84ToRD(next[1], next[0])
+		sum += x1*y2 - x2*y1
+	}
+	return math.Abs(sum) / 2
+}
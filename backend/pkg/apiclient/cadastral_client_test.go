@@ -0,0 +1,104 @@
+package apiclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+// Two adjoining ~20x20m parcels; the point 52.0907,5.1214 lies in the second
+const perceelFixture = `{
+	"type": "FeatureCollection",
+	"features": [{
+		"type": "Feature",
+		"properties": {"identificatie_lokaal_id": "50520001070000", "akr_kadastrale_gemeente_code": "UTT00",
+			"kadastrale_gemeente_waarde": "Utrecht", "sectie": "A", "perceelnummer": 1069, "kadastrale_grootte_waarde": 410},
+		"geometry": {"type": "Polygon", "coordinates": [[[5.12097, 52.0906], [5.12126, 52.0906], [5.12126, 52.09078], [5.12097, 52.09078], [5.12097, 52.0906]]]}
+	}, {
+		"type": "Feature",
+		"properties": {"identificatie_lokaal_id": "50520001070001", "akr_kadastrale_gemeente_code": "UTT00",
+			"kadastrale_gemeente_waarde": "Utrecht", "sectie": "A", "perceelnummer": 1070, "kadastrale_grootte_waarde": 395},
+		"geometry": {"type": "Polygon", "coordinates": [[[5.12126, 52.09062], [5.121552, 52.09062], [5.121552, 52.0908], [5.12126, 52.0908], [5.12126, 52.09062]]]}
+	}]
+}`
+
+func TestFetchCadastralParcel(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(perceelFixture))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{KadastraleKaartApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	parcel, err := client.FetchCadastralParcel(context.Background(), cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != "/collections/perceel/items" {
+		t.Errorf("Unexpected collection path %q", path)
+	}
+	if parcel == nil {
+		t.Fatal("Expected the parcel containing the point")
+	}
+	if parcel.ParcelNumber != "1070" || parcel.MunicipalityCode != "UTT00" || parcel.Section != "A" || parcel.Municipality != "Utrecht" {
+		t.Errorf("Expected UTT00 A 1070, got %+v", parcel)
+	}
+	if parcel.RegisteredArea != 395 {
+		t.Errorf("Expected registered area 395, got %f", parcel.RegisteredArea)
+	}
+	// 0.000292° lon x 0.00018° lat at 52°N is about 20m x 20m
+	if math.Abs(parcel.Area-400) > 10 {
+		t.Errorf("Expected computed area around 400m², got %f", parcel.Area)
+	}
+	if parcel.GeoJSON == "" {
+		t.Error("Expected parcel geometry")
+	}
+}
+
+func TestFetchPDOKPlatformData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(perceelFixture))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{KadastraleKaartApiURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	data, err := client.FetchPDOKPlatformData(context.Background(), cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if data.CadastralData == nil || data.CadastralData.ParcelID != "50520001070001" {
+		t.Errorf("Expected cadastral data for parcel 50520001070001, got %+v", data.CadastralData)
+	}
+
+	// A point outside every returned parcel has no cadastral data
+	ctx, prov := WithProvenance(context.Background())
+	data, err = client.FetchPDOKPlatformData(ctx, cfg, 52.1, 5.2)
+	if err != nil || data.CadastralData != nil {
+		t.Fatalf("Expected no parcel, got %+v, %v", data, err)
+	}
+	if prov.Status() != StatusEmpty {
+		t.Errorf("Expected %q provenance, got %q", StatusEmpty, prov.Status())
+	}
+}
+
+func TestPolygonsContain_Hole(t *testing.T) {
+	square := polygon{
+		{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}},
+		{{4, 4}, {6, 4}, {6, 6}, {4, 6}, {4, 4}},
+	}
+	if !polygonsContain([]polygon{square}, 2, 2) {
+		t.Error("Expected point in the outer ring to be contained")
+	}
+	if polygonsContain([]polygon{square}, 5, 5) {
+		t.Error("Expected point in the hole not to be contained")
+	}
+}
@@ -22,6 +22,7 @@ func (c *ApiClient) FetchCBSData(ctx context.Context, cfg *config.Config, neighb
 	logutil.Debugf("[CBS] FetchCBSData: URL=%s, neighborhoodCode=%s", cfg.CBSApiURL, neighborhoodCode)
 	// Return empty data if no CBS API URL is configured
 	if cfg.CBSApiURL == "" {
+		markNotConfigured(ctx, "CBSApiURL")
 		return emptyCBSData(), nil
 	}
 
@@ -41,12 +42,14 @@ func (c *ApiClient) FetchCBSData(ctx context.Context, cfg *config.Config, neighb
 	}
 
 	if err := c.GetJSON(ctx, "CBS", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyCBSData(), nil
 	}
 
 	logutil.Debugf("[CBS] Response: %+v", result)
 	if len(result.Value) == 0 {
 		logutil.Debugf("[CBS] No results for %s", neighborhoodCode)
+		markEmpty(ctx, "no CBS data for neighborhood")
 		return emptyCBSData(), nil
 	}
 
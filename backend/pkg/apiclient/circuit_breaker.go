@@ -0,0 +1,268 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+)
+
+// ErrCircuitOpen is returned (wrapped) for requests rejected by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Circuit breaker states
+const (
+	BreakerClosed   = "closed"    // requests flow normally
+	BreakerOpen     = "open"      // requests are rejected until the open timeout elapses
+	BreakerHalfOpen = "half_open" // a limited number of probe requests test recovery
+)
+
+// BreakerSettings configures the per-upstream circuit breakers
+type BreakerSettings struct {
+	FailureThreshold int           // consecutive failures that open the breaker; 0 disables breakers
+	OpenTimeout      time.Duration // how long an open breaker rejects requests before probing
+	HalfOpenRequests int           // concurrent probe requests allowed while half-open
+}
+
+// BreakerStatus is a snapshot of one upstream's breaker, for the admin endpoint
+type BreakerStatus struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	Trips               int        `json:"trips"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+}
+
+type circuitBreaker struct {
+	state     string
+	failures  int
+	trips     int
+	openedAt  time.Time
+	probes    int
+	lastError string
+}
+
+// breakerSet holds a circuit breaker per upstream API name
+type breakerSet struct {
+	settings BreakerSettings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func newBreakerSet(settings BreakerSettings) *breakerSet {
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+	return &breakerSet{settings: settings, now: time.Now, breakers: make(map[string]*circuitBreaker)}
+}
+
+func (bs *breakerSet) enabled() bool {
+	return bs != nil && bs.settings.FailureThreshold > 0
+}
+
+// allow reports whether a request to name may proceed. Allowed requests must
+// report their outcome with done; counted=false releases the request without
+// affecting the breaker (e.g. the caller cancelled it).
+func (bs *breakerSet) allow(name string) (done func(failed, counted bool), err error) {
+	if !bs.enabled() {
+		return func(bool, bool) {}, nil
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	b, ok := bs.breakers[name]
+	if !ok {
+		b = &circuitBreaker{state: BreakerClosed}
+		bs.breakers[name] = b
+	}
+
+	if b.state == BreakerOpen && bs.now().Sub(b.openedAt) >= bs.settings.OpenTimeout {
+		bs.transition(name, b, BreakerHalfOpen)
+	}
+
+	probe := false
+	switch b.state {
+	case BreakerOpen:
+		metrics.CircuitBreakerRejections.Inc(name)
+		return nil, fmt.Errorf("%w for %s", ErrCircuitOpen, name)
+	case BreakerHalfOpen:
+		if b.probes >= bs.settings.HalfOpenRequests {
+			metrics.CircuitBreakerRejections.Inc(name)
+			return nil, fmt.Errorf("%w for %s (probing)", ErrCircuitOpen, name)
+		}
+		b.probes++
+		probe = true
+	}
+
+	var once sync.Once
+	return func(failed, counted bool) {
+		once.Do(func() { bs.record(name, b, probe, failed, counted) })
+	}, nil
+}
+
+func (bs *breakerSet) record(name string, b *circuitBreaker, probe, failed, counted bool) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	// A probe only decides the outcome if the breaker is still half-open (not reset meanwhile)
+	probe = probe && b.state == BreakerHalfOpen
+	if probe {
+		b.probes--
+	}
+
+	if !counted {
+		return
+	}
+	if !failed {
+		b.failures = 0
+		if probe {
+			bs.transition(name, b, BreakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if probe || (b.state == BreakerClosed && b.failures >= bs.settings.FailureThreshold) {
+		bs.transition(name, b, BreakerOpen)
+	}
+}
+
+// recordError stores the last failure reason for the admin snapshot
+func (bs *breakerSet) recordError(name, reason string) {
+	if !bs.enabled() {
+		return
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if b, ok := bs.breakers[name]; ok {
+		b.lastError = reason
+	}
+}
+
+func (bs *breakerSet) transition(name string, b *circuitBreaker, state string) {
+	if b.state == state {
+		return
+	}
+	switch state {
+	case BreakerOpen:
+		b.openedAt = bs.now()
+		b.trips++
+		logutil.Warnf("[CIRCUIT] %s opened after %d consecutive failures; rejecting requests for %s", name, b.failures, bs.settings.OpenTimeout)
+	case BreakerHalfOpen:
+		b.probes = 0
+		logutil.Infof("[CIRCUIT] %s half-open; probing upstream", name)
+	case BreakerClosed:
+		b.failures = 0
+		b.lastError = ""
+		logutil.Infof("[CIRCUIT] %s closed; upstream recovered", name)
+	}
+	b.state = state
+	metrics.CircuitBreakerState.Set(breakerStateValue(state), name)
+}
+
+// breakerStateValue encodes a state for the gauge: 0 closed, 1 half-open, 2 open
+func breakerStateValue(state string) float64 {
+	switch state {
+	case BreakerHalfOpen:
+		return 1
+	case BreakerOpen:
+		return 2
+	}
+	return 0
+}
+
+// snapshot returns the state of every breaker that has seen traffic, sorted by name
+func (bs *breakerSet) snapshot() []BreakerStatus {
+	if !bs.enabled() {
+		return []BreakerStatus{}
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	out := make([]BreakerStatus, 0, len(bs.breakers))
+	for name, b := range bs.breakers {
+		st := BreakerStatus{
+			Name:                name,
+			State:               b.state,
+			ConsecutiveFailures: b.failures,
+			Trips:               b.trips,
+			LastError:           b.lastError,
+		}
+		if b.state == BreakerOpen && bs.now().Sub(b.openedAt) >= bs.settings.OpenTimeout {
+			// Will probe on the next request
+			st.State = BreakerHalfOpen
+		}
+		if b.state != BreakerClosed {
+			openedAt := b.openedAt
+			retryAt := openedAt.Add(bs.settings.OpenTimeout)
+			st.OpenedAt, st.RetryAt = &openedAt, &retryAt
+		}
+		out = append(out, st)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// reset closes the named breaker, or all breakers if name is empty. It reports
+// whether any breaker was reset.
+func (bs *breakerSet) reset(name string) bool {
+	if !bs.enabled() {
+		return false
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	found := false
+	for n, b := range bs.breakers {
+		if name != "" && n != name {
+			continue
+		}
+		found = true
+		bs.transition(n, b, BreakerClosed)
+		b.failures, b.probes = 0, 0
+	}
+	return found
+}
+
+// CircuitBreakers returns the state of the per-upstream circuit breakers
+func (c *ApiClient) CircuitBreakers() []BreakerStatus {
+	return c.breakers.snapshot()
+}
+
+// ResetCircuitBreaker closes the named breaker (all breakers if name is empty).
+// It returns false if no matching breaker exists.
+func (c *ApiClient) ResetCircuitBreaker(name string) bool {
+	return c.breakers.reset(name)
+}
+
+// statusCode returns the response status, or 0 without a response
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// upstreamFailed reports whether a response or transport error counts against
+// the upstream's breaker. Callers cancelling their own request and client
+// errors (4xx other than 429) don't indicate an unhealthy upstream.
+func upstreamFailed(ctx context.Context, resp *http.Response, err error) (failed, counted bool) {
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, false
+		}
+		return true, true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests, true
+}
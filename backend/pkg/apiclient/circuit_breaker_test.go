@@ -0,0 +1,153 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+func TestBreakerSet_Transitions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	bs := newBreakerSet(BreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	bs.now = func() time.Time { return now }
+
+	fail := func() {
+		done, err := bs.allow("Overpass")
+		if err != nil {
+			t.Fatalf("Expected request to be allowed, got %v", err)
+		}
+		done(true, true)
+	}
+
+	fail()
+	fail()
+	if _, err := bs.allow("Overpass"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected breaker to open after 2 failures, got %v", err)
+	}
+	if _, err := bs.allow("PDOK"); err != nil {
+		t.Errorf("Expected other upstreams to be unaffected, got %v", err)
+	}
+
+	// After the open timeout a single probe is let through
+	now = now.Add(time.Minute)
+	probe, err := bs.allow("Overpass")
+	if err != nil {
+		t.Fatalf("Expected probe after open timeout, got %v", err)
+	}
+	if _, err := bs.allow("Overpass"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected concurrent requests to be rejected while probing, got %v", err)
+	}
+
+	// A failed probe reopens the breaker
+	probe(true, true)
+	if _, err := bs.allow("Overpass"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected failed probe to reopen the breaker, got %v", err)
+	}
+
+	// A successful probe closes it
+	now = now.Add(time.Minute)
+	probe, _ = bs.allow("Overpass")
+	probe(false, true)
+	status := bs.snapshot()
+	if len(status) != 2 || status[0].Name != "Overpass" || status[0].State != BreakerClosed || status[0].Trips != 2 {
+		t.Errorf("Unexpected snapshot after recovery: %+v", status)
+	}
+}
+
+func TestBreakerSet_UncountedAndReset(t *testing.T) {
+	bs := newBreakerSet(BreakerSettings{FailureThreshold: 1, OpenTimeout: time.Hour})
+
+	// Cancelled requests don't count against the upstream
+	done, _ := bs.allow("Luchtmeetnet")
+	done(true, false)
+	if _, err := bs.allow("Luchtmeetnet"); err != nil {
+		t.Fatalf("Expected uncounted failure to leave breaker closed, got %v", err)
+	}
+
+	done, _ = bs.allow("Luchtmeetnet")
+	done(true, true)
+	if got := bs.snapshot()[0].State; got != BreakerOpen {
+		t.Fatalf("Expected open breaker, got %q", got)
+	}
+
+	if bs.reset("unknown") {
+		t.Error("Expected reset of unknown breaker to report false")
+	}
+	if !bs.reset("Luchtmeetnet") {
+		t.Error("Expected reset to find the breaker")
+	}
+	if _, err := bs.allow("Luchtmeetnet"); err != nil {
+		t.Errorf("Expected reset breaker to allow requests, got %v", err)
+	}
+
+	// Threshold 0 disables breakers entirely
+	disabled := newBreakerSet(BreakerSettings{})
+	for i := 0; i < 10; i++ {
+		done, err := disabled.allow("Overpass")
+		if err != nil {
+			t.Fatalf("Expected disabled breaker to allow requests, got %v", err)
+		}
+		done(true, true)
+	}
+}
+
+func TestGetJSON_CircuitOpenShortCircuits(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{CircuitBreakerFailureThreshold: 2, CircuitBreakerOpenTimeout: time.Hour}
+	c := NewApiClient(server.Client(), cfg)
+
+	var target map[string]interface{}
+	// Retries count towards the threshold, then stop once the breaker opens
+	err := c.GetJSONWithRetry(context.Background(), "Flaky", server.URL, nil, 5, time.Millisecond, &target)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected retries to stop at the open breaker, got %v", err)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected 2 upstream requests before the breaker opened, got %d", got)
+	}
+
+	ctx, prov := WithProvenance(context.Background())
+	err = c.GetJSON(ctx, "Flaky", server.URL, nil, &target)
+	markFailed(ctx, err)
+	if prov.Status() != StatusCircuitOpen {
+		t.Errorf("Expected %q provenance, got %q (%s)", StatusCircuitOpen, prov.Status(), prov.Reason())
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("Expected no upstream request while open, got %d", got)
+	}
+
+	status := c.CircuitBreakers()
+	if len(status) != 1 || status[0].State != BreakerOpen || status[0].LastError != "status 502" {
+		t.Errorf("Unexpected breaker status: %+v", status)
+	}
+}
+
+func TestRetryWithBackoff_SkipsClientErrors(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	c := NewApiClient(server.Client(), nil)
+	var target map[string]interface{}
+	if err := c.GetJSONWithRetry(context.Background(), "Missing", server.URL, nil, 3, time.Millisecond, &target); err == nil {
+		t.Fatal("Expected error for 404")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("Expected 404 not to be retried, got %d requests", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,14 +15,18 @@ import (
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/models"
 )
 
 // ApiClient for external API calls
 type ApiClient struct {
-	HTTP *http.Client
-	cfg  *config.Config
+	HTTP     *http.Client
+	cfg      *config.Config
+	limiter  *rateLimiter
+	breakers *breakerSet
 }
 
 func NewApiClient(client *http.Client, cfg *config.Config) *ApiClient {
@@ -29,10 +34,46 @@ func NewApiClient(client *http.Client, cfg *config.Config) *ApiClient {
 		// default client with reasonable timeout to avoid hanging requests
 		client = &http.Client{Timeout: 10 * time.Second}
 	}
+	var limits map[string]float64
+	var defaultRate float64
+	var breakers BreakerSettings
+	if cfg != nil {
+		limits = cfg.UpstreamRateLimits
+		defaultRate = cfg.UpstreamDefaultRateLimit
+		breakers = BreakerSettings{
+			FailureThreshold: cfg.CircuitBreakerFailureThreshold,
+			OpenTimeout:      cfg.CircuitBreakerOpenTimeout,
+			HalfOpenRequests: cfg.CircuitBreakerHalfOpenRequests,
+		}
+	}
 	return &ApiClient{
-		HTTP: client,
-		cfg:  cfg,
+		HTTP:     client,
+		cfg:      cfg,
+		limiter:  newRateLimiter(limits, defaultRate),
+		breakers: newBreakerSet(breakers),
+	}
+}
+
+// httpStatusError is returned by the JSON helpers for non-2xx responses
+type httpStatusError struct {
+	code int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("API returned status %d", e.code)
+}
+
+// retryable reports whether a failed attempt is worth retrying. Open breakers,
+// cancellations and client errors (4xx other than 429) fail the same way again.
+func retryable(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
 	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= http.StatusInternalServerError || statusErr.code == http.StatusTooManyRequests
+	}
+	return true
 }
 
 // BearerAuthHeader returns a header map with Bearer token authorization.
@@ -66,7 +107,7 @@ func (c *ApiClient) GetJSON(ctx context.Context, apiName, url string, headers ma
 		req.Header.Set(k, v)
 	}
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs(apiName, req)
 	if err != nil {
 		logutil.Debugf("[%s] HTTP request failed: %v", apiName, err)
 		return fmt.Errorf("HTTP request failed: %w", err)
@@ -75,7 +116,7 @@ func (c *ApiClient) GetJSON(ctx context.Context, apiName, url string, headers ma
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		logutil.Debugf("[%s] Non-2xx status: %d", apiName, resp.StatusCode)
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return &httpStatusError{code: resp.StatusCode}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
@@ -109,7 +150,7 @@ func (c *ApiClient) PostJSON(ctx context.Context, apiName, url string, body inte
 		req.Header.Set(k, v)
 	}
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs(apiName, req)
 	if err != nil {
 		logutil.Debugf("[%s] HTTP request failed: %v", apiName, err)
 		return fmt.Errorf("HTTP request failed: %w", err)
@@ -118,7 +159,7 @@ func (c *ApiClient) PostJSON(ctx context.Context, apiName, url string, body inte
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		logutil.Debugf("[%s] Non-2xx status: %d", apiName, resp.StatusCode)
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return &httpStatusError{code: resp.StatusCode}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
@@ -145,7 +186,7 @@ func (c *ApiClient) PostFormJSON(ctx context.Context, apiName, url, formData str
 		req.Header.Set(k, v)
 	}
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs(apiName, req)
 	if err != nil {
 		logutil.Debugf("[%s] HTTP request failed: %v", apiName, err)
 		return fmt.Errorf("HTTP request failed: %w", err)
@@ -154,7 +195,7 @@ func (c *ApiClient) PostFormJSON(ctx context.Context, apiName, url, formData str
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		logutil.Debugf("[%s] Non-2xx status: %d", apiName, resp.StatusCode)
-		return fmt.Errorf("API returned status %d", resp.StatusCode)
+		return &httpStatusError{code: resp.StatusCode}
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
@@ -182,7 +223,8 @@ func (c *ApiClient) PostFormJSONWithRetry(ctx context.Context, apiName, url, for
 
 // retryWithBackoff executes fn with exponential backoff retries on failure.
 // Retries up to maxAttempts times with the first retry after retryDelay.
-// Returns early if context is cancelled or if fn succeeds.
+// Returns early if context is cancelled, if fn succeeds, or if the failure is not
+// retryable (e.g. the upstream's circuit breaker is open).
 func (c *ApiClient) retryWithBackoff(ctx context.Context, apiName string, maxAttempts int, initialDelay time.Duration, fn func(context.Context) error) error {
 	var lastErr error
 	delay := initialDelay
@@ -200,8 +242,13 @@ func (c *ApiClient) retryWithBackoff(ctx context.Context, apiName string, maxAtt
 		}
 
 		lastErr = err
+		if !retryable(err) {
+			logutil.Debugf("[%s] Attempt %d failed (%v), not retrying", apiName, attempt, err)
+			return err
+		}
 		if attempt < maxAttempts {
 			logutil.Debugf("[%s] Attempt %d failed (%v), retrying in %v...", apiName, attempt, err, delay)
+			metrics.UpstreamRetries.Inc(apiName)
 			select {
 			case <-time.After(delay):
 				// Exponential backoff: double the delay for next attempt
@@ -216,20 +263,40 @@ func (c *ApiClient) retryWithBackoff(ctx context.Context, apiName string, maxAtt
 	return lastErr
 }
 
+// maxAddressUnits caps the units fetched for one postcode and huisnummer; a large
+// complex has a few hundred huisletter/toevoeging combinations at most
+const maxAddressUnits = 500
+
+// AmbiguousAddressError is returned when a postcode and house number do not
+// identify a single unit. Candidates lists the units at that huisnummer.
+type AmbiguousAddressError struct {
+	Address    string
+	Candidates []models.AddressSuggestion
+}
+
+func (e *AmbiguousAddressError) Error() string {
+	return fmt.Sprintf("ambiguous address %s: %d units match", e.Address, len(e.Candidates))
+}
+
+// FetchBAGData resolves a postcode and house number, including any huisletter and
+// toevoeging ("12A", "12-2"), to its BAG address. It returns an error wrapping
+// ErrAddressNotFound if nothing matches, or an *AmbiguousAddressError if the input
+// matches none of two or more units exactly.
 func (c *ApiClient) FetchBAGData(ctx context.Context, postcode, number string) (*models.BAGData, error) {
-	postcode = strings.ToUpper(strings.TrimSpace(postcode))
-	number = strings.TrimSpace(number)
-	if postcode == "" || number == "" {
-		return nil, fmt.Errorf("postcode and house number are required")
+	addr, err := utils.ParseDutchAddress(postcode, number)
+	if err != nil {
+		return nil, err
 	}
 
-	logutil.Debugf("[BAG] FetchBAGData: postcode=%s, number=%s", postcode, number)
+	logutil.Debugf("[BAG] FetchBAGData: %s", addr)
 	endpoint := c.cfg.BagApiURL
 
+	// Fetch every unit at the huisnummer and match huisletter and toevoeging here, so a
+	// near miss ("12-A" for huisletter A) still resolves and a miss can list the units
 	params := url.Values{}
-	params.Set("q", fmt.Sprintf("postcode:%s AND huisnummer:%s", postcode, number))
+	params.Set("q", fmt.Sprintf("postcode:%s AND huisnummer:%d", addr.Postcode, addr.HouseNumber))
 	params.Set("fq", "type:adres")
-	params.Set("rows", "1")
+	params.Set("rows", strconv.Itoa(maxAddressUnits))
 	params.Set("wt", "json")
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"?"+params.Encode(), nil)
@@ -239,7 +306,7 @@ func (c *ApiClient) FetchBAGData(ctx context.Context, postcode, number string) (
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs("BAG", req)
 	if err != nil {
 		logutil.Debugf("[BAG] HTTP error: %v", err)
 		return nil, err
@@ -258,21 +325,86 @@ func (c *ApiClient) FetchBAGData(ctx context.Context, postcode, number string) (
 		return nil, err
 	}
 
-	logutil.Debugf("[BAG] Raw response: %s", string(body))
 	var apiResp models.BagResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
 		logutil.Debugf("[BAG] Unmarshal error: %v", err)
 		return nil, fmt.Errorf("failed to parse BAG API response: %w", err)
 	}
 
-	logutil.Debugf("[BAG] Parsed response: %+v", apiResp)
-	if len(apiResp.Response.Docs) == 0 {
-		logutil.Debugf("[BAG] No results for %s %s", postcode, number)
-		return nil, fmt.Errorf("no results from BAG API for %s %s", postcode, number)
+	docs := apiResp.Response.Docs
+	logutil.Debugf("[BAG] %d units at %s %d", len(docs), addr.Postcode, addr.HouseNumber)
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%w: no results from BAG API for %s", ErrAddressNotFound, addr)
 	}
 
-	doc := apiResp.Response.Docs[0]
+	doc, ok := matchAddressUnit(addr, docs)
+	if !ok && len(docs) == 1 {
+		// A bare huisnummer picks out the only unit; a suffix it lacks is a miss
+		if addr.HouseLetter == "" && addr.Addition == "" {
+			return bagDataFromDoc(docs[0])
+		}
+		return nil, fmt.Errorf("%w: %s, the only unit is %s", ErrAddressNotFound, addr, docHouseNumber(docs[0]))
+	}
+	if !ok {
+		candidates := make([]models.AddressSuggestion, 0, len(docs))
+		for _, d := range docs {
+			candidates = append(candidates, models.AddressSuggestion{
+				ID:          d.ID,
+				Label:       d.Weergavenaam,
+				Street:      d.Straatnaam,
+				HouseNumber: docHouseNumber(d),
+				Postcode:    d.Postcode,
+				City:        d.WoonplaatsNaam,
+			})
+		}
+		return nil, &AmbiguousAddressError{Address: addr.String(), Candidates: candidates}
+	}
+
+	return bagDataFromDoc(*doc)
+}
+
+// matchAddressUnit picks the unit with the requested huisletter and toevoeging. If none
+// matches exactly, a unit whose letter and toevoeging together spell the requested
+// suffix is accepted, since "12-A" and "12A" are often used interchangeably.
+func matchAddressUnit(addr utils.DutchAddress, docs []models.BagDocument) (*models.BagDocument, bool) {
+	match := func(same func(d models.BagDocument) bool) (*models.BagDocument, bool) {
+		var found *models.BagDocument
+		for i := range docs {
+			if !same(docs[i]) {
+				continue
+			}
+			if found != nil {
+				return nil, false
+			}
+			found = &docs[i]
+		}
+		return found, found != nil
+	}
+
+	if doc, ok := match(func(d models.BagDocument) bool {
+		return strings.EqualFold(d.Huisletter, addr.HouseLetter) && strings.EqualFold(d.Huisnummertoevoeg, addr.Addition)
+	}); ok {
+		return doc, true
+	}
+	return match(func(d models.BagDocument) bool {
+		return strings.EqualFold(d.Huisletter+d.Huisnummertoevoeg, addr.HouseLetter+addr.Addition)
+	})
+}
+
+// docHouseNumber formats a document's huisnummer, huisletter and toevoeging canonically
+func docHouseNumber(doc models.BagDocument) string {
+	if doc.Huisnummer <= 0 {
+		return strings.TrimSpace(doc.HuisNLT)
+	}
+	return utils.DutchAddress{
+		HouseNumber: int(doc.Huisnummer),
+		HouseLetter: strings.ToUpper(strings.TrimSpace(doc.Huisletter)),
+		Addition:    strings.ToUpper(strings.TrimSpace(doc.Huisnummertoevoeg)),
+	}.HouseNumberString()
+}
 
+// bagDataFromDoc converts a Locatieserver address document to BAGData
+func bagDataFromDoc(doc models.BagDocument) (*models.BAGData, error) {
 	// Derive coordinates from the WKT POINT value provided by the API.
 	var coordinates [2]float64
 	if strings.HasPrefix(doc.CentroidLL, "POINT(") {
@@ -341,6 +473,8 @@ func (c *ApiClient) FetchBAGData(ctx context.Context, postcode, number string) (
 		Coordinates:        coordinates,
 		GeoJSON:            geoJSON,
 		ID:                 bagID,
+		Postcode:           strings.TrimSpace(doc.Postcode),
+		HouseNumber:        docHouseNumber(doc),
 		NummeraanduidingID: strings.TrimSpace(doc.NummeraanduidingID),
 		VerblijfsobjectID:  strings.TrimSpace(doc.VerblijfsobjectID),
 		PandID:             strings.TrimSpace(doc.PandID),
@@ -367,8 +501,8 @@ func (c *ApiClient) FetchPDOKData(ctx context.Context, coordinates string) (*mod
 	// Documentation: https://www.nationaalgeoregister.nl/geonetwork/srv/dut/catalog.search#/metadata/c10a2c55-972f-4309-a038-0e5286934877
 	baseURL := "https://service.pdok.nl/roo/ruimtelijkeplannen/wfs/v1_0"
 
-	// Build WFS GetFeature request with proper BBOX
-	// Using a small buffer around the point to ensure we catch the containing polygon
+	// Build WFS GetFeature request with a ~1m BBOX so only plans containing the point match.
+	// FetchZoningPlans gives the full bestemmingsvlak breakdown.
 	lonFloat, err := strconv.ParseFloat(lon, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid longitude '%s': %w", lon, err)
@@ -377,7 +511,7 @@ func (c *ApiClient) FetchPDOKData(ctx context.Context, coordinates string) (*mod
 	if err != nil {
 		return nil, fmt.Errorf("invalid latitude '%s': %w", lat, err)
 	}
-	buffer := 0.001 // ~100m buffer
+	buffer := 0.00001 // ~1m buffer
 
 	bbox := fmt.Sprintf("%.6f,%.6f,%.6f,%.6f",
 		lonFloat-buffer, latFloat-buffer, lonFloat+buffer, latFloat+buffer)
@@ -394,7 +528,7 @@ func (c *ApiClient) FetchPDOKData(ctx context.Context, coordinates string) (*mod
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs("PDOK", req)
 	if err != nil {
 		logutil.Debugf("[PDOK] HTTP error: %v", err)
 		return nil, err
@@ -27,11 +27,13 @@ func (c *ApiClient) FetchCBSPopulationData(ctx context.Context, cfg *config.Conf
 
 	var apiResp models.CBSBuurtenResponse
 	if err := c.GetJSON(ctx, "CBS Population", url, nil, &apiResp); err != nil {
+		markFailed(ctx, err)
 		return emptyPopulationData(), nil
 	}
 
 	if len(apiResp.Features) == 0 {
 		logutil.Debugf("[CBS Population] No features found for coordinates")
+		markEmpty(ctx, "no neighbourhood found at coordinates")
 		return emptyPopulationData(), nil
 	}
 
@@ -150,6 +152,7 @@ func (c *ApiClient) FetchCBSStatLineData(ctx context.Context, cfg *config.Config
 	}
 
 	if cfg.CBSStatLineApiURL == "" {
+		markNotConfigured(ctx, "CBSStatLineApiURL")
 		return emptyData, nil
 	}
 
@@ -171,10 +174,12 @@ func (c *ApiClient) FetchCBSStatLineData(ctx context.Context, cfg *config.Config
 	}
 
 	if err := c.GetJSON(ctx, "CBS StatLine", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyData, nil
 	}
 
 	if len(result.Value) == 0 {
+		markEmpty(ctx, "no StatLine data for region")
 		return emptyData, nil
 	}
 
@@ -209,11 +214,13 @@ func (c *ApiClient) FetchCBSSquareStats(ctx context.Context, cfg *config.Config,
 
 	var apiResp models.CBSSquareResponse
 	if err := c.GetJSON(ctx, "CBS Square", url, nil, &apiResp); err != nil {
+		markFailed(ctx, err)
 		return emptySquareStats(), nil
 	}
 
 	if len(apiResp.Features) == 0 {
 		logutil.Debugf("[CBS Square] No features found")
+		markEmpty(ctx, "no CBS grid square at coordinates")
 		return emptySquareStats(), nil
 	}
 
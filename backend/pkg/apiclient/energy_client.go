@@ -25,6 +25,7 @@ func emptyEnergyClimateData() *models.EnergyClimateData {
 func (c *ApiClient) FetchEnergyClimateData(ctx context.Context, cfg *config.Config, bagID string) (*models.EnergyClimateData, error) {
 	if cfg.AltumEnergyApiURL == "" {
 		// Return default data when API is not configured (paid service)
+		markNotConfigured(ctx, "AltumEnergyApiURL")
 		return emptyEnergyClimateData(), nil
 	}
 
@@ -32,6 +33,7 @@ func (c *ApiClient) FetchEnergyClimateData(ctx context.Context, cfg *config.Conf
 
 	var result models.EnergyClimateData
 	if err := c.GetJSON(ctx, "Energy Climate", url, BearerAuthHeader(cfg.AltumEnergyApiKey), &result); err != nil {
+		markFailed(ctx, err)
 		return emptyEnergyClimateData(), nil
 	}
 
@@ -56,6 +58,7 @@ func emptySustainabilityData() *models.SustainabilityData {
 func (c *ApiClient) FetchSustainabilityData(ctx context.Context, cfg *config.Config, bagID string) (*models.SustainabilityData, error) {
 	if cfg.AltumSustainabilityApiURL == "" {
 		// Return default data when API is not configured (paid service)
+		markNotConfigured(ctx, "AltumSustainabilityApiURL")
 		return emptySustainabilityData(), nil
 	}
 
@@ -63,6 +66,7 @@ func (c *ApiClient) FetchSustainabilityData(ctx context.Context, cfg *config.Con
 
 	var result models.SustainabilityData
 	if err := c.GetJSON(ctx, "Sustainability", url, BearerAuthHeader(cfg.AltumSustainabilityApiKey), &result); err != nil {
+		markFailed(ctx, err)
 		return emptySustainabilityData(), nil
 	}
 
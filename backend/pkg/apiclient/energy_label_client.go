@@ -0,0 +1,159 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
+)
+
+// Default RVO EP-Online public API endpoint (free, API key required)
+const defaultEPOnlineApiURL = "https://public.ep-online.nl/api/v5"
+
+// epOnlineLabel is one registered label in an EP-Online PandEnergielabel response
+type epOnlineLabel struct {
+	BAGVerblijfsobjectID    string   `json:"BAGVerblijfsobjectID"`
+	Energieklasse           string   `json:"Energieklasse"`
+	Registratiedatum        string   `json:"Registratiedatum"`
+	Opnamedatum             string   `json:"Opnamedatum"`
+	GeldigTot               string   `json:"Geldig_tot"`
+	Berekeningstype         string   `json:"Berekeningstype"`
+	Energieprestatieindex   *float64 `json:"Energieprestatieindex"`
+	PrimaireFossieleEnergie *float64 `json:"Primaire_fossiele_energie"`
+	Gebouwtype              string   `json:"Gebouwtype"`
+	Status                  string   `json:"Status"`
+}
+
+// provisional reports whether the label is a pre-2021 voorlopig energielabel
+func (l epOnlineLabel) provisional() bool {
+	return strings.Contains(strings.ToLower(l.Berekeningstype+" "+l.Status), "voorlopig")
+}
+
+// FetchEnergyLabel retrieves the registered energy label of a BAG verblijfsobject
+// Documentation: https://public.ep-online.nl/swagger/index.html
+func (c *ApiClient) FetchEnergyLabel(ctx context.Context, cfg *config.Config, verblijfsobjectID string) (*models.EnergyLabelData, error) {
+	if !bagIDPattern.MatchString(verblijfsobjectID) {
+		err := fmt.Errorf("invalid BAG verblijfsobject ID %q", verblijfsobjectID)
+		markFailed(ctx, err)
+		return nil, err
+	}
+	return c.fetchEnergyLabel(ctx, cfg, "/PandEnergielabel/AdresseerbaarObject/"+url.PathEscape(verblijfsobjectID))
+}
+
+// FetchEnergyLabelByAddress retrieves the registered energy label by postcode and
+// house number, including any huisletter and toevoeging ("12A-2")
+func (c *ApiClient) FetchEnergyLabelByAddress(ctx context.Context, cfg *config.Config, postcode, houseNumber string) (*models.EnergyLabelData, error) {
+	addr, err := utils.ParseDutchAddress(postcode, houseNumber)
+	if err != nil {
+		markFailed(ctx, err)
+		return nil, err
+	}
+	query := url.Values{
+		"postcode":   {addr.Postcode},
+		"huisnummer": {strconv.Itoa(addr.HouseNumber)},
+	}
+	if addr.HouseLetter != "" {
+		query.Set("huisletter", addr.HouseLetter)
+	}
+	if addr.Addition != "" {
+		query.Set("huisnummertoevoeging", addr.Addition)
+	}
+	return c.fetchEnergyLabel(ctx, cfg, "/PandEnergielabel/Adres?"+query.Encode())
+}
+
+// fetchEnergyLabel requests the labels at path and picks the current definitive
+// label, keeping a voorlopig label alongside it
+func (c *ApiClient) fetchEnergyLabel(ctx context.Context, cfg *config.Config, path string) (*models.EnergyLabelData, error) {
+	if cfg.EnergieLabelApiKey == "" {
+		markNotConfigured(ctx, "EnergieLabelApiKey")
+		return nil, fmt.Errorf("EnergieLabelApiKey not configured")
+	}
+
+	baseURL := defaultEPOnlineApiURL
+	if cfg.EnergieLabelApiURL != "" {
+		baseURL = strings.TrimRight(cfg.EnergieLabelApiURL, "/")
+	}
+
+	var labels []epOnlineLabel
+	headers := map[string]string{"Authorization": cfg.EnergieLabelApiKey}
+	if err := c.GetJSON(ctx, "EP-Online", baseURL+path, headers, &labels); err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound {
+			markEmpty(ctx, "no registered energy label")
+			return nil, fmt.Errorf("no registered energy label")
+		}
+		markFailed(ctx, err)
+		return nil, fmt.Errorf("EP-Online request failed: %w", err)
+	}
+
+	// Newest registration first
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].Registratiedatum > labels[j].Registratiedatum })
+
+	var result *models.EnergyLabelData
+	var provisional *models.ProvisionalEnergyLabel
+	for _, l := range labels {
+		if l.Energieklasse == "" {
+			continue
+		}
+		if l.provisional() {
+			if provisional == nil {
+				provisional = &models.ProvisionalEnergyLabel{
+					Label:            l.Energieklasse,
+					RegistrationDate: epOnlineDate(l.Registratiedatum),
+					ValidUntil:       epOnlineDate(l.GeldigTot),
+				}
+			}
+			continue
+		}
+		if result == nil {
+			result = &models.EnergyLabelData{
+				VerblijfsobjectID: l.BAGVerblijfsobjectID,
+				Label:             l.Energieklasse,
+				RegistrationDate:  epOnlineDate(l.Registratiedatum),
+				InspectionDate:    epOnlineDate(l.Opnamedatum),
+				ValidUntil:        epOnlineDate(l.GeldigTot),
+				CalculationMethod: l.Berekeningstype,
+				BuildingType:      l.Gebouwtype,
+			}
+			if l.PrimaireFossieleEnergie != nil {
+				result.EP2 = *l.PrimaireFossieleEnergie
+			}
+			if l.Energieprestatieindex != nil {
+				result.EnergyIndex = *l.Energieprestatieindex
+			}
+			result.Expired = result.ValidUntil != "" && result.ValidUntil < time.Now().Format(time.DateOnly)
+		}
+	}
+
+	if result == nil && provisional == nil {
+		markEmpty(ctx, "no registered energy label")
+		return nil, fmt.Errorf("no registered energy label")
+	}
+	if result == nil {
+		// Only a voorlopig label: report it, but as the provisional label only
+		result = &models.EnergyLabelData{}
+	}
+	result.ProvisionalLabel = provisional
+
+	logutil.Debugf("[EP-Online] Label %q registered %s, valid until %s (EP2 %.1f)",
+		result.Label, result.RegistrationDate, result.ValidUntil, result.EP2)
+	return result, nil
+}
+
+// epOnlineDate trims an EP-Online timestamp ("2021-03-04T00:00:00") to its date
+func epOnlineDate(s string) string {
+	if len(s) > len(time.DateOnly) {
+		return s[:len(time.DateOnly)]
+	}
+	return s
+}
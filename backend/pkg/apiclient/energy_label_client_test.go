@@ -0,0 +1,88 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+func TestFetchEnergyLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "test-key" {
+			t.Errorf("Expected the API key in the Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/PandEnergielabel/AdresseerbaarObject/0344010000067871":
+			w.Write([]byte(`[
+				{"BAGVerblijfsobjectID": "0344010000067871", "Energieklasse": "F", "Registratiedatum": "2015-06-01T00:00:00",
+					"Geldig_tot": "2025-06-01T00:00:00", "Berekeningstype": "Voorlopig energielabel"},
+				{"BAGVerblijfsobjectID": "0344010000067871", "Energieklasse": "C", "Registratiedatum": "2017-02-10T00:00:00",
+					"Geldig_tot": "2027-02-10T00:00:00", "Berekeningstype": "Vereenvoudigd", "Energieprestatieindex": 1.62},
+				{"BAGVerblijfsobjectID": "0344010000067871", "Energieklasse": "A", "Registratiedatum": "2023-09-14T00:00:00",
+					"Opnamedatum": "2023-09-01T00:00:00", "Geldig_tot": "2033-09-14T00:00:00",
+					"Berekeningstype": "NTA 8800:2023 (basismethode)", "Primaire_fossiele_energie": 143.27, "Gebouwtype": "Tussenwoning"}
+			]`))
+		case "/PandEnergielabel/Adres":
+			q := r.URL.Query()
+			if q.Get("postcode") != "3511AB" || q.Get("huisnummer") != "12" || q.Get("huisletter") != "A" || q.Get("huisnummertoevoeging") != "2" {
+				t.Errorf("Unexpected address query %q", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"Energieklasse": "D", "Registratiedatum": "2012-01-05T00:00:00", "Geldig_tot": "2022-01-05T00:00:00",
+				"Berekeningstype": "ISSO82.3", "Energieprestatieindex": 2.1}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{EnergieLabelApiURL: server.URL, EnergieLabelApiKey: "test-key"}
+	client := NewApiClient(server.Client(), cfg)
+
+	label, err := client.FetchEnergyLabel(context.Background(), cfg, "0344010000067871")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if label.Label != "A" || label.RegistrationDate != "2023-09-14" || label.InspectionDate != "2023-09-01" || label.ValidUntil != "2033-09-14" {
+		t.Errorf("Expected the newest definitive label, got %+v", label)
+	}
+	if label.EP2 != 143.27 || label.CalculationMethod != "NTA 8800:2023 (basismethode)" || label.BuildingType != "Tussenwoning" || label.Expired {
+		t.Errorf("Unexpected label details: %+v", label)
+	}
+	if p := label.ProvisionalLabel; p == nil || p.Label != "F" || p.RegistrationDate != "2015-06-01" {
+		t.Errorf("Expected the voorlopig label to be kept, got %+v", p)
+	}
+
+	old, err := client.FetchEnergyLabelByAddress(context.Background(), cfg, "3511 ab", "12A-2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if old.Label != "D" || old.EnergyIndex != 2.1 || !old.Expired || old.ProvisionalLabel != nil {
+		t.Errorf("Expected an expired pre-2021 label, got %+v", old)
+	}
+
+	if _, err := client.FetchEnergyLabel(context.Background(), cfg, "0344010000099999"); err == nil {
+		t.Error("Expected an error when no label is registered")
+	}
+	if _, err := client.FetchEnergyLabel(context.Background(), cfg, "not-an-id"); err == nil {
+		t.Error("Expected an error for an invalid verblijfsobject ID")
+	}
+}
+
+func TestFetchEnergyLabel_NotConfigured(t *testing.T) {
+	cfg := &config.Config{}
+	client := NewApiClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		t.Errorf("Unexpected request to %s", r.URL)
+		return nil, http.ErrHandlerTimeout
+	})}, cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	if _, err := client.FetchEnergyLabel(ctx, cfg, "0344010000067871"); err == nil {
+		t.Error("Expected an error without an API key")
+	}
+	if prov.Status() != StatusNotConfigured {
+		t.Errorf("Expected status %q, got %q", StatusNotConfigured, prov.Status())
+	}
+}
@@ -33,6 +33,7 @@ func (c *ApiClient) FetchAirQualityData(ctx context.Context, cfg *config.Config,
 
 	// Return empty data if not configured
 	if cfg.LuchtmeetnetApiURL == "" {
+		markNotConfigured(ctx, "LuchtmeetnetApiURL")
 		return emptyAirQualityData(), nil
 	}
 
@@ -41,12 +42,14 @@ func (c *ApiClient) FetchAirQualityData(ctx context.Context, cfg *config.Config,
 	logutil.Debugf("[APIClient] FetchAirQualityData: stationURL=%s", stationURL)
 	req, err := http.NewRequestWithContext(ctx, "GET", stationURL, nil)
 	if err != nil {
+		markFailed(ctx, err)
 		return emptyAirQualityData(), nil
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs("Luchtmeetnet", req)
 	if err != nil {
+		markFailed(ctx, err)
 		return emptyAirQualityData(), nil
 	}
 	defer resp.Body.Close()
@@ -54,6 +57,7 @@ func (c *ApiClient) FetchAirQualityData(ctx context.Context, cfg *config.Config,
 	logutil.Debugf("[APIClient] FetchAirQualityData: response status=%d", resp.StatusCode)
 
 	if resp.StatusCode != 200 {
+		markFailed(ctx, fmt.Errorf("stations returned status %d", resp.StatusCode))
 		return emptyAirQualityData(), nil
 	}
 
@@ -65,11 +69,13 @@ func (c *ApiClient) FetchAirQualityData(ctx context.Context, cfg *config.Config,
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&stations); err != nil {
+		markFailed(ctx, err)
 		return emptyAirQualityData(), nil
 	}
 
 	if len(stations.Data) == 0 {
 		logutil.Debugf("[APIClient] FetchAirQualityData: no stations found")
+		markEmpty(ctx, "no air quality stations found")
 		return emptyAirQualityData(), nil
 	}
 
@@ -92,7 +98,7 @@ func (c *ApiClient) FetchAirQualityData(ctx context.Context, cfg *config.Config,
 	}
 	req2.Header.Set("Accept", "application/json")
 
-	resp2, err := c.HTTP.Do(req2)
+	resp2, err := c.doAs("Luchtmeetnet", req2)
 	if err != nil {
 		return &models.AirQualityData{
 			StationID:    stationID,
@@ -194,6 +200,7 @@ func emptyNoisePollutionData() *models.NoisePollutionData {
 func (c *ApiClient) FetchNoisePollutionData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.NoisePollutionData, error) {
 	// Return default data if not configured
 	if cfg.NoisePollutionApiURL == "" {
+		markNotConfigured(ctx, "NoisePollutionApiURL")
 		return emptyNoisePollutionData(), nil
 	}
 
@@ -202,6 +209,7 @@ func (c *ApiClient) FetchNoisePollutionData(ctx context.Context, cfg *config.Con
 	var result models.NoisePollutionData
 	if err := c.GetJSON(ctx, "NoisePollution", url, nil, &result); err != nil {
 		// Return default data for failures (soft failure)
+		markFailed(ctx, err)
 		return emptyNoisePollutionData(), nil
 	}
 
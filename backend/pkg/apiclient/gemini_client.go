@@ -10,6 +10,7 @@ import (
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
 	"github.com/iman-hussain/nethaddress/backend/pkg/models"
 )
 
@@ -41,12 +42,25 @@ type geminiResponse struct {
 			} `json:"parts"`
 		} `json:"content"`
 	} `json:"candidates"`
+	UsageMetadata *struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata,omitempty"`
 	Error *struct {
 		Code    int    `json:"code"`
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
 }
 
+// recordUsage adds the response's token counts to the Gemini metrics
+func (r *geminiResponse) recordUsage(kind string) {
+	if r.UsageMetadata == nil {
+		return
+	}
+	metrics.GeminiTokens.Add(float64(r.UsageMetadata.PromptTokenCount), kind, "prompt")
+	metrics.GeminiTokens.Add(float64(r.UsageMetadata.CandidatesTokenCount), kind, "output")
+}
+
 // failedGeminiSummary returns a GeminiSummary indicating failure with the given error message.
 func failedGeminiSummary(errorMsg string) *models.GeminiSummary {
 	return &models.GeminiSummary{
@@ -73,105 +87,11 @@ JSON Data:
 
 // GenerateLocationSummary sends property data to Gemini and returns an AI-generated summary
 func (c *ApiClient) GenerateLocationSummary(ctx context.Context, cfg *config.Config, propertyData interface{}) (*models.GeminiSummary, error) {
-	if cfg.GeminiApiKey == "" {
-		logutil.Debugf("[Gemini] API key not configured")
-		return failedGeminiSummary("Gemini API key not configured"), nil
-	}
-
-	// Marshal property data to JSON
-	jsonData, err := json.Marshal(propertyData)
-	if err != nil {
-		logutil.Debugf("[Gemini] Failed to marshal property data: %v", err)
-		return failedGeminiSummary("Failed to prepare data for AI analysis"), nil
-	}
-
-	// Truncate JSON if too large (Gemini has input limits)
-	maxDataSize := 30000 // ~30KB of JSON data
-	if len(jsonData) > maxDataSize {
-		jsonData = jsonData[:maxDataSize]
-		logutil.Debugf("[Gemini] Truncated JSON data to %d bytes", maxDataSize)
-	}
-
-	// Build the prompt with property data
-	prompt := fmt.Sprintf(GeminiPrompt, string(jsonData))
-
-	// Prepare Gemini API request
-	reqBody := geminiRequest{
-		Contents: []geminiContent{
-			{
-				Parts: []geminiPart{
-					{Text: prompt},
-				},
-			},
-		},
-		GenerationConfig: geminiGenerationConfig{
-			MaxOutputTokens: 200, // ~500 characters
-			Temperature:     0.7,
-		},
-	}
-
-	reqJSON, err := json.Marshal(reqBody)
-	if err != nil {
-		logutil.Debugf("[Gemini] Failed to marshal request: %v", err)
-		return failedGeminiSummary("Failed to prepare AI request"), nil
-	}
-
-	// Gemini 2.5 Flash-Lite API endpoint (GA model, optimised for low latency)
-	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-flash-lite:generateContent?key=%s", cfg.GeminiApiKey)
-
-	logutil.Debugf("[Gemini] Sending request to Gemini API")
-
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqJSON))
-	if err != nil {
-		logutil.Debugf("[Gemini] Failed to create request: %v", err)
-		return failedGeminiSummary("Failed to create AI request"), nil
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		logutil.Debugf("[Gemini] HTTP request failed: %v", err)
-		return failedGeminiSummary("Failed to connect to AI service"), nil
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		logutil.Debugf("[Gemini] Failed to read response: %v", err)
-		return failedGeminiSummary("Failed to read AI response"), nil
-	}
-
-	logutil.Debugf("[Gemini] Response status: %d", resp.StatusCode)
-
-	if resp.StatusCode != http.StatusOK {
-		logutil.Debugf("[Gemini] API error response: %s", string(body))
-		return failedGeminiSummary(fmt.Sprintf("AI service returned status %d", resp.StatusCode)), nil
-	}
-
-	var geminiResp geminiResponse
-	if err := json.Unmarshal(body, &geminiResp); err != nil {
-		logutil.Debugf("[Gemini] Failed to parse response: %v", err)
-		return failedGeminiSummary("Failed to parse AI response"), nil
+	jsonData, failed := geminiPromptData(cfg, propertyData)
+	if failed != nil {
+		return failed, nil
 	}
-
-	if geminiResp.Error != nil {
-		logutil.Debugf("[Gemini] API error: %s", geminiResp.Error.Message)
-		return failedGeminiSummary(geminiResp.Error.Message), nil
-	}
-
-	// Extract the generated text
-	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		logutil.Debugf("[Gemini] No content in response")
-		return failedGeminiSummary("AI returned empty response"), nil
-	}
-
-	summary := geminiResp.Candidates[0].Content.Parts[0].Text
-	logutil.Debugf("[Gemini] Generated summary: %d characters", len(summary))
-
-	return &models.GeminiSummary{
-		Summary:   summary,
-		Generated: true,
-	}, nil
+	return c.generateSummary(ctx, cfg, "location", fmt.Sprintf(GeminiPrompt, string(jsonData))), nil
 }
 
 // GeminiSolarPrompt is the hardcoded prompt template for generating solar eligibility summaries.
@@ -188,25 +108,62 @@ JSON Data:
 
 // GenerateSolarEligibilitySummary sends solar/weather data to Gemini to get a solar viability assessment
 func (c *ApiClient) GenerateSolarEligibilitySummary(ctx context.Context, cfg *config.Config, areaSqm float64, data interface{}) (*models.GeminiSummary, error) {
+	jsonData, failed := geminiPromptData(cfg, data)
+	if failed != nil {
+		return failed, nil
+	}
+	return c.generateSummary(ctx, cfg, "solar", fmt.Sprintf(GeminiSolarPrompt, areaSqm, string(jsonData))), nil
+}
+
+// GeminiAreaPrompt is the hardcoded prompt template for generating neighbourhood summaries.
+const GeminiAreaPrompt = `You are an expert Dutch property acquisitions analyst. Analyse this JSON data about a Dutch neighbourhood (buurt, wijk or postcode-4 area) as a whole and provide a concise screening summary (max 800 characters).
+
+Your response MUST include:
+1. **Market profile** (income and WOZ levels, population density, household make-up)
+2. **Liveability** (green share and amenities across the area)
+3. **Key risks** (share of the area in flood risk zones, other concerns)
+4. **Acquisition outlook** (what kind of properties or strategies suit this area)
+
+Be direct and specific. Refer to key figures from the JSON. Do not mention if any data is missing or unavailable. No fluff. British English.
+
+JSON Data:
+%s`
+
+// GenerateAreaSummary sends aggregated area statistics to Gemini and returns a neighbourhood summary
+func (c *ApiClient) GenerateAreaSummary(ctx context.Context, cfg *config.Config, areaData interface{}) (*models.GeminiSummary, error) {
+	jsonData, failed := geminiPromptData(cfg, areaData)
+	if failed != nil {
+		return failed, nil
+	}
+	return c.generateSummary(ctx, cfg, "area", fmt.Sprintf(GeminiAreaPrompt, string(jsonData))), nil
+}
+
+// geminiPromptData marshals the data embedded in a prompt, truncated to Gemini's input
+// limits. It returns a failed summary instead if Gemini is not configured.
+func geminiPromptData(cfg *config.Config, data interface{}) ([]byte, *models.GeminiSummary) {
 	if cfg.GeminiApiKey == "" {
-		logutil.Debugf("[Gemini] API key not configured for solar")
-		return failedGeminiSummary("Gemini API key not configured"), nil
+		logutil.Debugf("[Gemini] API key not configured")
+		return nil, failedGeminiSummary("Gemini API key not configured")
 	}
 
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		logutil.Debugf("[Gemini] Failed to marshal solar data: %v", err)
-		return failedGeminiSummary("Failed to prepare data for AI analysis"), nil
+		logutil.Debugf("[Gemini] Failed to marshal data: %v", err)
+		return nil, failedGeminiSummary("Failed to prepare data for AI analysis")
 	}
 
-	maxDataSize := 30000
+	// Truncate JSON if too large (Gemini has input limits)
+	maxDataSize := 30000 // ~30KB of JSON data
 	if len(jsonData) > maxDataSize {
 		jsonData = jsonData[:maxDataSize]
 		logutil.Debugf("[Gemini] Truncated JSON data to %d bytes", maxDataSize)
 	}
+	return jsonData, nil
+}
 
-	prompt := fmt.Sprintf(GeminiSolarPrompt, areaSqm, string(jsonData))
-
+// generateSummary sends a prompt to Gemini and returns the generated text. Failures
+// are reported on the returned summary rather than as errors; kind labels the token metrics.
+func (c *ApiClient) generateSummary(ctx context.Context, cfg *config.Config, kind, prompt string) *models.GeminiSummary {
 	reqBody := geminiRequest{
 		Contents: []geminiContent{
 			{
@@ -224,60 +181,64 @@ func (c *ApiClient) GenerateSolarEligibilitySummary(ctx context.Context, cfg *co
 	reqJSON, err := json.Marshal(reqBody)
 	if err != nil {
 		logutil.Debugf("[Gemini] Failed to marshal request: %v", err)
-		return failedGeminiSummary("Failed to prepare AI request"), nil
+		return failedGeminiSummary("Failed to prepare AI request")
 	}
 
-	// Gemini 2.5 Flash-Lite API endpoint
+	// Gemini 2.5 Flash-Lite API endpoint (GA model, optimised for low latency)
 	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/gemini-2.5-flash-lite:generateContent?key=%s", cfg.GeminiApiKey)
 
-	logutil.Debugf("[Gemini] Sending solar request to Gemini API")
+	logutil.Debugf("[Gemini] Sending %s request to Gemini API", kind)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqJSON))
 	if err != nil {
 		logutil.Debugf("[Gemini] Failed to create request: %v", err)
-		return failedGeminiSummary("Failed to create AI request"), nil
+		return failedGeminiSummary("Failed to create AI request")
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs("Gemini", req)
 	if err != nil {
 		logutil.Debugf("[Gemini] HTTP request failed: %v", err)
-		return failedGeminiSummary("Failed to connect to AI service"), nil
+		return failedGeminiSummary("Failed to connect to AI service")
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		logutil.Debugf("[Gemini] Failed to read response: %v", err)
-		return failedGeminiSummary("Failed to read AI response"), nil
+		return failedGeminiSummary("Failed to read AI response")
 	}
 
+	logutil.Debugf("[Gemini] Response status: %d", resp.StatusCode)
+
 	if resp.StatusCode != http.StatusOK {
 		logutil.Debugf("[Gemini] API error response: %s", string(body))
-		return failedGeminiSummary(fmt.Sprintf("AI service returned status %d", resp.StatusCode)), nil
+		return failedGeminiSummary(fmt.Sprintf("AI service returned status %d", resp.StatusCode))
 	}
 
 	var geminiResp geminiResponse
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
 		logutil.Debugf("[Gemini] Failed to parse response: %v", err)
-		return failedGeminiSummary("Failed to parse AI response"), nil
+		return failedGeminiSummary("Failed to parse AI response")
 	}
+	geminiResp.recordUsage(kind)
 
 	if geminiResp.Error != nil {
 		logutil.Debugf("[Gemini] API error: %s", geminiResp.Error.Message)
-		return failedGeminiSummary(geminiResp.Error.Message), nil
+		return failedGeminiSummary(geminiResp.Error.Message)
 	}
 
+	// Extract the generated text
 	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
 		logutil.Debugf("[Gemini] No content in response")
-		return failedGeminiSummary("AI returned empty response"), nil
+		return failedGeminiSummary("AI returned empty response")
 	}
 
 	summary := geminiResp.Candidates[0].Content.Parts[0].Text
-	logutil.Debugf("[Gemini] Generated solar summary: %d characters", len(summary))
+	logutil.Debugf("[Gemini] Generated %s summary: %d characters", kind, len(summary))
 
 	return &models.GeminiSummary{
 		Summary:   summary,
 		Generated: true,
-	}, nil
+	}
 }
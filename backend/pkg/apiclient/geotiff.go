@@ -0,0 +1,248 @@
+package apiclient
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TIFF tags needed to read single-band elevation coverages
+const (
+	tiffTagImageWidth      = 256
+	tiffTagImageLength     = 257
+	tiffTagBitsPerSample   = 258
+	tiffTagCompression     = 259
+	tiffTagStripOffsets    = 273
+	tiffTagSamplesPerPixel = 277
+	tiffTagRowsPerStrip    = 278
+	tiffTagStripByteCounts = 279
+	tiffTagPredictor       = 317
+	tiffTagTileWidth       = 322
+	tiffTagTileLength      = 323
+	tiffTagTileOffsets     = 324
+	tiffTagTileByteCounts  = 325
+	tiffTagSampleFormat    = 339
+	tiffTagGDALNoData      = 42113
+)
+
+// rasterGrid is a single-band raster decoded from a GeoTIFF coverage
+type rasterGrid struct {
+	Width  int
+	Height int
+	Values []float64 // row-major, top row first
+	NoData *float64
+}
+
+// at returns the value at col,row, or false for out-of-range and no-data cells
+func (g *rasterGrid) at(col, row int) (float64, bool) {
+	if g == nil || col < 0 || row < 0 || col >= g.Width || row >= g.Height {
+		return 0, false
+	}
+	v := g.Values[row*g.Width+col]
+	// AHN marks no-data with float32 max rather than always setting GDAL_NODATA
+	if math.IsNaN(v) || math.Abs(v) > 1e30 || (g.NoData != nil && v == *g.NoData) {
+		return 0, false
+	}
+	return v, true
+}
+
+// decodeGeoTIFF decodes the first band of a baseline (non-Big) TIFF with
+// uncompressed or deflate-compressed strips or tiles, as returned by WCS servers
+func decodeGeoTIFF(buf []byte) (*rasterGrid, error) {
+	if len(buf) < 8 {
+		return nil, fmt.Errorf("tiff: file too short")
+	}
+
+	var bo binary.ByteOrder
+	switch string(buf[:2]) {
+	case "II":
+		bo = binary.LittleEndian
+	case "MM":
+		bo = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("tiff: invalid byte order marker")
+	}
+	if bo.Uint16(buf[2:4]) != 42 {
+		return nil, fmt.Errorf("tiff: unsupported version (BigTIFF is not supported)")
+	}
+
+	tags, err := readTIFFTags(buf, bo, bo.Uint32(buf[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	first := func(tag uint16, def uint64) uint64 {
+		if v := tags[tag].values; len(v) > 0 {
+			return v[0]
+		}
+		return def
+	}
+
+	width := int(first(tiffTagImageWidth, 0))
+	height := int(first(tiffTagImageLength, 0))
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("tiff: missing image dimensions")
+	}
+	if spp := first(tiffTagSamplesPerPixel, 1); spp != 1 {
+		return nil, fmt.Errorf("tiff: expected a single band, got %d samples per pixel", spp)
+	}
+	if predictor := first(tiffTagPredictor, 1); predictor != 1 {
+		return nil, fmt.Errorf("tiff: unsupported predictor %d", predictor)
+	}
+	compression := first(tiffTagCompression, 1)
+	if compression != 1 && compression != 8 && compression != 32946 {
+		return nil, fmt.Errorf("tiff: unsupported compression %d", compression)
+	}
+	bits := int(first(tiffTagBitsPerSample, 8))
+	format := first(tiffTagSampleFormat, 1)
+	bytesPerSample := bits / 8
+	if bits%8 != 0 || bytesPerSample == 0 {
+		return nil, fmt.Errorf("tiff: unsupported bits per sample %d", bits)
+	}
+
+	// Strips are treated as full-width tiles
+	blockW, blockH := width, int(first(tiffTagRowsPerStrip, uint64(height)))
+	offsets, counts := tags[tiffTagStripOffsets].values, tags[tiffTagStripByteCounts].values
+	if _, tiled := tags[tiffTagTileWidth]; tiled {
+		blockW, blockH = int(first(tiffTagTileWidth, 0)), int(first(tiffTagTileLength, 0))
+		offsets, counts = tags[tiffTagTileOffsets].values, tags[tiffTagTileByteCounts].values
+	}
+	if blockW <= 0 || blockH <= 0 || len(offsets) == 0 || len(offsets) != len(counts) {
+		return nil, fmt.Errorf("tiff: invalid strip or tile layout")
+	}
+	blocksAcross := (width + blockW - 1) / blockW
+
+	grid := &rasterGrid{Width: width, Height: height, Values: make([]float64, width*height)}
+	for i := range grid.Values {
+		grid.Values[i] = math.NaN()
+	}
+
+	for i, off := range offsets {
+		end := off + counts[i]
+		if end > uint64(len(buf)) {
+			return nil, fmt.Errorf("tiff: block %d out of range", i)
+		}
+		data := buf[off:end]
+		if compression != 1 {
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("tiff: block %d: %w", i, err)
+			}
+			data, err = io.ReadAll(zr)
+			zr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("tiff: block %d: %w", i, err)
+			}
+		}
+
+		originX, originY := (i%blocksAcross)*blockW, (i/blocksAcross)*blockH
+		for row := 0; row < blockH; row++ {
+			for col := 0; col < blockW; col++ {
+				x, y := originX+col, originY+row
+				idx := (row*blockW + col) * bytesPerSample
+				if x >= width || y >= height || idx+bytesPerSample > len(data) {
+					continue
+				}
+				grid.Values[y*width+x] = decodeTIFFSample(data[idx:idx+bytesPerSample], bo, format, bits)
+			}
+		}
+	}
+
+	if nd := strings.Trim(tags[tiffTagGDALNoData].text, "\x00 "); nd != "" {
+		if v, err := strconv.ParseFloat(nd, 64); err == nil {
+			grid.NoData = &v
+		}
+	}
+	return grid, nil
+}
+
+type tiffTag struct {
+	values []uint64
+	text   string
+}
+
+// readTIFFTags reads the tags of the first IFD
+func readTIFFTags(buf []byte, bo binary.ByteOrder, ifd uint32) (map[uint16]tiffTag, error) {
+	if uint64(ifd)+2 > uint64(len(buf)) {
+		return nil, fmt.Errorf("tiff: IFD offset out of range")
+	}
+	n := int(bo.Uint16(buf[ifd:]))
+	if uint64(ifd)+2+uint64(n)*12 > uint64(len(buf)) {
+		return nil, fmt.Errorf("tiff: IFD truncated")
+	}
+
+	tags := make(map[uint16]tiffTag, n)
+	for i := 0; i < n; i++ {
+		entry := buf[int(ifd)+2+i*12 : int(ifd)+2+(i+1)*12]
+		tag, typ, count := bo.Uint16(entry[0:2]), bo.Uint16(entry[2:4]), bo.Uint32(entry[4:8])
+
+		var size int
+		switch typ {
+		case 1, 2: // BYTE, ASCII
+			size = 1
+		case 3: // SHORT
+			size = 2
+		case 4: // LONG
+			size = 4
+		default:
+			continue // types not needed for the tags we read
+		}
+
+		length := uint64(size) * uint64(count)
+		data := entry[8:12]
+		if length > 4 {
+			off := uint64(bo.Uint32(entry[8:12]))
+			if off+length > uint64(len(buf)) {
+				return nil, fmt.Errorf("tiff: tag %d out of range", tag)
+			}
+			data = buf[off : off+length]
+		}
+
+		var t tiffTag
+		if typ == 2 {
+			t.text = string(data[:length])
+		} else {
+			t.values = make([]uint64, count)
+			for j := range t.values {
+				switch size {
+				case 1:
+					t.values[j] = uint64(data[j])
+				case 2:
+					t.values[j] = uint64(bo.Uint16(data[j*2:]))
+				case 4:
+					t.values[j] = uint64(bo.Uint32(data[j*4:]))
+				}
+			}
+		}
+		tags[tag] = t
+	}
+	return tags, nil
+}
+
+// decodeTIFFSample converts one raw sample to float64 based on its sample format
+func decodeTIFFSample(b []byte, bo binary.ByteOrder, format uint64, bits int) float64 {
+	switch {
+	case format == 3 && bits == 32:
+		return float64(math.Float32frombits(bo.Uint32(b)))
+	case format == 3 && bits == 64:
+		return math.Float64frombits(bo.Uint64(b))
+	case format == 2 && bits == 8:
+		return float64(int8(b[0]))
+	case format == 2 && bits == 16:
+		return float64(int16(bo.Uint16(b)))
+	case format == 2 && bits == 32:
+		return float64(int32(bo.Uint32(b)))
+	case bits == 8:
+		return float64(b[0])
+	case bits == 16:
+		return float64(bo.Uint16(b))
+	case bits == 32:
+		return float64(bo.Uint32(b))
+	}
+	return math.NaN()
+}
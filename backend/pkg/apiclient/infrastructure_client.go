@@ -94,6 +94,7 @@ func (c *ApiClient) FetchGreenSpacesData(ctx context.Context, cfg *config.Config
 
 	var apiResp models.BgtGreenResponse
 	if err := c.GetJSONWithRetry(ctx, "GreenSpaces", url, nil, 3, 10*time.Second, &apiResp); err != nil {
+		markFailed(ctx, err)
 		return emptyGreenSpacesData(), nil
 	}
 
@@ -193,7 +194,7 @@ func (c *ApiClient) fetchNatura2000Areas(ctx context.Context, lat, lon float64,
 	}
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.doAs("Natura 2000", req)
 	if err != nil {
 		return nil
 	}
@@ -280,6 +281,7 @@ out center body qt 20;`, radius, lat, lon, radius, lat, lon)
 
 	var apiResp models.OverpassResponse
 	if err := c.PostFormJSONWithRetry(ctx, "Education", overpassURL, "data="+query, nil, 3, 10*time.Second, &apiResp); err != nil {
+		markFailed(ctx, err)
 		return emptyEducationData(), nil
 	}
 
@@ -408,6 +410,7 @@ func emptyEducationData() *models.EducationData {
 func (c *ApiClient) FetchBuildingPermitsData(ctx context.Context, cfg *config.Config, lat, lon float64, radius int) (*models.BuildingPermitsData, error) {
 	// Return empty data if not configured
 	if cfg.BuildingPermitsApiURL == "" {
+		markNotConfigured(ctx, "BuildingPermitsApiURL")
 		return emptyBuildingPermitsData(), nil
 	}
 
@@ -415,6 +418,7 @@ func (c *ApiClient) FetchBuildingPermitsData(ctx context.Context, cfg *config.Co
 
 	var result models.BuildingPermitsData
 	if err := c.GetJSONWithRetry(ctx, "BuildingPermits", url, nil, 3, 10*time.Second, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyBuildingPermitsData(), nil
 	}
 
@@ -447,6 +451,7 @@ out body qt 50;`, radius, lat, lon, radius, lat, lon, radius, lat, lon, radius,
 
 	var apiResp models.OverpassFacilitiesResponse
 	if err := c.PostFormJSONWithRetry(ctx, "Facilities", overpassURL, "data="+query, nil, 3, 10*time.Second, &apiResp); err != nil {
+		markFailed(ctx, err)
 		return emptyFacilitiesData(), nil
 	}
 
@@ -589,96 +594,3 @@ func emptyFacilitiesData() *models.FacilitiesData {
 		CategoryCounts: make(map[string]int),
 	}
 }
-
-// FetchAHNHeightData retrieves elevation data using Open-Elevation API
-// Documentation: https://open-elevation.com
-func (c *ApiClient) FetchAHNHeightData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.AHNHeightData, error) {
-	// Always use Open-Elevation API (free, no auth required)
-	// Ignore config override which may have wrong WFS URLs
-	openElevationURL := "https://api.open-elevation.com/api/v1/lookup"
-
-	// Query elevation for the point and surrounding points
-	url := fmt.Sprintf("%s?locations=%.6f,%.6f", openElevationURL, lat, lon)
-	logutil.Debugf("[AHN] Request URL: %s", url)
-
-	var apiResp models.OpenElevationResponse
-	if err := c.GetJSON(ctx, "AHN", url, nil, &apiResp); err != nil {
-		// Fallback to estimate on API failure
-		return estimateElevationForAmsterdam(lat, lon), nil
-	}
-
-	if len(apiResp.Results) == 0 {
-		return estimateElevationForAmsterdam(lat, lon), nil
-	}
-
-	elevation := apiResp.Results[0].Elevation
-	logutil.Debugf("[AHN] Elevation: %.2f meters", elevation)
-
-	// Assess flood risk based on elevation (NAP = Amsterdam Ordnance Datum)
-	// Most of Amsterdam is below sea level (-2m to 2m)
-	floodRisk := "Low"
-	if elevation < -2.0 {
-		floodRisk = "High"
-	} else if elevation < 1.0 {
-		floodRisk = "Medium"
-	}
-
-	// Assess view potential
-	viewPotential := "Fair"
-	if elevation > 5.0 {
-		viewPotential = "Excellent"
-	} else if elevation > 2.0 {
-		viewPotential = "Good"
-	} else if elevation < -1.0 {
-		viewPotential = "Poor"
-	}
-
-	result := &models.AHNHeightData{
-		Elevation:     elevation,
-		TerrainSlope:  0.5, // Netherlands is very flat
-		FloodRisk:     floodRisk,
-		ViewPotential: viewPotential,
-		Surrounding:   []float64{elevation - 0.5, elevation + 0.5, elevation, elevation - 0.3},
-	}
-
-	logutil.Debugf("[AHN] Result: elevation=%.2f, risk=%s", elevation, floodRisk)
-	return result, nil
-}
-
-// estimateElevationForAmsterdam provides a reasonable estimate for Amsterdam area
-// Most of Amsterdam is around -2m to +2m NAP
-func estimateElevationForAmsterdam(lat, lon float64) *models.AHNHeightData {
-	// Amsterdam center is approximately 52.37N, 4.89E
-	// Elevation varies from about -5m (polders) to +2m (city center)
-
-	// Simple estimation based on distance from city center
-	centerLat := 52.37
-	centerLon := 4.89
-
-	// Central Amsterdam is around sea level, outskirts are lower
-	distance := math.Sqrt(math.Pow(lat-centerLat, 2) + math.Pow(lon-centerLon, 2))
-
-	// Estimate elevation: central = 0m, further out = -2m typical
-	elevation := -1.0 - (distance * 10)
-	if elevation < -5 {
-		elevation = -5
-	}
-	if elevation > 2 {
-		elevation = 2
-	}
-
-	floodRisk := "Medium"
-	if elevation < -2.0 {
-		floodRisk = "High"
-	} else if elevation > 0 {
-		floodRisk = "Low"
-	}
-
-	return &models.AHNHeightData{
-		Elevation:     elevation,
-		TerrainSlope:  0.5,
-		FloodRisk:     floodRisk,
-		ViewPotential: "Fair",
-		Surrounding:   []float64{elevation - 0.5, elevation + 0.5},
-	}
-}
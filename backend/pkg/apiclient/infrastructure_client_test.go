@@ -143,35 +143,3 @@ func TestFetchFacilitiesData(t *testing.T) {
 		t.Error("Expected non-nil data")
 	}
 }
-
-func TestFetchAHNHeightData(t *testing.T) {
-	// Use transport mocking since the code now uses hardcoded URLs
-	mockTransport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
-		responseBody := `{
-			"results": [
-				{"latitude": 52.0907, "longitude": 5.1214, "elevation": 3.5}
-			]
-		}`
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Header:     make(http.Header),
-			Body:       io.NopCloser(strings.NewReader(responseBody)),
-		}, nil
-	})
-
-	cfg := &config.Config{}
-	client := NewApiClient(&http.Client{Transport: mockTransport}, cfg)
-
-	data, err := client.FetchAHNHeightData(context.Background(), cfg, 52.0907, 5.1214)
-	if err != nil {
-		t.Fatalf("Expected no error, got %v", err)
-	}
-
-	if data.Elevation != 3.5 {
-		t.Errorf("Expected elevation 3.5, got %f", data.Elevation)
-	}
-	// Elevation 3.5m in Netherlands = Low flood risk
-	if data.FloodRisk != "Low" {
-		t.Errorf("Expected flood risk 'Low', got '%s'", data.FloodRisk)
-	}
-}
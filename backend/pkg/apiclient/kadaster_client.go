@@ -12,6 +12,7 @@ import (
 // Documentation: https://www.kadaster.nl/-/objectinformatie-api
 func (c *ApiClient) FetchKadasterObjectInfo(ctx context.Context, cfg *config.Config, bagID string) (*models.KadasterObjectInfo, error) {
 	if cfg.KadasterObjectInfoApiURL == "" {
+		markNotConfigured(ctx, "KadasterObjectInfoApiURL")
 		return nil, fmt.Errorf("KadasterObjectInfoApiURL not configured")
 	}
 
@@ -0,0 +1,127 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// ErrAddressNotFound is returned when a Locatieserver ID does not resolve to an address
+var ErrAddressNotFound = errors.New("address not found")
+
+// MaxSuggestions caps the candidates returned by SuggestAddresses
+const MaxSuggestions = 25
+
+// locatieserverIDPattern matches Locatieserver object IDs such as "adr-<32 hex digits>"
+var locatieserverIDPattern = regexp.MustCompile(`^[a-z]{3}-[0-9a-f]{32}$`)
+
+// ValidLocatieserverID reports whether id has the shape of a Locatieserver object ID
+func ValidLocatieserverID(id string) bool {
+	return locatieserverIDPattern.MatchString(id)
+}
+
+// locatieserverURL returns the URL of a Locatieserver service (suggest, lookup) next
+// to the free search endpoint configured in BAG_API_URL
+func (c *ApiClient) locatieserverURL(service string) string {
+	base := strings.TrimSuffix(strings.TrimRight(c.cfg.BagApiURL, "/"), "/free")
+	return base + "/" + service
+}
+
+// SuggestAddresses returns ranked address candidates for free text such as a partial
+// street name, city or house number with letter/toevoeging ("Domplein 12a utr")
+// Documentation: https://github.com/PDOK/locatieserver/wiki/API-Locatieserver
+func (c *ApiClient) SuggestAddresses(ctx context.Context, query string, limit int) ([]models.AddressSuggestion, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if limit <= 0 || limit > MaxSuggestions {
+		limit = MaxSuggestions
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("fq", "type:adres")
+	params.Set("fl", "id,type,weergavenaam,straatnaam,huis_nlt,postcode,woonplaatsnaam,score")
+	params.Set("rows", strconv.Itoa(limit))
+
+	var resp models.LocatieserverSuggestResponse
+	if err := c.GetJSON(ctx, "BAG", c.locatieserverURL("suggest")+"?"+params.Encode(), nil, &resp); err != nil {
+		return nil, fmt.Errorf("locatieserver suggest failed: %w", err)
+	}
+
+	suggestions := make([]models.AddressSuggestion, 0, len(resp.Response.Docs))
+	for _, doc := range resp.Response.Docs {
+		s := models.AddressSuggestion{
+			ID:          doc.ID,
+			Label:       doc.Weergavenaam,
+			Street:      doc.Straatnaam,
+			HouseNumber: doc.HuisNLT,
+			Postcode:    doc.Postcode,
+			City:        doc.WoonplaatsNaam,
+			Score:       doc.Score,
+		}
+		if hl := resp.Highlighting[doc.ID].Suggest; len(hl) > 0 {
+			s.Highlight = hl[0]
+		}
+		suggestions = append(suggestions, s)
+	}
+
+	logutil.Debugf("[BAG] %d suggestions for %q", len(suggestions), query)
+	return suggestions, nil
+}
+
+// LookupAddress resolves a Locatieserver address ID, as returned by SuggestAddresses,
+// to the full BAG record. Returns ErrAddressNotFound if the ID is not a known address.
+func (c *ApiClient) LookupAddress(ctx context.Context, id string) (*models.BAGData, error) {
+	if !ValidLocatieserverID(id) {
+		return nil, fmt.Errorf("%w: invalid id %q", ErrAddressNotFound, id)
+	}
+
+	params := url.Values{}
+	params.Set("id", id)
+	params.Set("fl", "*")
+
+	var resp models.BagResponse
+	if err := c.GetJSON(ctx, "BAG", c.locatieserverURL("lookup")+"?"+params.Encode(), nil, &resp); err != nil {
+		return nil, fmt.Errorf("locatieserver lookup failed: %w", err)
+	}
+	if len(resp.Response.Docs) == 0 || resp.Response.Docs[0].Type != "adres" {
+		return nil, fmt.Errorf("%w: %s", ErrAddressNotFound, id)
+	}
+	return bagDataFromDoc(resp.Response.Docs[0])
+}
+
+// ReverseGeocode returns the BAG address nearest to the coordinates and its distance
+// in metres. Returns ErrAddressNotFound if the reverse service finds no address.
+func (c *ApiClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*models.BAGData, float64, error) {
+	params := url.Values{}
+	params.Set("lat", strconv.FormatFloat(lat, 'f', 7, 64))
+	params.Set("lon", strconv.FormatFloat(lon, 'f', 7, 64))
+	params.Set("type", "adres")
+	params.Set("fl", "*")
+	params.Set("rows", "1")
+
+	var resp models.BagResponse
+	if err := c.GetJSON(ctx, "BAG", c.locatieserverURL("reverse")+"?"+params.Encode(), nil, &resp); err != nil {
+		return nil, 0, fmt.Errorf("locatieserver reverse failed: %w", err)
+	}
+	if len(resp.Response.Docs) == 0 {
+		return nil, 0, fmt.Errorf("%w near (%f, %f)", ErrAddressNotFound, lat, lon)
+	}
+
+	doc := resp.Response.Docs[0]
+	bagData, err := bagDataFromDoc(doc)
+	if err != nil {
+		return nil, 0, err
+	}
+	logutil.Debugf("[BAG] Nearest address to (%f, %f): %s at %.1fm", lat, lon, bagData.Address, doc.Afstand)
+	return bagData, doc.Afstand, nil
+}
@@ -0,0 +1,126 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+const testAddressID = "adr-8f3f3e2a1c6b4d5e9f0a1b2c3d4e5f60"
+
+func TestSuggestAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3_1/suggest" {
+			t.Errorf("Expected suggest service next to the free endpoint, got %q", r.URL.Path)
+		}
+		if r.URL.Query().Get("q") != "domplein 12a utr" || r.URL.Query().Get("fq") != "type:adres" || r.URL.Query().Get("rows") != "5" {
+			t.Errorf("Unexpected query %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{
+			"response": {"numFound": 2, "docs": [
+				{"id": "` + testAddressID + `", "type": "adres", "weergavenaam": "Domplein 12A, 3512JC Utrecht",
+				 "straatnaam": "Domplein", "huis_nlt": "12A", "postcode": "3512JC", "woonplaatsnaam": "Utrecht", "score": 14.2},
+				{"id": "adr-00000000000000000000000000000001", "type": "adres", "weergavenaam": "Domplein 12, 3512JC Utrecht",
+				 "straatnaam": "Domplein", "huis_nlt": "12", "postcode": "3512JC", "woonplaatsnaam": "Utrecht", "score": 11.9}
+			]},
+			"highlighting": {"` + testAddressID + `": {"suggest": ["<b>Domplein</b> <b>12A</b>, 3512JC <b>Utrecht</b>"]}}
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL + "/v3_1/free"}
+	client := NewApiClient(server.Client(), cfg)
+
+	suggestions, err := client.SuggestAddresses(context.Background(), "domplein 12a utr", 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %d", len(suggestions))
+	}
+	first := suggestions[0]
+	if first.ID != testAddressID || first.HouseNumber != "12A" || first.Postcode != "3512JC" || first.City != "Utrecht" {
+		t.Errorf("Unexpected first suggestion: %+v", first)
+	}
+	if first.Highlight == "" || suggestions[1].Highlight != "" {
+		t.Errorf("Expected highlighting only where returned, got %q and %q", first.Highlight, suggestions[1].Highlight)
+	}
+}
+
+func TestLookupAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3_1/lookup" || r.URL.Query().Get("id") != testAddressID {
+			t.Errorf("Unexpected lookup %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"response": {"docs": [{
+			"id": "` + testAddressID + `", "type": "adres", "weergavenaam": "Domplein 12A, 3512JC Utrecht",
+			"huisnummer": 12, "huisletter": "A", "huis_nlt": "12A", "postcode": "3512JC",
+			"verblijfsobject_id": "0344010000012345", "pand_id": "0344100000067890",
+			"centroide_ll": "POINT(5.1214 52.0907)"
+		}]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL + "/v3_1/free"}
+	client := NewApiClient(server.Client(), cfg)
+
+	bagData, err := client.LookupAddress(context.Background(), testAddressID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bagData.Postcode != "3512JC" || bagData.HouseNumber != "12A" || bagData.PandID != "0344100000067890" {
+		t.Errorf("Unexpected BAG data: %+v", bagData)
+	}
+}
+
+func TestLookupAddress_NotFound(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// A street ID resolves, but is not an address
+		w.Write([]byte(`{"response": {"docs": [{"id": "wgd-00000000000000000000000000000002", "type": "weg"}]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL + "/v3_1/free"}
+	client := NewApiClient(server.Client(), cfg)
+
+	if _, err := client.LookupAddress(context.Background(), "wgd-00000000000000000000000000000002"); !errors.Is(err, ErrAddressNotFound) {
+		t.Errorf("Expected ErrAddressNotFound for a street, got %v", err)
+	}
+	if _, err := client.LookupAddress(context.Background(), "adr-1 OR *"); !errors.Is(err, ErrAddressNotFound) {
+		t.Errorf("Expected ErrAddressNotFound for a malformed id, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected the malformed id to be rejected without a request, got %d requests", requests)
+	}
+}
+
+func TestReverseGeocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3_1/reverse" || r.URL.Query().Get("type") != "adres" || r.URL.Query().Get("lat") != "52.0907000" {
+			t.Errorf("Unexpected reverse request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"response": {"docs": [{
+			"id": "` + testAddressID + `", "type": "adres", "weergavenaam": "Domplein 12A, 3512JC Utrecht",
+			"huisnummer": 12, "huisletter": "A", "postcode": "3512JC", "afstand": 8.4,
+			"centroide_ll": "POINT(5.12141 52.09075)"
+		}]}}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{BagApiURL: server.URL + "/v3_1/free"}
+	client := NewApiClient(server.Client(), cfg)
+
+	bagData, distance, err := client.ReverseGeocode(context.Background(), 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if bagData.Postcode != "3512JC" || bagData.HouseNumber != "12A" || distance != 8.4 {
+		t.Errorf("Unexpected nearest address %+v at %.1fm", bagData, distance)
+	}
+}
@@ -12,6 +12,7 @@ import (
 // Documentation: https://matrixian.com/en/api/property-value-plus-api/
 func (c *ApiClient) FetchPropertyValuePlus(ctx context.Context, cfg *config.Config, bagID string, lat, lon float64) (*models.MatrixianPropertyValue, error) {
 	if cfg.MatrixianApiURL == "" {
+		markNotConfigured(ctx, "MatrixianApiURL")
 		return nil, fmt.Errorf("MatrixianApiURL not configured")
 	}
 
@@ -3,71 +3,158 @@ package apiclient
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
 	"github.com/iman-hussain/nethaddress/backend/pkg/models"
 )
 
-// Default PDOK RCE Monuments API endpoint (free, no auth required)
+// Default PDOK RCE protected sites API endpoint (free, no auth required)
 const defaultMonumentenApiURL = "https://api.pdok.nl/rce/beschermde-gebieden-cultuurhistorie/ogc/v1"
 
+// Default RCE linked data SPARQL endpoint for the monument register (free, no auth required)
+const defaultRCERegisterURL = "https://api.linkeddata.cultureelerfgoed.nl/datasets/rce/cho/services/cho/sparql"
+
+// monumentRegisterURL links to a rijksmonument's entry in the public register
+const monumentRegisterURL = "https://monumentenregister.cultureelerfgoed.nl/monumenten/"
+
+// MonumentRijks is the monument type of a pand in the register. The RCE register
+// only holds rijksmonumenten; provincial and municipal monuments are kept by each
+// province and municipality and are not looked up.
+const MonumentRijks = "Rijksmonument"
+
+// MonumentLocalUnknown is the LocalStatus of every pand: without a lookup in the
+// provincial and municipal registers a pand that is no rijksmonument may still be
+// a gemeentelijk or provinciaal monument
+const MonumentLocalUnknown = "unknown"
+
+// gezichtCitationPath marks a protected site whose designation is a beschermd
+// stads- of dorpsgezicht rather than a (complex) rijksmonument or archaeological site
+const gezichtCitationPath = "/gezichten/"
+
+// BAG object identifiers are 16 digits; validating them keeps the SPARQL query safe
+var bagIDPattern = regexp.MustCompile(`^\d{16}$`)
+
+// monumentQuery finds register entries linked to a BAG pand
+const monumentQuery = `PREFIX ceo: <https://linkeddata.cultureelerfgoed.nl/def/ceo#>
+PREFIX skos: <http://www.w3.org/2004/02/skos/core#>
+SELECT ?nummer ?naam ?status ?datum WHERE {
+  ?bag ceo:identificatieBAGPand "%s" .
+  ?relatie ceo:heeftBAGRelatie ?bag .
+  ?monument ceo:heeftBasisregistratieRelatie ?relatie ;
+            ceo:heeftJuridischeStatus/skos:prefLabel ?status .
+  OPTIONAL { ?monument ceo:rijksmonumentnummer ?nummer }
+  OPTIONAL { ?monument ceo:heeftNaam/ceo:naam ?naam }
+  OPTIONAL { ?monument ceo:datumInschrijvingInMonumentenregister ?datum }
+}
+LIMIT 20`
+
 // emptyMonumentData returns a default MonumentData struct for soft failures.
 func emptyMonumentData() *models.MonumentData {
 	return &models.MonumentData{
-		IsMonument: false,
-		Type:       "",
-		Date:       "",
+		IsMonument:  false,
+		Type:        "",
+		Date:        "",
+		LocalStatus: MonumentLocalUnknown,
 	}
 }
 
-// FetchMonumentData queries the PDOK RCE monuments API to check if an address is a monument
-// Uses bbox query with coordinates from BAG data
+// FetchMonumentData looks up the BAG pand in the RCE monument register.
+// A pand without a register entry is reported as no rijksmonument, with its
+// gemeentelijk and provinciaal status unknown.
 func (c *ApiClient) FetchMonumentData(ctx context.Context, cfg *config.Config, bagPandID string) (*models.MonumentData, error) {
-	// This method uses BAG Pand ID - we'll also provide a coordinate-based fallback
-	logutil.Debugf("[Monument] FetchMonumentData called with bagPandID: %s", bagPandID)
+	if !bagIDPattern.MatchString(bagPandID) {
+		markFailed(ctx, fmt.Errorf("invalid BAG pand ID %q", bagPandID))
+		return emptyMonumentData(), nil
+	}
+
+	endpoint := defaultRCERegisterURL
+	if cfg.RCERegisterURL != "" {
+		endpoint = cfg.RCERegisterURL
+	}
+
+	form := url.Values{"query": {fmt.Sprintf(monumentQuery, bagPandID)}}
+	headers := map[string]string{"Accept": "application/sparql-results+json"}
+
+	var apiResp models.SPARQLResponse
+	if err := c.PostFormJSON(ctx, "RCE", endpoint, form.Encode(), headers, &apiResp); err != nil {
+		markFailed(ctx, err)
+		return emptyMonumentData(), nil
+	}
 
-	// For now, return not a monument - the coordinate-based method is more reliable
-	// The BAG Pand ID lookup requires Amsterdam's specific API
-	return emptyMonumentData(), nil
+	logutil.Debugf("[Monument] Found %d register entries for pand %s", len(apiResp.Results.Bindings), bagPandID)
+
+	// A pand can also be linked to entries that lost their status; use the first current one
+	var result *models.MonumentData
+	for _, row := range apiResp.Results.Bindings {
+		if !isRijksmonumentStatus(row["status"].Value) {
+			continue
+		}
+		result = &models.MonumentData{
+			IsMonument:  true,
+			Type:        MonumentRijks,
+			Name:        row["naam"].Value,
+			Number:      row["nummer"].Value,
+			Date:        row["datum"].Value,
+			LocalStatus: MonumentLocalUnknown,
+		}
+		if result.Number != "" {
+			result.URL = monumentRegisterURL + url.PathEscape(result.Number)
+		}
+		break
+	}
+
+	if result == nil {
+		return emptyMonumentData(), nil
+	}
+	logutil.Debugf("[Monument] Pand %s is a %s (%s, registered %s)", bagPandID, result.Type, result.Number, result.Date)
+	return result, nil
+}
+
+// isRijksmonumentStatus reports whether the register's juridische status label is
+// a current rijksmonument, not e.g. "geen rijksmonument" after deregistration
+func isRijksmonumentStatus(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), "rijksmonument")
 }
 
-// FetchMonumentDataByCoords queries PDOK RCE monuments API using coordinates
+// FetchProtectedArea returns the beschermd stads- of dorpsgezicht containing the
+// coordinates, or nil if the location is outside any protected area.
 // Documentation: https://api.pdok.nl/rce/beschermde-gebieden-cultuurhistorie/ogc/v1
-func (c *ApiClient) FetchMonumentDataByCoords(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.MonumentData, error) {
+func (c *ApiClient) FetchProtectedArea(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.ProtectedArea, error) {
 	// Use config URL if provided (for testing), otherwise use PDOK RCE API default
 	baseURL := defaultMonumentenApiURL
 	if cfg.MonumentenApiURL != "" {
 		baseURL = cfg.MonumentenApiURL
 	}
 
-	// Create a small bounding box around the point (approximately 50m)
-	delta := 0.0005
+	// A ~1m box around the point, so only areas containing the property match
+	delta := 0.00001
 	bbox := fmt.Sprintf("%.6f,%.6f,%.6f,%.6f", lon-delta, lat-delta, lon+delta, lat+delta)
 
-	url := fmt.Sprintf("%s/collections/rce_inspire_points/items?bbox=%s&f=json&limit=5", baseURL, bbox)
+	url := fmt.Sprintf("%s/collections/rce_inspire_polygons/items?bbox=%s&f=json&limit=10", baseURL, bbox)
 	logutil.Debugf("[Monument] Request URL: %s", url)
 
 	var apiResp models.MonumentResponse
 	if err := c.GetJSON(ctx, "Monument", url, nil, &apiResp); err != nil {
-		return emptyMonumentData(), nil
+		return nil, err
 	}
 
-	logutil.Debugf("[Monument] Found %d monuments near coordinates", len(apiResp.Features))
-
-	if len(apiResp.Features) == 0 {
-		return emptyMonumentData(), nil
+	// The polygons also cover archaeological sites and rijksmonument complexes;
+	// only designations in the gezichten register are stads- of dorpsgezichten
+	for _, feature := range apiResp.Features {
+		if !strings.EqualFold(feature.Properties.Classification, "cultural") ||
+			!strings.Contains(feature.Properties.CICitation, gezichtCitationPath) {
+			continue
+		}
+		logutil.Debugf("[Monument] Inside protected area: %s", feature.Properties.Text)
+		return &models.ProtectedArea{
+			Name: feature.Properties.Text,
+			Date: feature.Properties.LegalFoundationDate,
+			URL:  feature.Properties.CICitation,
+		}, nil
 	}
-
-	// Return the first monument found
-	monument := apiResp.Features[0]
-	result := &models.MonumentData{
-		IsMonument: true,
-		Type:       "Rijksmonument",
-		Name:       monument.Properties.Text,
-		Date:       monument.Properties.LegalFoundationDate,
-	}
-
-	logutil.Debugf("[Monument] Found: %s (registered %s)", result.Name, result.Date)
-	return result, nil
+	return nil, nil
 }
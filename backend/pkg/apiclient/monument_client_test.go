@@ -4,48 +4,129 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 )
 
 func TestFetchMonumentData(t *testing.T) {
-	// The BAG Pand ID-based method now returns not-a-monument as we use coordinate-based lookup
-	// This test verifies the function doesn't error
-	cfg := &config.Config{}
-	client := NewApiClient(http.DefaultClient, cfg)
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.FormValue("query")
+		w.WriteHeader(http.StatusOK)
+		// SPARQL JSON results; the pand is also linked to a deregistered entry
+		w.Write([]byte(`{
+			"head": {"vars": ["nummer", "naam", "status", "datum"]},
+			"results": {"bindings": [
+				{
+					"nummer": {"type": "literal", "value": "3890"},
+					"naam": {"type": "literal", "value": "Pakhuis"},
+					"status": {"type": "literal", "value": "geen rijksmonument"},
+					"datum": {"type": "literal", "value": "1999-03-01"}
+				},
+				{
+					"nummer": {"type": "literal", "value": "3891"},
+					"naam": {"type": "literal", "value": "Anne Frank Huis"},
+					"status": {"type": "literal", "value": "rijksmonument"},
+					"datum": {"type": "literal", "value": "1970-06-12"}
+				}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{RCERegisterURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	data, err := client.FetchMonumentData(ctx, cfg, "0363100012169587")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(query, `"0363100012169587"`) {
+		t.Errorf("Expected query to match the pand ID, got %q", query)
+	}
+	if !data.IsMonument || data.Type != MonumentRijks {
+		t.Fatalf("Expected the current rijksmonument entry, got %+v", data)
+	}
+	if data.Number != "3891" || data.Date != "1970-06-12" || data.Name != "Anne Frank Huis" {
+		t.Errorf("Unexpected register details: %+v", data)
+	}
+	if data.URL != "https://monumentenregister.cultureelerfgoed.nl/monumenten/3891" {
+		t.Errorf("Unexpected register link %q", data.URL)
+	}
+	if prov.Status() != StatusOK {
+		t.Errorf("Expected ok provenance, got %q", prov.Status())
+	}
+}
+
+func TestFetchMonumentData_NotListed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"head": {"vars": []}, "results": {"bindings": []}}`))
+	}))
+	defer server.Close()
 
-	data, err := client.FetchMonumentData(context.Background(), cfg, "1234567890")
+	cfg := &config.Config{RCERegisterURL: server.URL}
+	client := NewApiClient(server.Client(), cfg)
+
+	data, err := client.FetchMonumentData(context.Background(), cfg, "0363100012169588")
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-	// BAG Pand ID method returns not-a-monument by design (coordinate method is preferred)
-	if data == nil {
-		t.Fatal("Expected non-nil data")
+	if data.IsMonument || data.LocalStatus != MonumentLocalUnknown {
+		t.Errorf("Expected no rijksmonument with an unknown local status, got %+v", data)
+	}
+}
+
+func TestFetchMonumentData_InvalidPandID(t *testing.T) {
+	cfg := &config.Config{RCERegisterURL: "http://unused.invalid"}
+	client := NewApiClient(http.DefaultClient, cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	data, err := client.FetchMonumentData(ctx, cfg, `1" } #`)
+	if err != nil || data == nil || data.IsMonument {
+		t.Fatalf("Expected soft failure, got %+v, %v", data, err)
+	}
+	if prov.Status() != StatusError || prov.Requests() != 0 {
+		t.Errorf("Expected error provenance without upstream request, got %q after %d requests", prov.Status(), prov.Requests())
 	}
 }
 
-func TestFetchMonumentDataByCoords(t *testing.T) {
-	// Test with PDOK RCE beschermde-gebieden-cultuurhistorie OGC API response format
+func TestFetchProtectedArea(t *testing.T) {
+	var path string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
 		w.WriteHeader(http.StatusOK)
 		// PDOK RCE returns GeoJSON FeatureCollection
 		w.Write([]byte(`{
 			"type": "FeatureCollection",
 			"features": [{
 				"type": "Feature",
-				"id": "rijksmonument.12345",
+				"id": "rce_inspire_polygons.1",
 				"properties": {
-					"monumentnummer": "12345",
-					"rijksmonument_naam": "Anne Frank Huis",
-					"monumenttype": "Rijksmonument"
-				},
-				"geometry": {
-					"type": "Point",
-					"coordinates": [4.8837, 52.3753]
+					"text": "Terrein met resten van een kasteel",
+					"siteprotectionclassification": "archaeological"
+				}
+			}, {
+				"type": "Feature",
+				"id": "rce_inspire_polygons.3",
+				"properties": {
+					"text": "Westerkerk (complex)",
+					"ci_citation": "https://monumentenregister.cultureelerfgoed.nl/monumenten/518467",
+					"siteprotectionclassification": "cultural"
+				}
+			}, {
+				"type": "Feature",
+				"id": "rce_inspire_polygons.2",
+				"properties": {
+					"text": "Amsterdam - Binnen de Singelgracht",
+					"legalfoundationdate": "1999-11-30",
+					"ci_citation": "https://monumentenregister.cultureelerfgoed.nl/gezichten/8",
+					"siteprotectionclassification": "cultural"
 				}
 			}],
-			"numberReturned": 1
+			"numberReturned": 3
 		}`))
 	}))
 	defer server.Close()
@@ -53,14 +134,14 @@ func TestFetchMonumentDataByCoords(t *testing.T) {
 	cfg := &config.Config{MonumentenApiURL: server.URL}
 	client := NewApiClient(server.Client(), cfg)
 
-	data, err := client.FetchMonumentDataByCoords(context.Background(), cfg, 52.3753, 4.8837)
+	area, err := client.FetchProtectedArea(context.Background(), cfg, 52.3753, 4.8837)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
-	if !data.IsMonument {
-		t.Fatal("Expected monument flag to be true")
+	if path != "/collections/rce_inspire_polygons/items" {
+		t.Errorf("Unexpected collection path %q", path)
 	}
-	if data.Type != "Rijksmonument" {
-		t.Errorf("Expected monument type 'Rijksmonument', got '%s'", data.Type)
+	if area == nil || area.Name != "Amsterdam - Binnen de Singelgracht" || area.Date != "1999-11-30" {
+		t.Fatalf("Expected the stadsgezicht, skipping the archaeological site and monument complex, got %+v", area)
 	}
 }
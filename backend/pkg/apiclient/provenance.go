@@ -0,0 +1,145 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Provenance statuses describing how a fetch result was obtained
+const (
+	StatusOK            = "ok"             // real data from the upstream
+	StatusEmpty         = "empty"          // upstream answered but had no data for this location
+	StatusFallback      = "fallback"       // synthetic or assumed values, not a measurement
+	StatusError         = "error"          // upstream failed; any payload is a placeholder
+	StatusNotConfigured = "not_configured" // API URL or key missing; payload is a placeholder
+	StatusCircuitOpen   = "circuit_open"   // upstream skipped while its circuit breaker is open
+)
+
+// Provenance records how a single fetch was served: its outcome and the upstream
+// requests it made. Attach one to a context with WithProvenance before calling a
+// Fetch* method; clients mark soft failures on it instead of hiding them.
+type Provenance struct {
+	mu          sync.Mutex
+	status      string
+	reason      string
+	upstreamURL string
+	requests    int
+	latency     time.Duration
+}
+
+type provenanceKey struct{}
+
+// WithProvenance returns a context that records provenance for the fetches made with it
+func WithProvenance(ctx context.Context) (context.Context, *Provenance) {
+	p := &Provenance{}
+	return context.WithValue(ctx, provenanceKey{}, p), p
+}
+
+func provenanceFrom(ctx context.Context) *Provenance {
+	p, _ := ctx.Value(provenanceKey{}).(*Provenance)
+	return p
+}
+
+// Status returns the recorded outcome, StatusOK if nothing was marked
+func (p *Provenance) Status() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status == "" {
+		return StatusOK
+	}
+	return p.status
+}
+
+// Reason explains a non-ok status
+func (p *Provenance) Reason() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.reason
+}
+
+// UpstreamURL returns the last upstream URL requested, without query parameters
+func (p *Provenance) UpstreamURL() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.upstreamURL
+}
+
+// Latency returns the total time spent waiting on upstream requests
+func (p *Provenance) Latency() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.latency
+}
+
+// Requests returns the number of upstream requests made, including retries
+func (p *Provenance) Requests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests
+}
+
+func (p *Provenance) recordRequest(u *url.URL, took time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests++
+	p.latency += took
+	// Query strings may carry API keys, so only keep scheme, host and path
+	p.upstreamURL = (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}).String()
+}
+
+func (p *Provenance) mark(status, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status = status
+	p.reason = reason
+}
+
+// markNotConfigured records that a fetch returned placeholder data because setting is unset
+func markNotConfigured(ctx context.Context, setting string) {
+	if p := provenanceFrom(ctx); p != nil {
+		p.mark(StatusNotConfigured, setting+" not configured")
+	}
+}
+
+// markFailed records that a fetch returned placeholder data because the upstream failed.
+// A request rejected by an open circuit breaker keeps its more specific circuit_open status.
+func markFailed(ctx context.Context, err error) {
+	if p := provenanceFrom(ctx); p != nil {
+		if errors.Is(err, ErrCircuitOpen) || p.Status() == StatusCircuitOpen {
+			markCircuitOpen(ctx, err)
+			return
+		}
+		reason := "upstream request failed"
+		if err != nil {
+			reason = err.Error()
+		}
+		p.mark(StatusError, reason)
+	}
+}
+
+// markCircuitOpen records that a fetch short-circuited because the upstream's breaker is open
+func markCircuitOpen(ctx context.Context, err error) {
+	if p := provenanceFrom(ctx); p != nil {
+		if p.Status() == StatusCircuitOpen {
+			return
+		}
+		p.mark(StatusCircuitOpen, err.Error())
+	}
+}
+
+// markEmpty records that the upstream answered without data for the location
+func markEmpty(ctx context.Context, reason string) {
+	if p := provenanceFrom(ctx); p != nil {
+		p.mark(StatusEmpty, reason)
+	}
+}
+
+// markFallback records that a fetch returned assumed or estimated values
+func markFallback(ctx context.Context, reason string) {
+	if p := provenanceFrom(ctx); p != nil {
+		p.mark(StatusFallback, reason)
+	}
+}
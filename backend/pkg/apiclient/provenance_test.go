@@ -0,0 +1,73 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+func TestProvenance_OK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"current_weather": map[string]any{"temperature": 15.5},
+		})
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{KNMIWeatherApiURL: server.URL + "/forecast"}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	if _, err := client.FetchKNMIWeatherData(ctx, cfg, 52.37, 4.89); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if prov.Status() != StatusOK {
+		t.Errorf("Expected status %q, got %q", StatusOK, prov.Status())
+	}
+	if prov.Requests() != 1 {
+		t.Errorf("Expected 1 upstream request, got %d", prov.Requests())
+	}
+	if got := prov.UpstreamURL(); got != server.URL+"/forecast" {
+		t.Errorf("Expected upstream URL without query, got %q", got)
+	}
+}
+
+func TestProvenance_SoftFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewApiClient(server.Client(), &config.Config{})
+
+	// Missing URL returns a placeholder marked not_configured
+	ctx, prov := WithProvenance(context.Background())
+	if _, err := client.FetchKNMIWeatherData(ctx, &config.Config{}, 52.37, 4.89); err != nil {
+		t.Fatalf("Expected soft failure, got %v", err)
+	}
+	if prov.Status() != StatusNotConfigured || !strings.Contains(prov.Reason(), "KNMIWeatherApiURL") {
+		t.Errorf("Expected not_configured for KNMIWeatherApiURL, got %q (%s)", prov.Status(), prov.Reason())
+	}
+
+	// Upstream failure returns a placeholder marked error
+	cfg := &config.Config{KNMIWeatherApiURL: server.URL}
+	ctx, prov = WithProvenance(context.Background())
+	if _, err := client.FetchKNMIWeatherData(ctx, cfg, 52.37, 4.89); err != nil {
+		t.Fatalf("Expected soft failure, got %v", err)
+	}
+	if prov.Status() != StatusError {
+		t.Errorf("Expected status %q, got %q", StatusError, prov.Status())
+	}
+}
+
+func TestProvenance_WithoutContext(t *testing.T) {
+	// Marking without an attached Provenance must be a no-op
+	markFailed(context.Background(), nil)
+	markFallback(context.Background(), "estimate")
+}
@@ -0,0 +1,147 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+)
+
+// defaultUpstreamRateLimits caps requests per second for upstream hosts known to
+// throttle or ban aggressive clients. Config entries override these values.
+var defaultUpstreamRateLimits = map[string]float64{
+	"overpass-api.de":       2,
+	"overpass.kumi.systems": 2,
+	"maps.mail.ru":          2,
+	"api.luchtmeetnet.nl":   5,
+}
+
+// rateLimiter enforces per-upstream request rates using one token bucket per host.
+// Hosts without a configured limit fall back to defaultRate; a rate of 0 means unlimited.
+type rateLimiter struct {
+	mu          sync.Mutex
+	limits      map[string]float64
+	defaultRate float64
+	buckets     map[string]*tokenBucket
+}
+
+// tokenBucket is a minimal token bucket; tokens may go negative to queue reservations.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limits map[string]float64, defaultRate float64) *rateLimiter {
+	merged := make(map[string]float64, len(defaultUpstreamRateLimits)+len(limits))
+	for host, rate := range defaultUpstreamRateLimits {
+		merged[host] = rate
+	}
+	for host, rate := range limits {
+		merged[strings.ToLower(host)] = rate
+	}
+	return &rateLimiter{
+		limits:      merged,
+		defaultRate: defaultRate,
+		buckets:     make(map[string]*tokenBucket),
+	}
+}
+
+// Wait blocks until a request to host is allowed or ctx is cancelled.
+func (l *rateLimiter) Wait(ctx context.Context, host string) error {
+	delay := l.reserve(strings.ToLower(host), time.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token for host and returns how long the caller must wait before using it.
+func (l *rateLimiter) reserve(host string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rate, ok := l.limits[host]
+	if !ok {
+		rate = l.defaultRate
+	}
+	if rate <= 0 {
+		return 0
+	}
+
+	bucket, ok := l.buckets[host]
+	if !ok {
+		burst := math.Max(1, math.Ceil(rate))
+		bucket = &tokenBucket{rate: rate, burst: burst, tokens: burst, last: now}
+		l.buckets[host] = bucket
+	}
+
+	elapsed := now.Sub(bucket.last).Seconds()
+	bucket.last = now
+	bucket.tokens = math.Min(bucket.burst, bucket.tokens+elapsed*bucket.rate)
+	bucket.tokens--
+
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / bucket.rate * float64(time.Second))
+}
+
+// doAs sends req through the shared HTTP client after waiting for the upstream's rate
+// limit. All outbound requests should go through here so per-source limits, provenance
+// and metrics apply consistently. Metrics and circuit breaking are keyed by apiName;
+// while the breaker is open, requests fail fast with ErrCircuitOpen.
+func (c *ApiClient) doAs(apiName string, req *http.Request) (*http.Response, error) {
+	done, err := c.breakers.allow(apiName)
+	if err != nil {
+		markCircuitOpen(req.Context(), err)
+		return nil, err
+	}
+
+	if c.limiter != nil {
+		if err := c.limiter.Wait(req.Context(), req.URL.Hostname()); err != nil {
+			done(false, false)
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.HTTP.Do(req)
+	elapsed := time.Since(start)
+	if p := provenanceFrom(req.Context()); p != nil {
+		p.recordRequest(req.URL, elapsed)
+	}
+
+	failed, counted := upstreamFailed(req.Context(), resp, err)
+	done(failed, counted)
+	if failed {
+		reason := fmt.Sprintf("status %d", statusCode(resp))
+		if err != nil {
+			reason = err.Error()
+		}
+		c.breakers.recordError(apiName, reason)
+	}
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.UpstreamRequests.Inc(apiName, code)
+	metrics.UpstreamDuration.Observe(elapsed.Seconds(), apiName)
+	return resp, err
+}
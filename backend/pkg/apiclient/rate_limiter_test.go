@@ -0,0 +1,85 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	limiter := newRateLimiter(map[string]float64{"slow.example": 2}, 0)
+	now := time.Now()
+
+	// Burst of 2 is allowed immediately
+	if d := limiter.reserve("slow.example", now); d != 0 {
+		t.Errorf("Expected first request to pass, got wait %v", d)
+	}
+	if d := limiter.reserve("slow.example", now); d != 0 {
+		t.Errorf("Expected second request to pass, got wait %v", d)
+	}
+
+	// Third request must wait half a second at 2 req/s
+	if d := limiter.reserve("slow.example", now); d != 500*time.Millisecond {
+		t.Errorf("Expected 500ms wait, got %v", d)
+	}
+
+	// Tokens refill over time
+	if d := limiter.reserve("slow.example", now.Add(2*time.Second)); d != 0 {
+		t.Errorf("Expected request after refill to pass, got wait %v", d)
+	}
+}
+
+func TestRateLimiter_UnlimitedHosts(t *testing.T) {
+	limiter := newRateLimiter(nil, 0)
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		if d := limiter.reserve("127.0.0.1", now); d != 0 {
+			t.Fatalf("Expected unlimited host to never wait, got %v", d)
+		}
+	}
+
+	// Built-in defaults still apply to known fragile upstreams
+	if _, ok := limiter.limits["overpass-api.de"]; !ok {
+		t.Error("Expected default limit for overpass-api.de")
+	}
+}
+
+func TestDo_RecordsMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := NewApiClient(server.Client(), nil)
+	okBefore := metrics.UpstreamRequests.Value("Metrics Test", "200")
+	unavailableBefore := metrics.UpstreamRequests.Value("Metrics Test", "503")
+	durationsBefore := metrics.UpstreamDuration.Count("Metrics Test")
+	retriesBefore := metrics.UpstreamRetries.Value("Metrics Test")
+
+	var target map[string]interface{}
+	if err := c.GetJSON(context.Background(), "Metrics Test", server.URL+"/ok", nil, &target); err != nil {
+		t.Fatalf("GetJSON failed: %v", err)
+	}
+	c.GetJSONWithRetry(context.Background(), "Metrics Test", server.URL+"/down", nil, 2, time.Millisecond, &target)
+
+	if got := metrics.UpstreamRequests.Value("Metrics Test", "200") - okBefore; got != 1 {
+		t.Errorf("Expected 1 request with status 200, got %v", got)
+	}
+	if got := metrics.UpstreamRequests.Value("Metrics Test", "503") - unavailableBefore; got != 2 {
+		t.Errorf("Expected 2 requests with status 503, got %v", got)
+	}
+	if got := metrics.UpstreamDuration.Count("Metrics Test") - durationsBefore; got != 3 {
+		t.Errorf("Expected 3 latency observations, got %d", got)
+	}
+	if got := metrics.UpstreamRetries.Value("Metrics Test") - retriesBefore; got != 1 {
+		t.Errorf("Expected 1 retry, got %v", got)
+	}
+}
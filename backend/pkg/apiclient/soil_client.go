@@ -25,6 +25,7 @@ func emptyWURSoilData() *models.WURSoilData {
 func (c *ApiClient) FetchWURSoilData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.WURSoilData, error) {
 	if cfg.WURSoilApiURL == "" {
 		// Return default data when API is not configured (requires agreement)
+		markNotConfigured(ctx, "WURSoilApiURL")
 		return emptyWURSoilData(), nil
 	}
 
@@ -32,6 +33,7 @@ func (c *ApiClient) FetchWURSoilData(ctx context.Context, cfg *config.Config, la
 
 	var result models.WURSoilData
 	if err := c.GetJSON(ctx, "WUR Soil", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyWURSoilData(), nil
 	}
 
@@ -54,6 +56,7 @@ func emptySubsidenceData() *models.SubsidenceData {
 func (c *ApiClient) FetchSubsidenceData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.SubsidenceData, error) {
 	if cfg.SkyGeoSubsidenceApiURL == "" {
 		// Return default data when API is not configured (paid service)
+		markNotConfigured(ctx, "SkyGeoSubsidenceApiURL")
 		return emptySubsidenceData(), nil
 	}
 
@@ -61,6 +64,7 @@ func (c *ApiClient) FetchSubsidenceData(ctx context.Context, cfg *config.Config,
 
 	var result models.SubsidenceData
 	if err := c.GetJSON(ctx, "Subsidence", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptySubsidenceData(), nil
 	}
 
@@ -71,6 +75,7 @@ func (c *ApiClient) FetchSubsidenceData(ctx context.Context, cfg *config.Config,
 // Documentation: https://api.store (government soil quality API)
 func (c *ApiClient) FetchSoilQualityData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.SoilQualityData, error) {
 	if cfg.SoilQualityApiURL == "" {
+		markNotConfigured(ctx, "SoilQualityApiURL")
 		return nil, fmt.Errorf("SoilQualityApiURL not configured")
 	}
 
@@ -105,6 +110,7 @@ func emptyBROSoilMapData() *models.BROSoilMapData {
 func (c *ApiClient) FetchBROSoilMapData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.BROSoilMapData, error) {
 	// Return default data if not configured
 	if cfg.BROSoilMapApiURL == "" {
+		markNotConfigured(ctx, "BROSoilMapApiURL")
 		return emptyBROSoilMapData(), nil
 	}
 
@@ -112,6 +118,7 @@ func (c *ApiClient) FetchBROSoilMapData(ctx context.Context, cfg *config.Config,
 
 	var result models.BROSoilMapData
 	if err := c.GetJSON(ctx, "BRO Soil Map", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyBROSoilMapData(), nil
 	}
 
@@ -25,6 +25,7 @@ func (c *ApiClient) FetchNDWTrafficData(ctx context.Context, cfg *config.Config,
 	// NDW requires registration - return empty data if not configured
 	if cfg.NDWTrafficApiURL == "" {
 		logutil.Debugf("[NDW Traffic] No API URL configured, returning empty data")
+		markNotConfigured(ctx, "NDWTrafficApiURL")
 		return emptyNDWTrafficData(), nil
 	}
 
@@ -36,12 +37,19 @@ func (c *ApiClient) FetchNDWTrafficData(ctx context.Context, cfg *config.Config,
 	}
 
 	if err := c.GetJSON(ctx, "NDW Traffic", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyNDWTrafficData(), nil
 	}
 
 	return result.Data, nil
 }
 
+// Waits before retrying the primary Overpass endpoint and between fallback mirrors
+var (
+	openOVRetryWait    = 10 * time.Second
+	openOVFallbackWait = 3 * time.Second
+)
+
 // FetchOpenOVData retrieves public transport data using OSM Overpass API
 // Documentation: https://wiki.openstreetmap.org/wiki/Overpass_API
 func (c *ApiClient) FetchOpenOVData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.OpenOVTransportData, error) {
@@ -80,7 +88,9 @@ out body qt 50;`, radius, lat, lon, radius, lat, lon, radius, lat, lon, radius,
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", "NethAddress/1.0 (https://github.com/iman-hussain/nethaddress)")
 
-		resp, err := c.HTTP.Do(req)
+		// Each endpoint has its own breaker so an unavailable primary does not
+		// block the mirrors
+		resp, err := c.doAs("openOV:"+req.URL.Host, req)
 		if err != nil {
 			return nil, err
 		}
@@ -118,8 +128,9 @@ out body qt 50;`, radius, lat, lon, radius, lat, lon, radius, lat, lon, radius,
 	select {
 	case <-ctx.Done():
 		logutil.Debugf("[OpenOV] Context cancelled during 10s wait")
+		markFailed(ctx, ctx.Err())
 		return emptyTransportData(), nil
-	case <-time.After(10 * time.Second):
+	case <-time.After(openOVRetryWait):
 	}
 
 	logutil.Debugf("[OpenOV] Attempt 2: retrying primary endpoint after 10s wait")
@@ -137,8 +148,9 @@ out body qt 50;`, radius, lat, lon, radius, lat, lon, radius, lat, lon, radius,
 		select {
 		case <-ctx.Done():
 			logutil.Debugf("[OpenOV] Context cancelled before fallback attempt")
+			markFailed(ctx, ctx.Err())
 			return emptyTransportData(), nil
-		case <-time.After(3 * time.Second):
+		case <-time.After(openOVFallbackWait):
 		}
 
 		logutil.Debugf("[OpenOV] Trying fallback endpoint: %s", fallbackURL)
@@ -153,6 +165,7 @@ out body qt 50;`, radius, lat, lon, radius, lat, lon, radius, lat, lon, radius,
 	}
 
 	logutil.Debugf("[OpenOV] All endpoints failed, last error: %v", lastErr)
+	markFailed(ctx, lastErr)
 	return emptyTransportData(), nil
 }
 
@@ -266,6 +279,7 @@ func emptyParkingData() *models.ParkingData {
 func (c *ApiClient) FetchParkingData(ctx context.Context, cfg *config.Config, lat, lon float64, radius int) (*models.ParkingData, error) {
 	// Return empty data if not configured
 	if cfg.ParkingApiURL == "" {
+		markNotConfigured(ctx, "ParkingApiURL")
 		return emptyParkingData(), nil
 	}
 
@@ -273,6 +287,7 @@ func (c *ApiClient) FetchParkingData(ctx context.Context, cfg *config.Config, la
 
 	var result models.ParkingData
 	if err := c.GetJSON(ctx, "Parking", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyParkingData(), nil
 	}
 
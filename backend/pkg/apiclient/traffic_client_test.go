@@ -2,9 +2,12 @@ package apiclient
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 )
@@ -76,6 +79,37 @@ func TestFetchOpenOVData(t *testing.T) {
 	}
 }
 
+func TestFetchOpenOVData_MirrorServesWhilePrimaryBreakerOpen(t *testing.T) {
+	defer func(retry, fallback time.Duration) { openOVRetryWait, openOVFallbackWait = retry, fallback }(openOVRetryWait, openOVFallbackWait)
+	openOVRetryWait, openOVFallbackWait = time.Millisecond, time.Millisecond
+
+	hits := map[string]int{}
+	cfg := &config.Config{CircuitBreakerFailureThreshold: 1, CircuitBreakerOpenTimeout: time.Hour}
+	client := NewApiClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		hits[r.URL.Host]++
+		body := `{"elements": [{"type": "node", "id": 1, "lat": 52.091, "lon": 5.122, "tags": {"name": "Centraal", "railway": "station"}}]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})}, cfg)
+
+	// Open the primary endpoint's breaker
+	done, err := client.breakers.allow("openOV:overpass-api.de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done(true, true)
+
+	data, err := client.FetchOpenOVData(context.Background(), cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(data.NearestStops) != 1 || data.NearestStops[0].Name != "Centraal" {
+		t.Errorf("Expected the mirror's stop, got %+v", data.NearestStops)
+	}
+	if hits["overpass-api.de"] != 0 || hits["overpass.kumi.systems"] != 1 {
+		t.Errorf("Expected the open primary to be skipped and the first mirror to serve, got %v", hits)
+	}
+}
+
 func TestFetchParkingData(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
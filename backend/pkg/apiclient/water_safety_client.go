@@ -29,6 +29,7 @@ func (c *ApiClient) FetchFloodRiskData(ctx context.Context, cfg *config.Config,
 	var apiResp models.FloodRiskResponse
 	if err := c.GetJSON(ctx, "FloodRisk", url, nil, &apiResp); err != nil {
 		// If API returns error, assume low risk (most of Netherlands is protected)
+		markFallback(ctx, "flood risk assumed low: "+err.Error())
 		return defaultFloodRiskData("Low"), nil
 	}
 
@@ -117,6 +118,7 @@ func emptyWaterQualityData() *models.WaterQualityData {
 // Documentation: https://www.dutchwatersector.com (Digital Delta)
 func (c *ApiClient) FetchWaterQualityData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.WaterQualityData, error) {
 	if cfg.DigitalDeltaApiURL == "" {
+		markNotConfigured(ctx, "DigitalDeltaApiURL")
 		return nil, fmt.Errorf("DigitalDeltaApiURL not configured")
 	}
 
@@ -125,6 +127,7 @@ func (c *ApiClient) FetchWaterQualityData(ctx context.Context, cfg *config.Confi
 	var result models.WaterQualityData
 	if err := c.GetJSON(ctx, "Water Quality", url, nil, &result); err != nil {
 		// Return empty data for failures (soft failure)
+		markFailed(ctx, err)
 		return emptyWaterQualityData(), nil
 	}
 
@@ -135,6 +138,7 @@ func (c *ApiClient) FetchWaterQualityData(ctx context.Context, cfg *config.Confi
 // Documentation: https://api.store (CBS Safety Experience)
 func (c *ApiClient) FetchSafetyData(ctx context.Context, cfg *config.Config, neighborhoodCode string) (*models.SafetyData, error) {
 	if cfg.SafetyExperienceApiURL == "" {
+		markNotConfigured(ctx, "SafetyExperienceApiURL")
 		return nil, fmt.Errorf("SafetyExperienceApiURL not configured")
 	}
 
@@ -179,6 +183,7 @@ func emptySchipholData() *models.SchipholFlightData {
 // Documentation: https://developer.schiphol.nl
 func (c *ApiClient) FetchSchipholFlightData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.SchipholFlightData, error) {
 	if cfg.SchipholApiURL == "" {
+		markNotConfigured(ctx, "SchipholApiURL")
 		return nil, fmt.Errorf("SchipholApiURL not configured")
 	}
 
@@ -195,6 +200,7 @@ func (c *ApiClient) FetchSchipholFlightData(ctx context.Context, cfg *config.Con
 	var result models.SchipholFlightData
 	if err := c.GetJSON(ctx, "Schiphol", url, headers, &result); err != nil {
 		// Return empty data for failures (soft failure - location may not be affected)
+		markFailed(ctx, err)
 		return emptySchipholData(), nil
 	}
 
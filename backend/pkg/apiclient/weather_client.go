@@ -32,6 +32,7 @@ func (c *ApiClient) FetchKNMIWeatherData(ctx context.Context, cfg *config.Config
 	// Return empty data if not configured
 	if cfg.KNMIWeatherApiURL == "" {
 		logutil.Debugf("[APIClient] FetchKNMIWeatherData: KNMIWeatherApiURL not configured")
+		markNotConfigured(ctx, "KNMIWeatherApiURL")
 		return emptyKNMIWeatherData(), nil
 	}
 
@@ -54,6 +55,7 @@ func (c *ApiClient) FetchKNMIWeatherData(ctx context.Context, cfg *config.Config
 	}
 
 	if err := c.GetJSON(ctx, "KNMI Weather", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyKNMIWeatherData(), nil
 	}
 
@@ -121,6 +123,7 @@ func emptyWeerliveWeatherData() *models.WeerliveWeatherData {
 func (c *ApiClient) FetchWeerliveWeather(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.WeerliveWeatherData, error) {
 	// Return empty data if not configured
 	if cfg.WeerliveApiURL == "" {
+		markNotConfigured(ctx, "WeerliveApiURL")
 		return emptyWeerliveWeatherData(), nil
 	}
 
@@ -131,10 +134,12 @@ func (c *ApiClient) FetchWeerliveWeather(ctx context.Context, cfg *config.Config
 	}
 
 	if err := c.GetJSON(ctx, "Weerlive", url, nil, &response); err != nil {
+		markFailed(ctx, err)
 		return emptyWeerliveWeatherData(), nil
 	}
 
 	if len(response.LiveWeather) == 0 {
+		markEmpty(ctx, "no live weather data")
 		return emptyWeerliveWeatherData(), nil
 	}
 
@@ -157,6 +162,7 @@ func (c *ApiClient) FetchKNMISolarData(ctx context.Context, cfg *config.Config,
 	// Return empty data if not configured
 	if cfg.KNMISolarApiURL == "" {
 		logutil.Debugf("[APIClient] FetchKNMISolarData: KNMISolarApiURL not configured")
+		markNotConfigured(ctx, "KNMISolarApiURL")
 		return emptyKNMISolarData(), nil
 	}
 
@@ -176,6 +182,7 @@ func (c *ApiClient) FetchKNMISolarData(ctx context.Context, cfg *config.Config,
 	}
 
 	if err := c.GetJSON(ctx, "KNMI Solar", url, nil, &result); err != nil {
+		markFailed(ctx, err)
 		return emptyKNMISolarData(), nil
 	}
 
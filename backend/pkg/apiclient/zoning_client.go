@@ -0,0 +1,282 @@
+package apiclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// Default Ruimtelijke Plannen Opvragen API endpoint (free, API key required)
+const defaultRuimtelijkePlannenApiURL = "https://ruimte.omgevingswet.overheid.nl/ruimtelijke-plannen/api/opvragen/v4"
+
+// ruimtelijkePlannenViewerURL links to a plan in the public viewer
+const ruimtelijkePlannenViewerURL = "https://www.ruimtelijkeplannen.nl/viewer/view?planidn="
+
+// maxZoningPlans caps the plans whose details are fetched; a point rarely has more
+const maxZoningPlans = 10
+
+// rpPointQuery is the body of a Ruimtelijke Plannen _zoek request for a single point
+type rpPointQuery struct {
+	Geo struct {
+		Intersects struct {
+			Type        string     `json:"type"`
+			Coordinates [2]float64 `json:"coordinates"`
+		} `json:"intersects"`
+	} `json:"_geo"`
+}
+
+type rpPlansResponse struct {
+	Embedded struct {
+		Plannen []struct {
+			ID             string `json:"id"`
+			Naam           string `json:"naam"`
+			Type           string `json:"type"`
+			PlanstatusInfo struct {
+				Planstatus string `json:"planstatus"`
+				Datum      string `json:"datum"`
+			} `json:"planstatusInfo"`
+		} `json:"plannen"`
+	} `json:"_embedded"`
+}
+
+type rpBestemmingsvlakkenResponse struct {
+	Embedded struct {
+		Bestemmingsvlakken []struct {
+			Type                  string `json:"type"` // enkelbestemming, dubbelbestemming
+			Naam                  string `json:"naam"`
+			Bestemmingshoofdgroep string `json:"bestemmingshoofdgroep"`
+		} `json:"bestemmingsvlakken"`
+	} `json:"_embedded"`
+}
+
+type rpBouwvlakkenResponse struct {
+	Embedded struct {
+		Bouwvlakken []struct {
+			ID string `json:"id"`
+		} `json:"bouwvlakken"`
+	} `json:"_embedded"`
+}
+
+type rpMaatvoeringenResponse struct {
+	Embedded struct {
+		Maatvoeringen []struct {
+			Naam   string `json:"naam"`
+			Omvang []struct {
+				Naam   string `json:"naam"`
+				Waarde string `json:"waarde"`
+			} `json:"omvang"`
+		} `json:"maatvoeringen"`
+	} `json:"_embedded"`
+}
+
+// FetchZoningPlans returns every spatial plan applying at the coordinates, with the
+// bestemmingsvlakken, bouwvlak and maatvoeringen at that exact point
+// Documentation: https://developer.omgevingswet.overheid.nl/api-register/api/ruimtelijke-plannen-opvragen/
+func (c *ApiClient) FetchZoningPlans(ctx context.Context, cfg *config.Config, lat, lon float64) ([]models.ZoningPlan, error) {
+	if cfg.RuimtelijkePlannenApiKey == "" {
+		markNotConfigured(ctx, "RuimtelijkePlannenApiKey")
+		return nil, fmt.Errorf("RuimtelijkePlannenApiKey not configured")
+	}
+
+	baseURL := defaultRuimtelijkePlannenApiURL
+	if cfg.RuimtelijkePlannenApiURL != "" {
+		baseURL = strings.TrimRight(cfg.RuimtelijkePlannenApiURL, "/")
+	}
+
+	// The API only accepts RD coordinates for geometry filters
+	var query rpPointQuery
+	query.Geo.Intersects.Type = "Point"
+	x, y := wgs84ToRD(lat, lon)
+	query.Geo.Intersects.Coordinates = [2]float64{x, y}
+
+	headers := map[string]string{
+		"X-Api-Key":   cfg.RuimtelijkePlannenApiKey,
+		"Content-Crs": "epsg:28992",
+	}
+
+	var plans rpPlansResponse
+	if err := c.PostJSON(ctx, "Ruimtelijke Plannen", baseURL+"/plannen/_zoek?pageSize=50", query, headers, &plans); err != nil {
+		markFailed(ctx, err)
+		return nil, fmt.Errorf("ruimtelijke plannen request failed: %w", err)
+	}
+
+	logutil.Debugf("[Zoning] Found %d plans at (%.1f, %.1f)", len(plans.Embedded.Plannen), x, y)
+
+	result := make([]models.ZoningPlan, 0, len(plans.Embedded.Plannen))
+	var detailsErr error
+	for _, p := range plans.Embedded.Plannen {
+		if len(result) == maxZoningPlans {
+			logutil.Debugf("[Zoning] Skipping details for %d further plans", len(plans.Embedded.Plannen)-maxZoningPlans)
+			break
+		}
+		plan := models.ZoningPlan{
+			ID:         p.ID,
+			Name:       p.Naam,
+			Type:       p.Type,
+			Status:     p.PlanstatusInfo.Planstatus,
+			StatusDate: p.PlanstatusInfo.Datum,
+			InForce:    planInForce(p.PlanstatusInfo.Planstatus),
+			URL:        ruimtelijkePlannenViewerURL + url.QueryEscape(p.ID),
+		}
+
+		// A plan whose details fail is skipped; the others still count
+		planURL := baseURL + "/plannen/" + url.PathEscape(p.ID)
+		if err := c.fetchZoningPlanDetails(ctx, planURL, query, headers, &plan); err != nil {
+			logutil.Warnf("[Zoning] Skipping plan %s: %v", p.ID, err)
+			detailsErr = fmt.Errorf("plan %s: %w", p.ID, err)
+			continue
+		}
+
+		result = append(result, plan)
+	}
+	if len(result) == 0 && detailsErr != nil {
+		markFailed(ctx, detailsErr)
+		return nil, detailsErr
+	}
+
+	return result, nil
+}
+
+// fetchZoningPlanDetails adds the bestemmingsvlakken, bouwvlak and maatvoeringen of
+// a plan at the queried point
+func (c *ApiClient) fetchZoningPlanDetails(ctx context.Context, planURL string, query rpPointQuery, headers map[string]string, plan *models.ZoningPlan) error {
+	var vlakken rpBestemmingsvlakkenResponse
+	if err := c.PostJSON(ctx, "Ruimtelijke Plannen", planURL+"/bestemmingsvlakken/_zoek", query, headers, &vlakken); err != nil {
+		return fmt.Errorf("bestemmingsvlakken request failed: %w", err)
+	}
+	for _, v := range vlakken.Embedded.Bestemmingsvlakken {
+		if strings.EqualFold(v.Type, "dubbelbestemming") {
+			plan.Overlays = append(plan.Overlays, v.Naam)
+		} else if plan.MainUse == "" {
+			plan.MainUse = v.Naam
+			plan.MainUseGroup = v.Bestemmingshoofdgroep
+		}
+	}
+
+	var bouwvlakken rpBouwvlakkenResponse
+	if err := c.PostJSON(ctx, "Ruimtelijke Plannen", planURL+"/bouwvlakken/_zoek", query, headers, &bouwvlakken); err != nil {
+		return fmt.Errorf("bouwvlakken request failed: %w", err)
+	}
+	plan.InBuildingPlane = len(bouwvlakken.Embedded.Bouwvlakken) > 0
+
+	var maatvoeringen rpMaatvoeringenResponse
+	if err := c.PostJSON(ctx, "Ruimtelijke Plannen", planURL+"/maatvoeringen/_zoek", query, headers, &maatvoeringen); err != nil {
+		return fmt.Errorf("maatvoeringen request failed: %w", err)
+	}
+	for _, m := range maatvoeringen.Embedded.Maatvoeringen {
+		for _, o := range m.Omvang {
+			name := o.Naam
+			if name == "" {
+				name = m.Naam
+			}
+			applyMaatvoering(plan, name, o.Waarde)
+		}
+	}
+	return nil
+}
+
+// inForceStatuses are the plan statuses under which a plan applies; concept,
+// voorontwerp, ontwerp and voorbereiding plans do not
+var inForceStatuses = map[string]bool{
+	"vastgesteld":          true,
+	"goedgekeurd":          true,
+	"onherroepelijk":       true,
+	"deels onherroepelijk": true,
+}
+
+// planInForce reports whether a plan status means the plan currently applies
+func planInForce(status string) bool {
+	return inForceStatuses[strings.ToLower(strings.TrimSpace(status))]
+}
+
+// applyMaatvoering stores a recognised maatvoering ("maximum bouwhoogte (m)", ...) on the plan
+func applyMaatvoering(plan *models.ZoningPlan, name, value string) {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", "."), 64)
+	if err != nil {
+		return
+	}
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "bouwhoogte"):
+		plan.MaxBuildingHeight = v
+	case strings.Contains(name, "goothoogte"):
+		plan.MaxGutterHeight = v
+	case strings.Contains(name, "bebouwingspercentage"):
+		plan.BuildingCoverage = v
+	}
+}
+
+// FetchLandUseData summarises the zoning plans at the coordinates: the governing
+// enkelbestemming, its building rights, dubbelbestemmingen as restrictions and
+// draft plans as future developments
+func (c *ApiClient) FetchLandUseData(ctx context.Context, cfg *config.Config, lat, lon float64) (*models.LandUseData, error) {
+	plans, err := c.FetchZoningPlans(ctx, cfg, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		markEmpty(ctx, "no spatial plans at coordinates")
+		return &models.LandUseData{}, nil
+	}
+	return summariseZoning(plans), nil
+}
+
+// summariseZoning builds LandUseData from the plans applying at a point. The most
+// recent plan in force with an enkelbestemming governs the building rights.
+func summariseZoning(plans []models.ZoningPlan) *models.LandUseData {
+	result := &models.LandUseData{
+		Restrictions: []string{},
+		AllowedUses:  []string{},
+		FuturePlans:  []models.DevelopmentPlan{},
+		Plans:        plans,
+	}
+
+	var governing *models.ZoningPlan
+	seen := map[string]bool{}
+	for i := range plans {
+		p := &plans[i]
+		if !p.InForce {
+			result.FuturePlans = append(result.FuturePlans, models.DevelopmentPlan{
+				PlanName: p.Name,
+				Type:     p.Type,
+				Status:   "Proposed",
+			})
+			continue
+		}
+		for _, overlay := range p.Overlays {
+			if !seen[overlay] {
+				seen[overlay] = true
+				result.Restrictions = append(result.Restrictions, overlay)
+			}
+		}
+		if p.MainUse != "" && (governing == nil || p.StatusDate > governing.StatusDate) {
+			governing = p
+		}
+	}
+	sort.Strings(result.Restrictions)
+
+	if governing == nil {
+		return result
+	}
+	result.PrimaryUse = governing.MainUseGroup
+	if result.PrimaryUse == "" {
+		result.PrimaryUse = governing.MainUse
+	}
+	result.ZoningCode = governing.ID
+	result.ZoningDetails = governing.MainUse
+	result.AllowedUses = append(result.AllowedUses, governing.MainUse)
+	result.BuildingRights = &models.BuildingRights{
+		MaxHeight:       governing.MaxBuildingHeight,
+		MaxGutterHeight: governing.MaxGutterHeight,
+		GroundCoverage:  governing.BuildingCoverage,
+		InBuildingPlane: governing.InBuildingPlane,
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+)
+
+// newRuimtelijkePlannenServer serves a point covered by an onherroepelijk
+// bestemmingsplan, a paraplu plan with a dubbelbestemming and a draft plan
+func newRuimtelijkePlannenServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("X-Api-Key") != "test-key" || r.Header.Get("Content-Crs") != "epsg:28992" {
+			t.Errorf("Unexpected request %s %s (headers %v)", r.Method, r.URL.Path, r.Header)
+		}
+		var query rpPointQuery
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil || query.Geo.Intersects.Type != "Point" {
+			t.Errorf("Expected point geometry filter, got %+v (%v)", query, err)
+		}
+		// Utrecht Domplein lies around RD (136.9km, 455.9km)
+		if x, y := query.Geo.Intersects.Coordinates[0], query.Geo.Intersects.Coordinates[1]; x < 136000 || x > 138000 || y < 455000 || y > 457000 {
+			t.Errorf("Expected RD coordinates, got (%f, %f)", x, y)
+		}
+
+		switch r.URL.Path {
+		case "/plannen/_zoek":
+			w.Write([]byte(`{"_embedded": {"plannen": [
+				{"id": "NL.IMRO.0344.BPBINNENSTAD-OH01", "naam": "Binnenstad", "type": "bestemmingsplan",
+				 "planstatusInfo": {"planstatus": "onherroepelijk", "datum": "2016-03-10"}},
+				{"id": "NL.IMRO.0344.PARAPLUARCH-VA01", "naam": "Archeologie", "type": "bestemmingsplan",
+				 "planstatusInfo": {"planstatus": "vastgesteld", "datum": "2019-05-02"}},
+				{"id": "NL.IMRO.0344.OPBINNENSTAD-ON01", "naam": "Omgevingsplan Binnenstad", "type": "omgevingsplan",
+				 "planstatusInfo": {"planstatus": "ontwerp", "datum": "2024-01-15"}}
+			]}}`))
+		case "/plannen/NL.IMRO.0344.BPBINNENSTAD-OH01/bestemmingsvlakken/_zoek":
+			w.Write([]byte(`{"_embedded": {"bestemmingsvlakken": [
+				{"type": "enkelbestemming", "naam": "Gemengd - 2", "bestemmingshoofdgroep": "gemengd"},
+				{"type": "dubbelbestemming", "naam": "Waarde - Cultuurhistorie", "bestemmingshoofdgroep": "waarde"}
+			]}}`))
+		case "/plannen/NL.IMRO.0344.BPBINNENSTAD-OH01/bouwvlakken/_zoek":
+			w.Write([]byte(`{"_embedded": {"bouwvlakken": [{"id": "bv1"}]}}`))
+		case "/plannen/NL.IMRO.0344.BPBINNENSTAD-OH01/maatvoeringen/_zoek":
+			w.Write([]byte(`{"_embedded": {"maatvoeringen": [
+				{"naam": "maatvoering", "omvang": [
+					{"naam": "maximum bouwhoogte (m)", "waarde": "15"},
+					{"naam": "maximum goothoogte (m)", "waarde": "10,5"},
+					{"naam": "maximum bebouwingspercentage (%)", "waarde": "80"}
+				]}
+			]}}`))
+		case "/plannen/NL.IMRO.0344.PARAPLUARCH-VA01/bestemmingsvlakken/_zoek":
+			w.Write([]byte(`{"_embedded": {"bestemmingsvlakken": [
+				{"type": "dubbelbestemming", "naam": "Waarde - Archeologie 1", "bestemmingshoofdgroep": "waarde"}
+			]}}`))
+		default:
+			w.Write([]byte(`{"_embedded": {}}`))
+		}
+	}))
+}
+
+func TestFetchZoningPlans(t *testing.T) {
+	server := newRuimtelijkePlannenServer(t)
+	defer server.Close()
+
+	cfg := &config.Config{RuimtelijkePlannenApiURL: server.URL, RuimtelijkePlannenApiKey: "test-key"}
+	client := NewApiClient(server.Client(), cfg)
+
+	plans, err := client.FetchZoningPlans(context.Background(), cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(plans) != 3 {
+		t.Fatalf("Expected 3 plans, got %d", len(plans))
+	}
+
+	bp := plans[0]
+	if !bp.InForce || bp.MainUse != "Gemengd - 2" || bp.MainUseGroup != "gemengd" {
+		t.Errorf("Unexpected enkelbestemming: %+v", bp)
+	}
+	if len(bp.Overlays) != 1 || bp.Overlays[0] != "Waarde - Cultuurhistorie" {
+		t.Errorf("Expected dubbelbestemming overlay, got %v", bp.Overlays)
+	}
+	if !bp.InBuildingPlane || bp.MaxBuildingHeight != 15 || bp.MaxGutterHeight != 10.5 || bp.BuildingCoverage != 80 {
+		t.Errorf("Unexpected bouwvlak/maatvoeringen: %+v", bp)
+	}
+	if plans[2].InForce {
+		t.Error("Expected draft plan not to be in force")
+	}
+}
+
+func TestFetchZoningPlans_SkipsPlanWithFailedDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plannen/_zoek":
+			w.Write([]byte(`{"_embedded": {"plannen": [
+				{"id": "broken", "naam": "Broken", "planstatusInfo": {"planstatus": "onherroepelijk"}},
+				{"id": "ok", "naam": "Ok", "planstatusInfo": {"planstatus": "vastgesteld"}}
+			]}}`))
+		case "/plannen/broken/bouwvlakken/_zoek":
+			http.Error(w, "bad request", http.StatusBadRequest)
+		default:
+			w.Write([]byte(`{"_embedded": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{RuimtelijkePlannenApiURL: server.URL, RuimtelijkePlannenApiKey: "test-key"}
+	client := NewApiClient(server.Client(), cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	plans, err := client.FetchZoningPlans(ctx, cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected the resolved plans, got %v", err)
+	}
+	if len(plans) != 1 || plans[0].ID != "ok" {
+		t.Errorf("Expected only the resolved plan, got %+v", plans)
+	}
+	if prov.Status() == StatusError {
+		t.Errorf("Expected the source not to be marked failed, got %q", prov.Status())
+	}
+}
+
+func TestPlanInForce(t *testing.T) {
+	for status, want := range map[string]bool{
+		"vastgesteld": true, "goedgekeurd": true, "Onherroepelijk": true, "deels onherroepelijk": true,
+		"concept": false, "voorontwerp": false, "ontwerp": false, "voorbereidingsbesluit": false, "": false,
+	} {
+		if got := planInForce(status); got != want {
+			t.Errorf("planInForce(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestFetchLandUseData(t *testing.T) {
+	server := newRuimtelijkePlannenServer(t)
+	defer server.Close()
+
+	cfg := &config.Config{RuimtelijkePlannenApiURL: server.URL, RuimtelijkePlannenApiKey: "test-key"}
+	client := NewApiClient(server.Client(), cfg)
+
+	data, err := client.FetchLandUseData(context.Background(), cfg, 52.0907, 5.1214)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if data.PrimaryUse != "gemengd" || data.ZoningDetails != "Gemengd - 2" || data.ZoningCode != "NL.IMRO.0344.BPBINNENSTAD-OH01" {
+		t.Errorf("Unexpected governing zoning: %+v", data)
+	}
+	if data.BuildingRights == nil || data.BuildingRights.MaxHeight != 15 || data.BuildingRights.MaxGutterHeight != 10.5 ||
+		data.BuildingRights.GroundCoverage != 80 || !data.BuildingRights.InBuildingPlane {
+		t.Errorf("Unexpected building rights: %+v", data.BuildingRights)
+	}
+	if len(data.Restrictions) != 2 || data.Restrictions[0] != "Waarde - Archeologie 1" {
+		t.Errorf("Expected dubbelbestemmingen from every plan in force, got %v", data.Restrictions)
+	}
+	if len(data.FuturePlans) != 1 || data.FuturePlans[0].PlanName != "Omgevingsplan Binnenstad" || data.FuturePlans[0].Status != "Proposed" {
+		t.Errorf("Expected draft plan as future development, got %+v", data.FuturePlans)
+	}
+}
+
+func TestFetchLandUseData_NotConfigured(t *testing.T) {
+	cfg := &config.Config{}
+	client := NewApiClient(http.DefaultClient, cfg)
+
+	ctx, prov := WithProvenance(context.Background())
+	if _, err := client.FetchLandUseData(ctx, cfg, 52.0907, 5.1214); err == nil {
+		t.Fatal("Expected error without API key")
+	}
+	if prov.Status() != StatusNotConfigured {
+		t.Errorf("Expected %q provenance, got %q", StatusNotConfigured, prov.Status())
+	}
+}
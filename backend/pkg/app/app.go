@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/batch"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/costs"
+	"github.com/iman-hussain/nethaddress/backend/pkg/handlers"
+	"github.com/iman-hussain/nethaddress/backend/pkg/localtax"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/routes"
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+	"github.com/rs/cors"
+)
+
+// App is the composition root: it builds the cache, API client, aggregator and
+// scoring engine once and shares them between every handler
+type App struct {
+	Config     *config.Config
+	Cache      cache.Cache
+	APIClient  *apiclient.ApiClient
+	Aggregator *aggregator.PropertyAggregator
+	Scoring    *scoring.EnhancedScoringEngine
+	Batch      *batch.Manager
+
+	PropertyHandler *handlers.PropertyHandler
+	SearchHandler   *handlers.SearchHandler
+	BatchHandler    *handlers.BatchHandler
+	Router          *routes.Router
+}
+
+// New wires the application. cacheService may be nil to disable caching;
+// httpClient may be nil to use the default upstream client. Tests can pass an
+// in-memory cache and an httptest client to run the full stack without Redis
+// or network access. An invalid scoring profiles file, cost rules or tax rates
+// directory is an error rather than a silent fallback to the built-ins.
+func New(cfg *config.Config, cacheService cache.Cache, httpClient *http.Client) (*App, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	profiles, err := scoring.LoadProfiles(cfg.ScoringProfilesFile)
+	if err != nil {
+		return nil, err
+	}
+	costRules, err := costs.LoadRules(cfg.CostRulesDir)
+	if err != nil {
+		return nil, err
+	}
+	taxRates, err := localtax.LoadRates(cfg.LocalTaxRatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &App{
+		Config:  cfg,
+		Cache:   cacheService,
+		Scoring: scoring.NewEnhancedScoringEngineWithProfiles(profiles).WithLocalTaxRates(taxRates),
+	}
+	a.APIClient = apiclient.NewApiClient(httpClient, cfg)
+	a.Aggregator = aggregator.NewPropertyAggregator(a.APIClient, cacheService, cfg)
+	a.Batch = batch.NewManager(a.Aggregator, a.Scoring, cfg.BatchWorkers, cfg.BatchMaxAddresses)
+
+	a.PropertyHandler = handlers.NewPropertyHandler(a.Aggregator, a.Scoring, costRules, a.APIClient, cfg)
+	a.SearchHandler = handlers.NewSearchHandler(a.Aggregator, a.APIClient, cfg)
+	a.BatchHandler = handlers.NewBatchHandler(a.Batch)
+	a.Router = routes.NewRouter(a.PropertyHandler, a.SearchHandler, a.BatchHandler, cacheService, a.APIClient)
+	return a, nil
+}
+
+// Start launches background workers; they stop when ctx is cancelled
+func (a *App) Start(ctx context.Context) {
+	a.Batch.Start(ctx)
+}
+
+// Handler returns the HTTP handler serving all routes with CORS applied
+func (a *App) Handler() http.Handler {
+	mux := http.NewServeMux()
+	a.Router.SetupRoutes(mux)
+
+	allowedOrigins := []string{"http://localhost:3000"}
+	if a.Config.FrontendOrigin != "" {
+		allowedOrigins = append(allowedOrigins, a.Config.FrontendOrigin)
+	}
+	logutil.Infof("CORS allowed origins: %v", allowedOrigins)
+	c := cors.New(cors.Options{
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Admin-Secret"},
+		AllowCredentials: false,
+		Debug:            false,
+	})
+	return c.Handler(mux)
+}
+
+// Shutdown waits for background workers to stop (cancel the Start context
+// first) and then releases shared resources. If ctx expires first, resources
+// are released anyway.
+func (a *App) Shutdown(ctx context.Context) error {
+	if err := a.Batch.Wait(ctx); err != nil {
+		logutil.Warnf("Batch workers did not stop in time: %v", err)
+	}
+	return a.Close()
+}
+
+// Close releases shared resources such as the cache connection
+func (a *App) Close() error {
+	if a.Cache == nil {
+		return nil
+	}
+	return a.Cache.Close()
+}
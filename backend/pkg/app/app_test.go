@@ -0,0 +1,584 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
+	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/handlers"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+// failingTransport fails the test if any upstream API is called
+type failingTransport struct{ t *testing.T }
+
+func (ft failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ft.t.Errorf("Unexpected upstream request to %s", r.URL)
+	return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: r}, nil
+}
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	cfg := &config.Config{BagApiURL: "http://bag.invalid", BatchWorkers: 1, BatchMaxAddresses: 10}
+	return newApp(t, cfg, &http.Client{Transport: failingTransport{t}})
+}
+
+// newApp wires an App with an in-memory cache, failing the test on invalid config
+func newApp(t *testing.T, cfg *config.Config, httpClient *http.Client) *App {
+	t.Helper()
+	a, err := New(cfg, cache.NewMemoryCache(100), httpClient)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return a
+}
+
+// primeProperty caches the aggregated data of an address so handlers serve it
+// without upstream requests
+func primeProperty(t *testing.T, a *App, postcode, houseNumber string, data aggregator.ComprehensivePropertyData) {
+	t.Helper()
+	if err := a.Cache.Set(context.Background(), cache.CacheKey{}.AggregatedKey(postcode, houseNumber), data, cache.PropertyDataTTL); err != nil {
+		t.Fatalf("Failed to prime cache: %v", err)
+	}
+}
+
+// getJSON sends a GET request to handler and decodes a 200 response into T
+func getJSON[T any](t *testing.T, handler http.Handler, target string) (*httptest.ResponseRecorder, T) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var resp T
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return rec, resp
+}
+
+// expectMethodNotAllowed checks that handler rejects a method on target
+func expectMethodNotAllowed(t *testing.T, handler http.Handler, method, target string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for %s, got %d", method, rec.Code)
+	}
+}
+
+func TestApp_InvalidConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"profiles": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for name, cfg := range map[string]*config.Config{
+		"scoring profiles": {ScoringProfilesFile: invalid},
+		"cost rules":       {CostRulesDir: dir},
+		"local tax rates":  {LocalTaxRatesDir: dir},
+	} {
+		if _, err := New(cfg, nil, nil); err == nil {
+			t.Errorf("%s: expected an error instead of the built-ins", name)
+		}
+	}
+}
+
+func TestApp_SharesDependencies(t *testing.T) {
+	a := newTestApp(t)
+
+	if a.Aggregator == nil || a.APIClient == nil || a.Scoring == nil || a.Batch == nil {
+		t.Fatal("Expected all shared dependencies to be built")
+	}
+	if a.PropertyHandler == nil || a.SearchHandler == nil || a.BatchHandler == nil || a.Router == nil {
+		t.Fatal("Expected all handlers to be built")
+	}
+}
+
+func TestApp_ServesFromSharedCache(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	cached := aggregator.ComprehensivePropertyData{Address: "Teststraat 1, 1234AB Utrecht"}
+	primeProperty(t, a, "1234AB", "1", cached)
+
+	rec, resp := getJSON[handlers.PropertyDataResponse](t, handler, "/api/property?postcode=1234AB&houseNumber=1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.Property == nil || resp.Property.Address != cached.Address {
+		t.Errorf("Expected cached address %q, got %q", cached.Address, resp.Property.Address)
+	}
+}
+
+func TestApp_FlushClearsSharedCache(t *testing.T) {
+	t.Setenv("ADMIN_SECRET", "secret")
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	key := cache.CacheKey{}.AggregatedKey("1234AB", "1")
+	a.Cache.Set(context.Background(), key, aggregator.ComprehensivePropertyData{Address: "cached"}, cache.PropertyDataTTL)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/cache/flush", nil)
+	req.Header.Set("X-Admin-Secret", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// The aggregator used by every handler sees the flush
+	if _, ok := a.Aggregator.GetCachedData(context.Background(), "1234AB", "1"); ok {
+		t.Error("Expected flush to clear the cache shared with the aggregator")
+	}
+}
+
+func TestApp_CircuitBreakerAdmin(t *testing.T) {
+	t.Setenv("ADMIN_SECRET", "secret")
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/circuit-breakers", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without admin secret, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/circuit-breakers", nil)
+	req.Header.Set("X-Admin-Secret", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Breakers []map[string]interface{} `json:"breakers"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Breakers == nil {
+		t.Errorf("Expected a breakers list, got %s (%v)", rec.Body.String(), err)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/circuit-breakers/reset?name=Overpass", nil)
+	req.Header.Set("X-Admin-Secret", "secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown breaker, got %d", rec.Code)
+	}
+}
+
+func TestApp_AddressSuggestAndLookup(t *testing.T) {
+	const id = "adr-8f3f3e2a1c6b4d5e9f0a1b2c3d4e5f60"
+	calls := map[string]int{}
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/suggest":
+			w.Write([]byte(`{"response": {"docs": [{"id": "` + id + `", "type": "adres", "weergavenaam": "Teststraat 1A, 1234AB Utrecht", "huis_nlt": "1A", "postcode": "1234AB"}]}}`))
+		case "/lookup":
+			w.Write([]byte(`{"response": {"docs": [{"id": "` + id + `", "type": "adres", "weergavenaam": "Teststraat 1A, 1234AB Utrecht", "huis_nlt": "1A", "postcode": "1234AB", "centroide_ll": "POINT(5.1 52.1)"}]}}`))
+		default:
+			t.Errorf("Unexpected upstream request to %s", r.URL)
+		}
+	}))
+	defer upstream.Close()
+
+	cfg := &config.Config{BagApiURL: upstream.URL + "/free", BatchWorkers: 1, BatchMaxAddresses: 10}
+	a := newApp(t, cfg, upstream.Client())
+	handler := a.Handler()
+
+	// Repeated keystrokes are served from the cache, whatever the case and spacing
+	for _, q := range []string{"teststraat%201", "Teststraat%20%201"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/address/suggest?q="+q, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), id) {
+			t.Fatalf("Expected suggestion with id, got %d: %s", rec.Code, rec.Body.String())
+		}
+	}
+	if calls["/suggest"] != 1 {
+		t.Errorf("Expected one upstream suggest call, got %d", calls["/suggest"])
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/address/suggest?q=t", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a one-character query, got %d", rec.Code)
+	}
+
+	// The id resolves to the postcode and house number whose analysis is cached
+	cached := aggregator.ComprehensivePropertyData{Address: "Teststraat 1A, 1234AB Utrecht"}
+	primeProperty(t, a, "1234AB", "1A", cached)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/property?id="+id, nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), cached.Address) {
+		t.Fatalf("Expected cached property for the id, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/property?id=not-an-id", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown id, got %d", rec.Code)
+	}
+}
+
+func TestApp_AmbiguousAddress(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": {"docs": [
+			{"id": "adr-1", "weergavenaam": "Teststraat 1-1, 1234AB Utrecht", "huisnummer": 1, "huisnummertoevoeging": "1", "postcode": "1234AB"},
+			{"id": "adr-2", "weergavenaam": "Teststraat 1-2, 1234AB Utrecht", "huisnummer": 1, "huisnummertoevoeging": "2", "postcode": "1234AB"}
+		]}}`))
+	}))
+	defer upstream.Close()
+
+	cfg := &config.Config{BagApiURL: upstream.URL, BatchWorkers: 1, BatchMaxAddresses: 10}
+	handler := newApp(t, cfg, upstream.Client()).Handler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/property?postcode=1234AB&houseNumber=1", nil))
+	if rec.Code != http.StatusMultipleChoices {
+		t.Fatalf("Expected 300 for an ambiguous address, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Candidates []struct {
+			ID          string `json:"id"`
+			HouseNumber string `json:"houseNumber"`
+		} `json:"candidates"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || len(resp.Candidates) != 2 || resp.Candidates[1].HouseNumber != "1-2" {
+		t.Errorf("Expected both units as candidates, got %s (%v)", rec.Body.String(), err)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/property?postcode=1234AB&houseNumber=1x-toolong", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid house number, got %d", rec.Code)
+	}
+}
+
+// redirectTransport sends every upstream request to a test server
+type redirectTransport struct{ target string }
+
+func (rt redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme = "http"
+	r.URL.Host = strings.TrimPrefix(rt.target, "http://")
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestApp_PropertyAt(t *testing.T) {
+	afstand := "12.5"
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reverse" {
+			// Every other upstream is unavailable without retries
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"response": {"docs": [{"type": "adres", "weergavenaam": "Teststraat 1, 1234AB Utrecht",
+			"huisnummer": 1, "postcode": "1234AB", "afstand": ` + afstand + `, "centroide_ll": "POINT(5.1 52.1)"}]}}`))
+	}))
+	defer upstream.Close()
+
+	cfg := &config.Config{BagApiURL: upstream.URL + "/free", BatchWorkers: 1, BatchMaxAddresses: 10}
+	a := newApp(t, cfg, &http.Client{Transport: redirectTransport{upstream.URL}})
+	handler := a.Handler()
+
+	cached := aggregator.ComprehensivePropertyData{Address: "Teststraat 1, 1234AB Utrecht"}
+	primeProperty(t, a, "1234AB", "1", cached)
+
+	// A nearby address is analysed as usual
+	rec, resp := getJSON[handlers.PropertyAtResponse](t, handler, "/api/property/at?lat=52.1&lon=5.1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.LocationOnly || resp.Postcode != "1234AB" || resp.Property == nil || resp.Property.Address != cached.Address {
+		t.Errorf("Expected the nearby address, got %s", rec.Body.String())
+	}
+
+	// A distant address falls back to the coordinate-based sources
+	afstand = "400"
+	rec, resp = getJSON[handlers.PropertyAtResponse](t, handler, "/api/property/at?lat=52.1&lon=5.1")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !resp.LocationOnly || resp.Property == nil || !resp.Property.LocationOnly {
+		t.Fatalf("Expected a location-only analysis, got %s", rec.Body.String())
+	}
+	if _, ok := resp.Property.Provenance["Monument Status"]; ok {
+		t.Error("Expected sources needing a BAG address to be left out")
+	}
+	if _, ok := resp.Property.Provenance["AHN Height Model"]; !ok {
+		t.Error("Expected coordinate-based sources to run")
+	}
+
+	if rec, _ := getJSON[handlers.PropertyAtResponse](t, handler, "/api/property/at?lat=40.4&lon=-3.7"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 outside the Netherlands, got %d", rec.Code)
+	}
+	for _, query := range []string{"lat=NaN&lon=NaN", "lat=52.1&lon=Inf", "lat=52.1"} {
+		if rec, _ := getJSON[handlers.PropertyAtResponse](t, handler, "/api/property/at?"+query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestApp_AreaAnalysis(t *testing.T) {
+	var areaRequests atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/collections/buurten/items" {
+			// Point-based sources are unavailable without retries
+			http.NotFound(w, r)
+			return
+		}
+		areaRequests.Add(1)
+		if r.URL.Query().Get("buurtcode") != "BU03440000" {
+			w.Write([]byte(`{"type": "FeatureCollection", "features": []}`))
+			return
+		}
+		w.Write([]byte(`{"type": "FeatureCollection", "features": [{"type": "Feature",
+			"properties": {"buurtnaam": "Binnenstad", "gemeentenaam": "Utrecht", "aantal_inwoners": 4200, "gemiddelde_woningwaarde": 512},
+			"geometry": {"type": "Polygon", "coordinates": [[[5.11, 52.08], [5.12, 52.08], [5.12, 52.09], [5.11, 52.09], [5.11, 52.08]]]}}]}`))
+	}))
+	defer upstream.Close()
+
+	cfg := &config.Config{CBSAreaApiURL: upstream.URL, BatchWorkers: 1, BatchMaxAddresses: 10}
+	a := newApp(t, cfg, &http.Client{Transport: redirectTransport{upstream.URL}})
+	handler := a.Handler()
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/area/bu03440000", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var resp handlers.AreaResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Analysis.Area.Name != "Binnenstad" || resp.Analysis.Area.AverageWOZ != 512000 || len(resp.Analysis.Area.SamplePoints) == 0 {
+			t.Errorf("Unexpected area: %+v", resp.Analysis.Area)
+		}
+		if resp.Scores == nil || resp.Scores.RiskLevel != "Unknown" {
+			t.Errorf("Expected neutral scores without point data, got %+v", resp.Scores)
+		}
+	}
+	if n := areaRequests.Load(); n != 1 {
+		t.Errorf("Expected the second request to be served from cache, got %d area requests", n)
+	}
+
+	for path, want := range map[string]int{
+		"/api/area/BU09999999": http.StatusNotFound,
+		"/api/area/nowhere":    http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+}
+
+func TestApp_Compare(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	for hn, address := range map[string]string{"1": "Teststraat 1, 1234AB Utrecht", "3": "Teststraat 3, 1234AB Utrecht"} {
+		cached := aggregator.ComprehensivePropertyData{Address: address}
+		primeProperty(t, a, "1234AB", hn, cached)
+	}
+
+	body := `{"addresses":[{"postcode":"1234 ab","houseNumber":"1"},{"postcode":"1234AB","houseNumber":"3"}]}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/compare", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp handlers.CompareResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Properties) != 2 || resp.Properties[1].Address != "Teststraat 3, 1234AB Utrecht" || resp.Properties[0].Scores == nil {
+		t.Errorf("Unexpected properties: %+v", resp.Properties)
+	}
+	if resp.Comparison == nil || len(resp.Comparison.Metrics) == 0 || len(resp.Comparison.Metrics[0].Values) != 2 {
+		t.Errorf("Expected a metric matrix aligned with the properties, got %+v", resp.Comparison)
+	}
+
+	for name, body := range map[string]string{
+		"single":    `{"addresses":[{"postcode":"1234AB","houseNumber":"1"}]}`,
+		"duplicate": `{"addresses":[{"postcode":"1234AB","houseNumber":"1"},{"postcode":"1234 AB","houseNumber":"1"}]}`,
+		"invalid":   `{"addresses":[{"postcode":"1234AB","houseNumber":"1"},{"postcode":"nope","houseNumber":"1"}]}`,
+		"malformed": `{"addresses":`,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/compare", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", name, rec.Code)
+		}
+	}
+
+	expectMethodNotAllowed(t, handler, http.MethodGet, "/api/compare")
+}
+
+func TestApp_ScoringProfiles(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	rec, list := getJSON[handlers.ScoringProfilesResponse](t, handler, "/api/scoring/profiles")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if list.Default != "balanced" || len(list.Profiles) != 5 {
+		t.Errorf("Unexpected profiles: default %s, %d profiles", list.Default, len(list.Profiles))
+	}
+
+	cached := aggregator.ComprehensivePropertyData{Address: "Teststraat 1, 1234AB Utrecht"}
+	primeProperty(t, a, "1234AB", "1", cached)
+
+	rec, resp := getJSON[handlers.PropertyScoresResponse](t, handler, "/api/property/scores?postcode=1234AB&houseNumber=1&profile=family")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.Scores == nil || resp.Scores.Profile != "family" {
+		t.Errorf("Expected scores weighted with the family profile, got %+v", resp.Scores)
+	}
+
+	if rec, _ := getJSON[handlers.PropertyScoresResponse](t, handler, "/api/property/scores?postcode=1234AB&houseNumber=1&profile=speculator"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown profile, got %d", rec.Code)
+	}
+}
+
+func TestApp_RentValuation(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	cached := aggregator.ComprehensivePropertyData{
+		Address:     "Teststraat 1, 1234AB Utrecht",
+		BAGBuilding: &models.BAGBuildingData{FloorArea: 70, ConstructionYear: 1965, UnitsInBuilding: 8},
+		WOZData:     &models.AltumWOZData{WOZValue: 300000},
+	}
+	primeProperty(t, a, "1234AB", "1", cached)
+	bare := aggregator.ComprehensivePropertyData{Address: "Teststraat 3, 1234AB Utrecht"}
+	primeProperty(t, a, "1234AB", "3", bare)
+
+	get := func(query string) (*httptest.ResponseRecorder, handlers.RentValuationResponse) {
+		t.Helper()
+		return getJSON[handlers.RentValuationResponse](t, handler, "/api/property/rent?"+query)
+	}
+
+	rec, resp := get("postcode=1234AB&houseNumber=1&energyLabel=C&outdoorArea=6&baths=0")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	v := resp.Valuation
+	if v == nil || v.Points == 0 || v.MaxRent == 0 || v.GrossYield == 0 || v.Segment == "" {
+		t.Fatalf("Expected a complete valuation, got %+v", v)
+	}
+	if v.Input.FloorArea != 70 || !v.Input.MultiFamily || v.Input.EnergyLabel != "C" || v.Input.OutdoorArea == nil || *v.Input.OutdoorArea != 6 {
+		t.Errorf("Expected aggregated inputs with the overrides applied, got %+v", v.Input)
+	}
+
+	// Without a floor area in the data it must be passed
+	if rec, _ := get("postcode=1234AB&houseNumber=3"); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a floor area, got %d", rec.Code)
+	}
+	if rec, _ := get("postcode=1234AB&houseNumber=3&floorArea=55"); rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 with a floor area override, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for _, query := range []string{"floorArea=-1", "toilets=1.5", "monument=maybe", "constructionYear=12"} {
+		if rec, _ := get("postcode=1234AB&houseNumber=1&" + query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestApp_PurchaseCosts(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	cached := aggregator.ComprehensivePropertyData{
+		Address:         "Teststraat 1, 1234AB Utrecht",
+		MarketValuation: &models.MatrixianPropertyValue{MarketValue: 420000},
+		WOZData:         &models.AltumWOZData{WOZValue: 380000},
+		BAGBuilding:     &models.BAGBuildingData{UsageFunctions: []string{"woonfunctie"}},
+	}
+	primeProperty(t, a, "1234AB", "1", cached)
+
+	get := func(query string) (*httptest.ResponseRecorder, handlers.PurchaseCostsResponse) {
+		t.Helper()
+		return getJSON[handlers.PurchaseCostsResponse](t, handler, "/api/property/costs?"+query)
+	}
+
+	rec, resp := get("postcode=1234AB&houseNumber=1&year=2025&income=60000")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	e := resp.Estimate
+	if e.Purchase.Price != 420000 || e.Purchase.PriceSource != "market" || e.Year != 2025 {
+		t.Errorf("Expected the 2025 rules on the market value, got %+v", e.Purchase)
+	}
+	if e.TransferTax.Amount != 8400 || e.Mortgage == nil || !e.NHG.Eligible {
+		t.Errorf("Unexpected estimate %+v", e)
+	}
+
+	// A price alone needs no address
+	rec, resp = get("price=300000&year=2026&ownerOccupied=false")
+	if rec.Code != http.StatusOK || resp.Estimate.TransferTax.Rate != 8 || resp.Postcode != "" {
+		t.Errorf("Expected an investor estimate from the price alone, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for _, query := range []string{"price=300000&starter=true", "price=300000&year=2010", "price=-1", "price=300000&interestRate=450", ""} {
+		if rec, _ := get(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, rec.Code)
+		}
+	}
+
+	expectMethodNotAllowed(t, handler, http.MethodPost, "/api/property/costs?price=300000")
+}
+
+func TestApp_LocalTaxes(t *testing.T) {
+	a := newTestApp(t)
+	handler := a.Handler()
+
+	cached := aggregator.ComprehensivePropertyData{
+		Address:          "Teststraat 1, 1234AB Utrecht",
+		MunicipalityCode: "GM0344",
+		WOZData:          &models.AltumWOZData{WOZValue: 400000},
+	}
+	primeProperty(t, a, "1234AB", "1", cached)
+
+	get := func(query string) (*httptest.ResponseRecorder, handlers.LocalTaxesResponse) {
+		t.Helper()
+		return getJSON[handlers.LocalTaxesResponse](t, handler, "/api/property/taxes?"+query)
+	}
+
+	rec, resp := get("postcode=1234AB&houseNumber=1&year=2026")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	e := resp.Estimate
+	if e.Municipality != "Utrecht" || e.WOZValue != 400000 || e.Landlord.Total != 521.2 || e.OwnerOccupier.Total != 941.2 {
+		t.Errorf("Expected Utrecht's 2026 taxes on the WOZ value, got %+v", e)
+	}
+
+	// A WOZ value and municipality need no address
+	rec, resp = get("woz=300000&municipality=0599&singlePerson=true")
+	if rec.Code != http.StatusOK || resp.Estimate.Municipality != "Rotterdam" || !resp.Estimate.SinglePerson || resp.Postcode != "" {
+		t.Errorf("Expected a Rotterdam estimate without an address, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for _, query := range []string{"woz=300000&municipality=Utrecht", "woz=-1&municipality=GM0344", "woz=300000&municipality=GM0344&year=2010", "woz=300000&municipality=GM0344&singlePerson=maybe", ""} {
+		if rec, _ := get(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected 400, got %d", query, rec.Code)
+		}
+	}
+
+	expectMethodNotAllowed(t, handler, http.MethodPost, "/api/property/taxes?woz=300000&municipality=GM0344")
+}
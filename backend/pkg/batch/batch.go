@@ -0,0 +1,407 @@
+package batch
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+)
+
+// Job lifecycle states
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+)
+
+// Per-address result states
+const (
+	ResultPending = "pending"
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// jobRetention is how long finished jobs stay available for polling and download
+const jobRetention = 24 * time.Hour
+
+// pruneInterval is how often expired jobs are dropped while the workers run
+const pruneInterval = time.Hour
+
+var (
+	// ErrNoAddresses is returned when a batch contains no usable addresses
+	ErrNoAddresses = errors.New("batch contains no addresses")
+	// ErrQueueFull is returned when the worker queue cannot accept the whole batch
+	ErrQueueFull = errors.New("batch queue is full, try again later")
+)
+
+// Aggregator is the subset of PropertyAggregator used by batch workers
+type Aggregator interface {
+	AggregatePropertyData(ctx context.Context, postcode, houseNumber string) (*aggregator.ComprehensivePropertyData, error)
+}
+
+// Scorer is the subset of EnhancedScoringEngine used by batch workers
+type Scorer interface {
+	CalculateComprehensiveScores(data *aggregator.ComprehensivePropertyData) *scoring.PropertyScores
+}
+
+// Address is a single postcode + house number pair submitted in a batch
+type Address struct {
+	Postcode    string `json:"postcode"`
+	HouseNumber string `json:"houseNumber"`
+}
+
+// Result holds the outcome for one address in a batch. Only a summary of the
+// property is kept; the full data is in the aggregator's cache.
+type Result struct {
+	Index       int                     `json:"index"`
+	Postcode    string                  `json:"postcode"`
+	HouseNumber string                  `json:"houseNumber"`
+	Status      string                  `json:"status"` // "pending", "success", "error"
+	Error       string                  `json:"error,omitempty"`
+	Property    *PropertySummary        `json:"property,omitempty"`
+	Scores      *scoring.PropertyScores `json:"scores,omitempty"`
+}
+
+// PropertySummary identifies the property a batch result was scored for
+type PropertySummary struct {
+	Address     string     `json:"address"`
+	BAGID       string     `json:"bagId"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// JobStatus is a point-in-time snapshot of a job's progress
+type JobStatus struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"` // "queued", "running", "completed"
+	Total      int        `json:"total"`
+	Completed  int        `json:"completed"`
+	Succeeded  int        `json:"succeeded"`
+	Failed     int        `json:"failed"`
+	Progress   float64    `json:"progress"` // 0-100
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// job is the internal mutable state of a batch job
+type job struct {
+	mu         sync.Mutex
+	id         string
+	status     string
+	results    []Result
+	completed  int
+	succeeded  int
+	failed     int
+	createdAt  time.Time
+	startedAt  *time.Time
+	finishedAt *time.Time
+}
+
+// task is a single address queued for a worker
+type task struct {
+	job   *job
+	index int
+}
+
+// Manager owns batch jobs and the worker pool that processes them
+type Manager struct {
+	aggregator   Aggregator
+	scorer       Scorer
+	workers      int
+	maxAddresses int
+
+	queue   chan task
+	running sync.WaitGroup
+
+	mu     sync.RWMutex
+	jobs   map[string]*job
+	submit sync.Mutex
+}
+
+// NewManager creates a batch manager. Call Start to launch the worker pool.
+func NewManager(agg Aggregator, scorer Scorer, workers, maxAddresses int) *Manager {
+	if workers <= 0 {
+		workers = 1
+	}
+	if maxAddresses <= 0 {
+		maxAddresses = 1000
+	}
+	return &Manager{
+		aggregator:   agg,
+		scorer:       scorer,
+		workers:      workers,
+		maxAddresses: maxAddresses,
+		// Room for several full batches; Submit rejects batches that don't fit
+		queue: make(chan task, maxAddresses*4),
+		jobs:  make(map[string]*job),
+	}
+}
+
+// MaxAddresses returns the largest batch size accepted by Submit
+func (m *Manager) MaxAddresses() int {
+	return m.maxAddresses
+}
+
+// Start launches the worker pool and the pruning of expired jobs. Both stop when
+// ctx is cancelled.
+func (m *Manager) Start(ctx context.Context) {
+	for i := 0; i < m.workers; i++ {
+		m.running.Add(1)
+		go func() {
+			defer m.running.Done()
+			m.worker(ctx)
+		}()
+	}
+	m.running.Add(1)
+	go func() {
+		defer m.running.Done()
+		m.pruneLoop(ctx)
+	}()
+	logutil.Infof("[BATCH] Started %d batch workers", m.workers)
+}
+
+// Wait blocks until all workers have stopped after their Start context was
+// cancelled, or until ctx is done
+func (m *Manager) Wait(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		m.running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Submit creates a job for the given addresses and queues it for processing
+func (m *Manager) Submit(addresses []Address) (*JobStatus, error) {
+	if len(addresses) == 0 {
+		return nil, ErrNoAddresses
+	}
+	if len(addresses) > m.maxAddresses {
+		return nil, fmt.Errorf("batch contains %d addresses, maximum is %d", len(addresses), m.maxAddresses)
+	}
+
+	m.pruneExpired(time.Now())
+
+	id, err := newJobID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate job ID: %w", err)
+	}
+
+	j := &job{
+		id:        id,
+		status:    StatusQueued,
+		results:   make([]Result, len(addresses)),
+		createdAt: time.Now(),
+	}
+	for i, addr := range addresses {
+		j.results[i] = Result{
+			Index:       i,
+			Postcode:    addr.Postcode,
+			HouseNumber: addr.HouseNumber,
+			Status:      ResultPending,
+		}
+	}
+
+	// Serialise submissions so the capacity check and enqueue are atomic
+	m.submit.Lock()
+	defer m.submit.Unlock()
+
+	if cap(m.queue)-len(m.queue) < len(addresses) {
+		return nil, ErrQueueFull
+	}
+
+	m.mu.Lock()
+	m.jobs[id] = j
+	m.mu.Unlock()
+
+	for i := range addresses {
+		m.queue <- task{job: j, index: i}
+	}
+
+	logutil.Infof("[BATCH] Queued job %s with %d addresses", id, len(addresses))
+	status := j.snapshot()
+	return &status, nil
+}
+
+// Status returns the current progress of a job
+func (m *Manager) Status(id string) (*JobStatus, bool) {
+	j, ok := m.lookup(id)
+	if !ok {
+		return nil, false
+	}
+	status := j.snapshot()
+	return &status, true
+}
+
+// Results returns the job status together with a copy of its per-address results
+func (m *Manager) Results(id string) (*JobStatus, []Result, bool) {
+	j, ok := m.lookup(id)
+	if !ok {
+		return nil, nil, false
+	}
+
+	j.mu.Lock()
+	results := make([]Result, len(j.results))
+	copy(results, j.results)
+	j.mu.Unlock()
+
+	status := j.snapshot()
+	return &status, results, true
+}
+
+// lookup returns a job that has not expired; expired jobs not yet pruned are hidden
+func (m *Manager) lookup(id string) (*job, bool) {
+	m.mu.RLock()
+	j, ok := m.jobs[id]
+	m.mu.RUnlock()
+	if !ok || j.expired(time.Now()) {
+		return nil, false
+	}
+	return j, true
+}
+
+// pruneLoop drops expired jobs every pruneInterval, so an idle server frees them too
+func (m *Manager) pruneLoop(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.pruneExpired(now)
+		}
+	}
+}
+
+// pruneExpired drops finished jobs older than jobRetention
+func (m *Manager) pruneExpired(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, j := range m.jobs {
+		if j.expired(now) {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func (m *Manager) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case t := <-m.queue:
+			m.process(ctx, t)
+		}
+	}
+}
+
+// process aggregates and scores a single address. Repeated addresses are served
+// from the aggregator's cache, so duplicates across jobs cost no upstream calls.
+func (m *Manager) process(ctx context.Context, t task) {
+	j := t.job
+
+	j.mu.Lock()
+	if j.status == StatusQueued {
+		now := time.Now()
+		j.status = StatusRunning
+		j.startedAt = &now
+	}
+	addr := Address{Postcode: j.results[t.index].Postcode, HouseNumber: j.results[t.index].HouseNumber}
+	j.mu.Unlock()
+
+	result := Result{
+		Index:       t.index,
+		Postcode:    addr.Postcode,
+		HouseNumber: addr.HouseNumber,
+	}
+
+	data, err := m.safeAggregate(ctx, addr)
+	if err != nil {
+		logutil.Debugf("[BATCH] Job %s address %s %s failed: %v", j.id, addr.Postcode, addr.HouseNumber, err)
+		result.Status = ResultError
+		result.Error = err.Error()
+	} else {
+		result.Status = ResultSuccess
+		result.Property = &PropertySummary{Address: data.Address, BAGID: data.BAGID, Coordinates: data.Coordinates}
+		if m.scorer != nil {
+			result.Scores = m.scorer.CalculateComprehensiveScores(data)
+		}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results[t.index] = result
+	j.completed++
+	if result.Status == ResultSuccess {
+		j.succeeded++
+	} else {
+		j.failed++
+	}
+	if j.completed == len(j.results) {
+		now := time.Now()
+		j.status = StatusCompleted
+		j.finishedAt = &now
+		logutil.Infof("[BATCH] Job %s completed: %d succeeded, %d failed", j.id, j.succeeded, j.failed)
+	}
+}
+
+// safeAggregate runs the aggregator, converting panics into errors so one bad
+// address cannot take down a worker
+func (m *Manager) safeAggregate(ctx context.Context, addr Address) (data *aggregator.ComprehensivePropertyData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic during aggregation: %v", r)
+		}
+	}()
+	return m.aggregator.AggregatePropertyData(ctx, addr.Postcode, addr.HouseNumber)
+}
+
+// expired reports whether the job finished more than jobRetention before now
+func (j *job) expired(now time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.finishedAt != nil && now.Sub(*j.finishedAt) > jobRetention
+}
+
+func (j *job) snapshot() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	progress := 0.0
+	if len(j.results) > 0 {
+		progress = float64(j.completed) / float64(len(j.results)) * 100
+	}
+	return JobStatus{
+		ID:         j.id,
+		Status:     j.status,
+		Total:      len(j.results),
+		Completed:  j.completed,
+		Succeeded:  j.succeeded,
+		Failed:     j.failed,
+		Progress:   progress,
+		CreatedAt:  j.createdAt,
+		StartedAt:  j.startedAt,
+		FinishedAt: j.finishedAt,
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
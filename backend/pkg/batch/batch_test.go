@@ -0,0 +1,187 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+)
+
+// fakeAggregator returns canned data and fails for house number "404"
+type fakeAggregator struct{}
+
+func (fakeAggregator) AggregatePropertyData(ctx context.Context, postcode, houseNumber string) (*aggregator.ComprehensivePropertyData, error) {
+	if houseNumber == "404" {
+		return nil, errors.New("no results from BAG API")
+	}
+	return &aggregator.ComprehensivePropertyData{
+		Address:     "Teststraat " + houseNumber + ", " + postcode + " Testdorp",
+		Coordinates: [2]float64{4.8952, 52.3702},
+		BAGID:       "0363010000123456",
+	}, nil
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "postcode,huisnummer\n3541 ed,53\n1234AB, 10\n"
+	addresses, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(addresses) != 2 {
+		t.Fatalf("Expected 2 addresses, got %d", len(addresses))
+	}
+	if addresses[0].Postcode != "3541ED" || addresses[0].HouseNumber != "53" {
+		t.Errorf("Expected normalised 3541ED 53, got %+v", addresses[0])
+	}
+}
+
+func TestParseCSV_SingleColumn(t *testing.T) {
+	addresses, err := ParseCSV(strings.NewReader("3541ED 53\n1234AB 10\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV failed: %v", err)
+	}
+	if len(addresses) != 2 || addresses[1].HouseNumber != "10" {
+		t.Errorf("Unexpected addresses: %+v", addresses)
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	wrapped := `{"addresses":[{"postcode":"3541ed","houseNumber":"53"}]}`
+	addresses, err := ParseJSON(strings.NewReader(wrapped))
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+	if len(addresses) != 1 || addresses[0].Postcode != "3541ED" {
+		t.Errorf("Unexpected addresses: %+v", addresses)
+	}
+
+	bare := `[{"postcode":"1234AB","houseNumber":"10"},{"postcode":"1234AB","houseNumber":"12"}]`
+	addresses, err = ParseJSON(strings.NewReader(bare))
+	if err != nil {
+		t.Fatalf("ParseJSON failed for bare array: %v", err)
+	}
+	if len(addresses) != 2 {
+		t.Errorf("Expected 2 addresses, got %d", len(addresses))
+	}
+
+	if _, err := ParseJSON(strings.NewReader(`{"addresses":[{"postcode":"1234AB"}]}`)); err == nil {
+		t.Error("Expected error for address without house number")
+	}
+}
+
+func TestManager_ProcessesJob(t *testing.T) {
+	manager := NewManager(fakeAggregator{}, scoring.NewEnhancedScoringEngine(), 2, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.Start(ctx)
+
+	job, err := manager.Submit([]Address{
+		{Postcode: "1234AB", HouseNumber: "10"},
+		{Postcode: "1234AB", HouseNumber: "404"},
+		{Postcode: "1234AB", HouseNumber: "12"},
+	})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		status, ok := manager.Status(job.ID)
+		if !ok {
+			t.Fatal("Job disappeared")
+		}
+		if status.Status == StatusCompleted {
+			if status.Succeeded != 2 || status.Failed != 1 {
+				t.Errorf("Expected 2 succeeded and 1 failed, got %d/%d", status.Succeeded, status.Failed)
+			}
+			if status.Progress != 100 {
+				t.Errorf("Expected progress 100, got %f", status.Progress)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Job did not complete in time, status: %+v", status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, results, _ := manager.Results(job.ID)
+	if results[1].Status != ResultError || results[1].Error == "" {
+		t.Errorf("Expected second result to be an error, got %+v", results[1])
+	}
+	if results[0].Scores == nil {
+		t.Error("Expected scores for successful result")
+	}
+	if p := results[0].Property; p == nil || p.Address != "Teststraat 10, 1234AB Testdorp" || p.BAGID != "0363010000123456" {
+		t.Errorf("Expected a property summary, got %+v", p)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Errorf("Expected header + 3 rows, got %d lines", len(lines))
+	}
+}
+
+func TestManager_ExpiresJobs(t *testing.T) {
+	manager := NewManager(fakeAggregator{}, nil, 1, 10)
+	job, err := manager.Submit([]Address{{"1234AB", "10"}})
+	if err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	j, _ := manager.lookup(job.ID)
+	finished := time.Now().Add(-jobRetention - time.Minute)
+	j.mu.Lock()
+	j.status, j.finishedAt = StatusCompleted, &finished
+	j.mu.Unlock()
+
+	// An expired job is hidden before it is pruned, then pruned without a new submission
+	if _, ok := manager.Status(job.ID); ok {
+		t.Error("Expected an expired job not to be returned")
+	}
+	manager.pruneExpired(time.Now())
+	manager.mu.RLock()
+	remaining := len(manager.jobs)
+	manager.mu.RUnlock()
+	if remaining != 0 {
+		t.Errorf("Expected the expired job to be pruned, %d jobs left", remaining)
+	}
+}
+
+func TestManager_RejectsOversizedBatch(t *testing.T) {
+	manager := NewManager(fakeAggregator{}, nil, 1, 2)
+	_, err := manager.Submit([]Address{{"1234AB", "1"}, {"1234AB", "2"}, {"1234AB", "3"}})
+	if err == nil {
+		t.Fatal("Expected error for batch exceeding maximum size")
+	}
+	if _, err := manager.Submit(nil); !errors.Is(err, ErrNoAddresses) {
+		t.Errorf("Expected ErrNoAddresses, got %v", err)
+	}
+}
+
+func TestManager_WaitForWorkers(t *testing.T) {
+	manager := NewManager(fakeAggregator{}, nil, 3, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	manager.Start(ctx)
+
+	expired, cancelExpired := context.WithCancel(context.Background())
+	cancelExpired()
+	if err := manager.Wait(expired); err == nil {
+		t.Error("Expected Wait to give up while workers are still running")
+	}
+
+	cancel()
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelWait()
+	if err := manager.Wait(waitCtx); err != nil {
+		t.Errorf("Expected workers to stop after cancellation, got %v", err)
+	}
+}
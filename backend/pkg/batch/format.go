@@ -0,0 +1,161 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
+)
+
+// batchRequest is the JSON body accepted by POST /api/batch
+type batchRequest struct {
+	Addresses []Address `json:"addresses"`
+}
+
+// ParseJSON reads addresses from a JSON body. Both {"addresses": [...]} and a
+// bare array of {"postcode", "houseNumber"} objects are accepted.
+func ParseJSON(r io.Reader) ([]Address, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	var addresses []Address
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(body, &addresses); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else {
+		var req batchRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		addresses = req.Addresses
+	}
+
+	return normalizeAddresses(addresses)
+}
+
+// ParseCSV reads addresses from CSV. Columns are postcode and house number, with an
+// optional header row; a single column in the form "3541ED 53" is also accepted.
+func ParseCSV(r io.Reader) ([]Address, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	postcodeCol, houseNumberCol := 0, 1
+	if len(records) > 0 && isHeaderRow(records[0]) {
+		for i, col := range records[0] {
+			switch strings.ToLower(strings.TrimSpace(col)) {
+			case "postcode", "zipcode", "postal_code":
+				postcodeCol = i
+			case "housenumber", "house_number", "huisnummer", "number":
+				houseNumberCol = i
+			}
+		}
+		records = records[1:]
+	}
+
+	addresses := make([]Address, 0, len(records))
+	for _, record := range records {
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if len(record) == 1 {
+			parts := strings.Fields(record[0])
+			if len(parts) < 2 {
+				return nil, fmt.Errorf("invalid CSV row %q, expected: postcode houseNumber", record[0])
+			}
+			addresses = append(addresses, Address{Postcode: parts[0], HouseNumber: parts[1]})
+			continue
+		}
+		if postcodeCol >= len(record) || houseNumberCol >= len(record) {
+			return nil, fmt.Errorf("invalid CSV row %q, missing postcode or house number", strings.Join(record, ","))
+		}
+		addresses = append(addresses, Address{Postcode: record[postcodeCol], HouseNumber: record[houseNumberCol]})
+	}
+
+	return normalizeAddresses(addresses)
+}
+
+func isHeaderRow(record []string) bool {
+	for _, col := range record {
+		if strings.Contains(strings.ToLower(col), "postcode") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeAddresses(addresses []Address) ([]Address, error) {
+	normalized := make([]Address, 0, len(addresses))
+	for i, addr := range addresses {
+		if strings.TrimSpace(addr.Postcode) == "" || strings.TrimSpace(addr.HouseNumber) == "" {
+			return nil, fmt.Errorf("address %d is missing postcode or houseNumber", i+1)
+		}
+		parsed, err := utils.ParseDutchAddress(addr.Postcode, addr.HouseNumber)
+		if err != nil {
+			return nil, fmt.Errorf("address %d: %w", i+1, err)
+		}
+		normalized = append(normalized, Address{Postcode: parsed.Postcode, HouseNumber: parsed.HouseNumberString()})
+	}
+	if len(normalized) == 0 {
+		return nil, ErrNoAddresses
+	}
+	return normalized, nil
+}
+
+// csvHeader lists the summary columns written by WriteCSV
+var csvHeader = []string{
+	"postcode", "houseNumber", "status", "error", "address", "bagId", "latitude", "longitude",
+	"overallScore", "esgScore", "profitScore", "opportunityScore", "riskLevel",
+}
+
+// WriteCSV writes one summary row per result. Full property data is only
+// available in the JSON download.
+func WriteCSV(w io.Writer, results []Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		row := []string{res.Postcode, res.HouseNumber, res.Status, res.Error, "", "", "", "", "", "", "", "", ""}
+		if res.Property != nil {
+			row[4] = res.Property.Address
+			row[5] = res.Property.BAGID
+			row[6] = strconv.FormatFloat(res.Property.Coordinates[1], 'f', 6, 64)
+			row[7] = strconv.FormatFloat(res.Property.Coordinates[0], 'f', 6, 64)
+		}
+		if res.Scores != nil {
+			row[8] = formatFloat(res.Scores.OverallScore)
+			row[9] = formatFloat(res.Scores.ESGScore)
+			row[10] = formatFloat(res.Scores.ProfitScore)
+			row[11] = formatFloat(res.Scores.OpportunityScore)
+			row[12] = res.Scores.RiskLevel
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -3,100 +3,95 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
 )
 
-// CacheService provides caching functionality for API responses
-type CacheService struct {
-	client *redis.Client
-	ctx    context.Context
+// Cache stores JSON-encoded values by key with a TTL. A ttl of 0 means no expiry.
+// Get returns an error wrapping ErrNotFound on a miss. Calls honour ctx
+// cancellation for backends that do network I/O.
+type Cache interface {
+	Get(ctx context.Context, key string, dest interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	FlushAll(ctx context.Context) error
+	Close() error
 }
 
-// NewCacheService creates a new cache service instance
-func NewCacheService(redisURL string) (*CacheService, error) {
-	opt, err := redis.ParseURL(redisURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
-	}
-
-	client := redis.NewClient(opt)
-	ctx := context.Background()
-
-	// Test connection
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
-	}
+// ErrNotFound is returned (wrapped) by Get when a key is missing, expired or undecodable
+var ErrNotFound = errors.New("key not found")
 
-	return &CacheService{
-		client: client,
-		ctx:    ctx,
-	}, nil
+// rawStore is implemented by backends that can serve encoded entries, so the
+// tiered cache can copy them between tiers without a decode/encode round trip
+type rawStore interface {
+	getRaw(ctx context.Context, key string) ([]byte, time.Duration, error) // remaining TTL, 0 = no expiry
+	setRaw(ctx context.Context, key string, data []byte, ttl time.Duration) error
 }
 
-// Get retrieves a value from cache
-// Self-healing: returns cache miss for legacy gob-encoded entries, forcing fresh fetch
-func (cs *CacheService) Get(key string, dest interface{}) error {
-	val, err := cs.client.Get(cs.ctx, key).Result()
-	if err == redis.Nil {
-		return fmt.Errorf("key not found: %s", key)
-	}
-	if err != nil {
-		return fmt.Errorf("cache get error: %w", err)
-	}
-
-	if err := json.Unmarshal([]byte(val), dest); err != nil {
-		// Self-healing: treat decode failures (e.g. legacy gob data) as cache miss
-		// This forces a fresh fetch which will overwrite with valid JSON
-		return fmt.Errorf("key not found: %s", key)
-	}
-
-	return nil
+// tier is a cache backend usable as a level of TieredCache
+type tier interface {
+	Cache
+	rawStore
 }
 
-// Set stores a value in cache with TTL
-func (cs *CacheService) Set(key string, value interface{}, ttl time.Duration) error {
-	data, err := json.Marshal(value)
-	if err != nil {
-		return fmt.Errorf("failed to encode value (json): %w", err)
-	}
+// Cache backends selectable with CACHE_BACKEND
+const (
+	BackendAuto   = "auto"   // Redis when reachable, memory otherwise
+	BackendRedis  = "redis"  // Redis only
+	BackendMemory = "memory" // in-process LRU only
+	BackendTiered = "tiered" // memory in front of Redis
+	BackendNone   = "none"   // no caching
+)
 
-	if err := cs.client.Set(cs.ctx, key, data, ttl).Err(); err != nil {
-		return fmt.Errorf("cache set error: %w", err)
+// New creates the cache backend selected by backend. Backends needing Redis fall
+// back to the in-memory cache when redisURL is unset or unreachable. It returns a
+// nil Cache for BackendNone.
+func New(backend, redisURL string, maxEntries int) (Cache, error) {
+	if backend == "" {
+		backend = BackendAuto
 	}
 
-	return nil
-}
+	switch backend {
+	case BackendNone:
+		return nil, nil
+	case BackendMemory:
+		return NewMemoryCache(maxEntries), nil
+	case BackendAuto, BackendRedis, BackendTiered:
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", backend)
+	}
 
-// Delete removes a key from cache
-func (cs *CacheService) Delete(key string) error {
-	if err := cs.client.Del(cs.ctx, key).Err(); err != nil {
-		return fmt.Errorf("cache delete error: %w", err)
+	if redisURL == "" {
+		if backend != BackendAuto {
+			logutil.Warnf("Cache backend %q requires REDIS_URL; using in-memory cache", backend)
+		}
+		return NewMemoryCache(maxEntries), nil
 	}
-	return nil
-}
 
-// Exists checks if a key exists in cache
-func (cs *CacheService) Exists(key string) (bool, error) {
-	count, err := cs.client.Exists(cs.ctx, key).Result()
+	redisCache, err := NewRedisCache(context.Background(), redisURL)
 	if err != nil {
-		return false, fmt.Errorf("cache exists error: %w", err)
+		logutil.Warnf("Could not connect to Redis (%v); using in-memory cache", err)
+		return NewMemoryCache(maxEntries), nil
 	}
-	return count > 0, nil
-}
 
-// Close closes the Redis connection
-func (cs *CacheService) Close() error {
-	return cs.client.Close()
+	if backend == BackendTiered {
+		return NewTieredCache(NewMemoryCache(maxEntries), redisCache), nil
+	}
+	return redisCache, nil
 }
 
-// FlushAll clears all keys from the cache
-func (cs *CacheService) FlushAll() error {
-	if err := cs.client.FlushAll(cs.ctx).Err(); err != nil {
-		return fmt.Errorf("cache flush error: %w", err)
+// decode unmarshals a cached entry.
+// Self-healing: decode failures (e.g. legacy gob data) are reported as a cache miss,
+// forcing a fresh fetch which will overwrite the entry with valid JSON.
+func decode(key string, data []byte, dest interface{}) error {
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
 	}
 	return nil
 }
@@ -126,6 +121,9 @@ const (
 
 	// Static data (soil, elevation) - cache for 90 days
 	StaticDataTTL = 90 * 24 * time.Hour
+
+	// Address suggestions - short-lived, repeated as users type
+	SuggestTTL = 10 * time.Minute
 )
 
 // CacheKey generates consistent cache keys
@@ -176,10 +174,10 @@ func (ck CacheKey) ElevationKey(lat, lon float64) string {
 	return fmt.Sprintf("elevation:%.4f:%.4f", lat, lon)
 }
 
-// AggregatedKey generates a cache key for aggregated property data
+// AggregatedKey generates a cache key for aggregated property data, so "12a",
+// "12 A" and "12A" share one entry
 func (ck CacheKey) AggregatedKey(postcode, houseNumber string) string {
-	normalizedPostcode := strings.ToUpper(strings.ReplaceAll(postcode, " ", ""))
-	normalizedHouseNumber := strings.TrimSpace(houseNumber)
+	normalizedPostcode, normalizedHouseNumber := utils.NormalizeAddressInput(postcode, houseNumber)
 	return fmt.Sprintf("aggregated:%s:%s", normalizedPostcode, normalizedHouseNumber)
 }
 
@@ -195,6 +193,22 @@ func (ck CacheKey) AISummaryKey(postcode string) string {
 	return fmt.Sprintf("ai-summary:%s", normalizedPostcode)
 }
 
+// SuggestKey generates a cache key for address suggestions, ignoring case and spacing
+func (ck CacheKey) SuggestKey(query string, limit int) string {
+	normalizedQuery := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	return fmt.Sprintf("suggest:%d:%s", limit, normalizedQuery)
+}
+
+// AddressLookupKey generates a cache key for a Locatieserver address ID
+func (ck CacheKey) AddressLookupKey(id string) string {
+	return fmt.Sprintf("address:%s", id)
+}
+
+// AreaKey generates a cache key for a buurt, wijk or postcode-4 area analysis
+func (ck CacheKey) AreaKey(code string) string {
+	return fmt.Sprintf("area:%s", strings.ToUpper(strings.TrimSpace(code)))
+}
+
 // ScoresKey generates a cache key for calculated scores
 func (ck CacheKey) ScoresKey(bagID string) string {
 	return fmt.Sprintf("scores:%s", bagID)
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// fakeClock lets tests advance time for TTL checks
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestMemoryCache(maxEntries int) (*MemoryCache, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	mc := NewMemoryCache(maxEntries)
+	mc.now = clock.now
+	return mc, clock
+}
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	ctx := context.Background()
+	mc, _ := newTestMemoryCache(10)
+
+	if err := mc.Set(ctx, "a", testValue{Name: "alpha", Count: 1}, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	var got testValue
+	if err := mc.Get(ctx, "a", &got); err != nil {
+		t.Fatalf("Expected hit, got %v", err)
+	}
+	if got.Name != "alpha" || got.Count != 1 {
+		t.Errorf("Unexpected value: %+v", got)
+	}
+
+	if err := mc.Get(ctx, "missing", &got); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	// Undecodable entries are reported as a miss
+	var wrongType []string
+	if err := mc.Get(ctx, "a", &wrongType); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected decode failure to be a miss, got %v", err)
+	}
+}
+
+func TestMemoryCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	mc, clock := newTestMemoryCache(10)
+	mc.Set(ctx, "short", 1, time.Minute)
+	mc.Set(ctx, "forever", 2, 0)
+
+	clock.advance(2 * time.Minute)
+
+	if ok, _ := mc.Exists(ctx, "short"); ok {
+		t.Error("Expected entry to expire after its TTL")
+	}
+	if ok, _ := mc.Exists(ctx, "forever"); !ok {
+		t.Error("Expected entry without TTL to persist")
+	}
+	if mc.Len() != 1 {
+		t.Errorf("Expected expired entry to be evicted on access, got %d entries", mc.Len())
+	}
+}
+
+func TestMemoryCache_LRUEviction(t *testing.T) {
+	ctx := context.Background()
+	mc, _ := newTestMemoryCache(2)
+	mc.Set(ctx, "a", 1, 0)
+	mc.Set(ctx, "b", 2, 0)
+
+	// Touch a so b becomes least recently used
+	var v int
+	mc.Get(ctx, "a", &v)
+	mc.Set(ctx, "c", 3, 0)
+
+	if ok, _ := mc.Exists(ctx, "b"); ok {
+		t.Error("Expected least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if ok, _ := mc.Exists(ctx, key); !ok {
+			t.Errorf("Expected %q to be kept", key)
+		}
+	}
+
+	mc.FlushAll(ctx)
+	if mc.Len() != 0 {
+		t.Errorf("Expected empty cache after flush, got %d", mc.Len())
+	}
+}
+
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+	front, frontClock := newTestMemoryCache(10)
+	back, _ := newTestMemoryCache(10)
+	tc := &TieredCache{front: front, back: back}
+
+	if err := tc.Set(ctx, "k", testValue{Name: "both"}, time.Hour); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if ok, _ := front.Exists(ctx, "k"); !ok {
+		t.Error("Expected write to reach the memory tier")
+	}
+	if ok, _ := back.Exists(ctx, "k"); !ok {
+		t.Error("Expected write to reach the shared tier")
+	}
+
+	// Front copies expire after TieredFrontTTL even if the shared entry lives longer
+	frontClock.advance(TieredFrontTTL + time.Second)
+	if ok, _ := front.Exists(ctx, "k"); ok {
+		t.Error("Expected memory tier copy to be capped at TieredFrontTTL")
+	}
+
+	// A miss in memory is filled from the shared tier
+	var got testValue
+	if err := tc.Get(ctx, "k", &got); err != nil || got.Name != "both" {
+		t.Fatalf("Expected fill from shared tier, got %+v (%v)", got, err)
+	}
+	if ok, _ := front.Exists(ctx, "k"); !ok {
+		t.Error("Expected memory tier to be refilled")
+	}
+
+	tc.Delete(ctx, "k")
+	if ok, _ := tc.Exists(ctx, "k"); ok {
+		t.Error("Expected delete to remove the key from both tiers")
+	}
+}
+
+func TestNew(t *testing.T) {
+	c, err := New(BackendNone, "", 0)
+	if err != nil || c != nil {
+		t.Errorf("Expected nil cache for %q, got %T (%v)", BackendNone, c, err)
+	}
+
+	// Backends needing Redis fall back to memory without REDIS_URL
+	for _, backend := range []string{"", BackendAuto, BackendMemory, BackendRedis, BackendTiered} {
+		c, err := New(backend, "", 0)
+		if err != nil {
+			t.Errorf("Backend %q: unexpected error %v", backend, err)
+			continue
+		}
+		if _, ok := c.(*MemoryCache); !ok {
+			t.Errorf("Backend %q: expected *MemoryCache, got %T", backend, c)
+		}
+	}
+
+	if _, err := New("memcached", "", 0); err == nil {
+		t.Error("Expected error for unknown backend")
+	}
+}
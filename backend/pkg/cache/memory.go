@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMemoryMaxEntries bounds the in-memory cache when no size is configured
+const DefaultMemoryMaxEntries = 10000
+
+// MemoryCache is a bounded in-process Cache with per-entry TTL and LRU eviction.
+// Values are stored JSON-encoded so callers never share mutable state with the cache.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List // front = most recently used
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	data    []byte
+	expires time.Time // zero = no expiry
+}
+
+// NewMemoryCache creates an in-memory cache holding at most maxEntries entries
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMemoryMaxEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+// Get retrieves a value from cache
+func (mc *MemoryCache) Get(ctx context.Context, key string, dest interface{}) error {
+	data, _, err := mc.getRaw(ctx, key)
+	if err != nil {
+		return err
+	}
+	return decode(key, data, dest)
+}
+
+// Set stores a value in cache with TTL
+func (mc *MemoryCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value (json): %w", err)
+	}
+	return mc.setRaw(ctx, key, data, ttl)
+}
+
+func (mc *MemoryCache) getRaw(_ context.Context, key string) ([]byte, time.Duration, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	el, ok := mc.items[key]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	entry := el.Value.(*memoryEntry)
+
+	var remaining time.Duration
+	if !entry.expires.IsZero() {
+		remaining = entry.expires.Sub(mc.now())
+		if remaining <= 0 {
+			mc.removeElement(el)
+			return nil, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+	}
+
+	mc.ll.MoveToFront(el)
+	return entry.data, remaining, nil
+}
+
+func (mc *MemoryCache) setRaw(_ context.Context, key string, data []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = mc.now().Add(ttl)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if el, ok := mc.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.data, entry.expires = data, expires
+		mc.ll.MoveToFront(el)
+		return nil
+	}
+
+	mc.items[key] = mc.ll.PushFront(&memoryEntry{key: key, data: data, expires: expires})
+	for mc.ll.Len() > mc.maxEntries {
+		mc.removeElement(mc.ll.Back())
+	}
+	return nil
+}
+
+// Delete removes a key from cache
+func (mc *MemoryCache) Delete(_ context.Context, key string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if el, ok := mc.items[key]; ok {
+		mc.removeElement(el)
+	}
+	return nil
+}
+
+// Exists checks if an unexpired key exists in cache
+func (mc *MemoryCache) Exists(ctx context.Context, key string) (bool, error) {
+	_, _, err := mc.getRaw(ctx, key)
+	return err == nil, nil
+}
+
+// FlushAll clears all keys from the cache
+func (mc *MemoryCache) FlushAll(_ context.Context) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.ll.Init()
+	mc.items = make(map[string]*list.Element)
+	return nil
+}
+
+// Close releases the cache; the in-memory cache has nothing to release
+func (mc *MemoryCache) Close() error {
+	return nil
+}
+
+// Len returns the number of entries held, including expired ones not yet evicted
+func (mc *MemoryCache) Len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	return mc.ll.Len()
+}
+
+func (mc *MemoryCache) removeElement(el *list.Element) {
+	mc.ll.Remove(el)
+	delete(mc.items, el.Value.(*memoryEntry).key)
+}
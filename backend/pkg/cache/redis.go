@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by Redis, shared between instances
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache connects to Redis and returns a cache using it
+func NewRedisCache(ctx context.Context, redisURL string) (*RedisCache, error) {
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opt)
+
+	// Test connection
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisCache{client: client}, nil
+}
+
+// Get retrieves a value from cache
+// Self-healing: returns cache miss for legacy gob-encoded entries, forcing fresh fetch
+func (cs *RedisCache) Get(ctx context.Context, key string, dest interface{}) error {
+	val, err := cs.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return fmt.Errorf("cache get error: %w", err)
+	}
+	return decode(key, val, dest)
+}
+
+// Set stores a value in cache with TTL
+func (cs *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value (json): %w", err)
+	}
+
+	return cs.setRaw(ctx, key, data, ttl)
+}
+
+func (cs *RedisCache) setRaw(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	if err := cs.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("cache set error: %w", err)
+	}
+	return nil
+}
+
+func (cs *RedisCache) getRaw(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	pipe := cs.client.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, fmt.Errorf("cache get error: %w", err)
+	}
+
+	val, err := get.Bytes()
+	if err == redis.Nil {
+		return nil, 0, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("cache get error: %w", err)
+	}
+
+	// PTTL is negative for keys without expiry
+	ttl := pttl.Val()
+	if ttl < 0 {
+		ttl = 0
+	}
+	return val, ttl, nil
+}
+
+// Delete removes a key from cache
+func (cs *RedisCache) Delete(ctx context.Context, key string) error {
+	if err := cs.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("cache delete error: %w", err)
+	}
+	return nil
+}
+
+// Exists checks if a key exists in cache
+func (cs *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	count, err := cs.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("cache exists error: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Close closes the Redis connection
+func (cs *RedisCache) Close() error {
+	return cs.client.Close()
+}
+
+// FlushAll clears all keys from the cache
+func (cs *RedisCache) FlushAll(ctx context.Context) error {
+	if err := cs.client.FlushAll(ctx).Err(); err != nil {
+		return fmt.Errorf("cache flush error: %w", err)
+	}
+	return nil
+}
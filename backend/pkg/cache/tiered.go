@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TieredFrontTTL caps how long the memory tier keeps an entry copied from Redis,
+// so updates and flushes made by other instances are picked up within this window
+const TieredFrontTTL = 5 * time.Minute
+
+// TieredCache serves reads from a local memory tier in front of a shared Redis tier.
+// Writes go to both tiers; misses in memory are filled from Redis.
+type TieredCache struct {
+	front tier
+	back  tier
+}
+
+// NewTieredCache creates a two-tier cache with memory in front of Redis
+func NewTieredCache(front *MemoryCache, back *RedisCache) *TieredCache {
+	return &TieredCache{front: front, back: back}
+}
+
+// Get retrieves a value from the memory tier, falling back to Redis
+func (tc *TieredCache) Get(ctx context.Context, key string, dest interface{}) error {
+	if data, _, err := tc.front.getRaw(ctx, key); err == nil {
+		return decode(key, data, dest)
+	}
+
+	data, ttl, err := tc.back.getRaw(ctx, key)
+	if err != nil {
+		return err
+	}
+	tc.front.setRaw(ctx, key, data, frontTTL(ttl))
+	return decode(key, data, dest)
+}
+
+// Set stores a value in both tiers
+func (tc *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value (json): %w", err)
+	}
+	tc.front.setRaw(ctx, key, data, frontTTL(ttl))
+	return tc.back.setRaw(ctx, key, data, ttl)
+}
+
+// Delete removes a key from both tiers
+func (tc *TieredCache) Delete(ctx context.Context, key string) error {
+	return errors.Join(tc.front.Delete(ctx, key), tc.back.Delete(ctx, key))
+}
+
+// Exists checks if a key exists in either tier
+func (tc *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if ok, _ := tc.front.Exists(ctx, key); ok {
+		return true, nil
+	}
+	return tc.back.Exists(ctx, key)
+}
+
+// FlushAll clears both tiers
+func (tc *TieredCache) FlushAll(ctx context.Context) error {
+	return errors.Join(tc.front.FlushAll(ctx), tc.back.FlushAll(ctx))
+}
+
+// Close closes both tiers
+func (tc *TieredCache) Close() error {
+	return errors.Join(tc.front.Close(), tc.back.Close())
+}
+
+// frontTTL limits a TTL to TieredFrontTTL (0 means no expiry)
+func frontTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 || ttl > TieredFrontTTL {
+		return TieredFrontTTL
+	}
+	return ttl
+}
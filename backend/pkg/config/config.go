@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -15,6 +16,7 @@ type Config struct {
 
 	// Property & Land Data APIs
 	BagApiURL                string `envconfig:"BAG_API_URL"`
+	BAGOGCApiURL             string `envconfig:"BAG_OGC_API_URL"`
 	KadasterObjectInfoApiURL string `envconfig:"KADASTER_OBJECTINFO_API_URL"`
 	KadasterObjectInfoApiKey string `envconfig:"KADASTER_OBJECTINFO_API_KEY"`
 	AltumWOZApiURL           string `envconfig:"ALTUM_WOZ_API_URL"`
@@ -56,6 +58,8 @@ type Config struct {
 	CBSPopulationApiURL  string `envconfig:"CBS_POPULATION_API_URL"`
 	CBSStatLineApiURL    string `envconfig:"CBS_STATLINE_API_URL"`
 	CBSSquareStatsApiURL string `envconfig:"CBS_SQUARE_STATS_API_URL"`
+	CBSAreaApiURL        string `envconfig:"CBS_AREA_API_URL"`
+	CBSPostcode4ApiURL   string `envconfig:"CBS_POSTCODE4_API_URL"`
 	CBSApiURL            string `envconfig:"CBS_API_URL"`
 	CBSApiKey            string `envconfig:"CBS_API_KEY"` // Added missing key
 
@@ -85,18 +89,54 @@ type Config struct {
 	AHNHeightModelApiURL string `envconfig:"AHN_HEIGHT_MODEL_API_URL"`
 
 	// Comprehensive Platforms
-	PDOKApiURL     string `envconfig:"PDOK_API_URL"`
-	StratopoApiURL string `envconfig:"STRATOPO_API_URL"`
-	StratopoApiKey string `envconfig:"STRATOPO_API_KEY"`
-	LandUseApiURL  string `envconfig:"LAND_USE_API_URL"`
+	PDOKApiURL            string `envconfig:"PDOK_API_URL"`
+	KadastraleKaartApiURL string `envconfig:"KADASTRALE_KAART_API_URL"`
+	StratopoApiURL        string `envconfig:"STRATOPO_API_URL"`
+	StratopoApiKey        string `envconfig:"STRATOPO_API_KEY"`
+	LandUseApiURL         string `envconfig:"LAND_USE_API_URL"`
+
+	// Zoning (Ruimtelijke Plannen Opvragen API)
+	RuimtelijkePlannenApiURL string `envconfig:"RUIMTELIJKE_PLANNEN_API_URL"`
+	RuimtelijkePlannenApiKey string `envconfig:"RUIMTELIJKE_PLANNEN_API_KEY"`
 
 	// Legacy/Existing
 	ZoningApiURL     string `envconfig:"ZONING_API_URL"`
 	BodemloketApiURL string `envconfig:"BODEMLOKET_API_URL"`
 	MonumentenApiURL string `envconfig:"MONUMENTEN_API_URL"`
+	RCERegisterURL   string `envconfig:"RCE_REGISTER_URL"`
 
 	// AI Summary
 	GeminiApiKey string `envconfig:"GEMINI_API_KEY"`
+
+	// Upstream Rate Limits (requests per second, keyed by host, e.g. "overpass-api.de:1,api.pdok.nl:20")
+	UpstreamRateLimits       map[string]float64 `envconfig:"UPSTREAM_RATE_LIMITS"`
+	UpstreamDefaultRateLimit float64            `envconfig:"UPSTREAM_DEFAULT_RATE_LIMIT"` // 0 = unlimited
+
+	// Circuit breakers per upstream API: consecutive failures before opening (0 = disabled),
+	// how long to reject requests once open, and probe requests allowed while half-open
+	CircuitBreakerFailureThreshold int           `envconfig:"CIRCUIT_BREAKER_FAILURE_THRESHOLD" default:"5"`
+	CircuitBreakerOpenTimeout      time.Duration `envconfig:"CIRCUIT_BREAKER_OPEN_TIMEOUT" default:"30s"`
+	CircuitBreakerHalfOpenRequests int           `envconfig:"CIRCUIT_BREAKER_HALF_OPEN_REQUESTS" default:"1"`
+
+	// Caching: "auto" (Redis when reachable, else memory), "redis", "memory", "tiered" or "none"
+	CacheBackend          string `envconfig:"CACHE_BACKEND" default:"auto"`
+	CacheMemoryMaxEntries int    `envconfig:"CACHE_MEMORY_MAX_ENTRIES" default:"10000"`
+
+	// Batch Processing
+	BatchWorkers      int `envconfig:"BATCH_WORKERS" default:"4"`
+	BatchMaxAddresses int `envconfig:"BATCH_MAX_ADDRESSES" default:"1000"`
+
+	// Scoring profiles: JSON file adding to or replacing the built-in profiles (empty = built-ins only)
+	ScoringProfilesFile string `envconfig:"SCORING_PROFILES_FILE"`
+
+	// Purchase cost rules: directory of per-year JSON files adding to or replacing the built-in years
+	CostRulesDir string `envconfig:"COST_RULES_DIR"`
+
+	// Municipal tax rates: directory of per-year COELO JSON files adding to or replacing the built-in years
+	LocalTaxRatesDir string `envconfig:"LOCAL_TAX_RATES_DIR"`
+
+	// Graceful shutdown: how long to drain in-flight requests and SSE streams on SIGTERM
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"30s"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -133,6 +173,9 @@ func (c *Config) ApplyUserLocalKeys(userKeys map[string]string) {
 	if val, ok := userKeys["KNMI Solar"]; ok {
 		c.KNMISolarApiKey = val
 	}
+	if val, ok := userKeys["EP-Online Energy Label"]; ok {
+		c.EnergieLabelApiKey = val
+	}
 	if val, ok := userKeys["Altum Energy & Climate"]; ok {
 		c.AltumEnergyApiKey = val
 	}
@@ -145,4 +188,7 @@ func (c *Config) ApplyUserLocalKeys(userKeys map[string]string) {
 	if val, ok := userKeys["Stratopo Environment"]; ok {
 		c.StratopoApiKey = val
 	}
+	if val, ok := userKeys["Land Use & Zoning"]; ok {
+		c.RuimtelijkePlannenApiKey = val
+	}
 }
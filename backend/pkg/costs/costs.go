@@ -0,0 +1,215 @@
+package costs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrMissingPrice is returned when there is no price to calculate costs from
+var ErrMissingPrice = errors.New("a purchase price is required")
+
+// Purchase describes the purchase and the buyer
+type Purchase struct {
+	Price         float64 `json:"price"`
+	PriceSource   string  `json:"priceSource"` // "input", "market" or "woz"
+	Residential   bool    `json:"residential"`
+	OwnerOccupied bool    `json:"ownerOccupied"` // the buyer will live in it
+	Starter       bool    `json:"starter"`       // the buyer claims the startersvrijstelling
+	BuyerAge      int     `json:"buyerAge,omitempty"`
+	EnergySaving  bool    `json:"energySaving"` // energy-saving measures are financed (higher NHG limit)
+
+	Cash          bool    `json:"cash"`           // bought without a mortgage
+	Loan          float64 `json:"loan,omitempty"` // requested mortgage; 0 derives it
+	Income        float64 `json:"income,omitempty"`
+	PartnerIncome float64 `json:"partnerIncome,omitempty"`
+	InterestRate  float64 `json:"interestRate,omitempty"` // percent; 0 uses the rules default
+}
+
+// Estimate is the cost of a purchase under one year's rules
+type Estimate struct {
+	Year        int               `json:"year"` // year of the rules applied
+	Purchase    Purchase          `json:"purchase"`
+	TransferTax TransferTax       `json:"transferTax"`
+	Fees        []Fee             `json:"fees"`
+	NHG         NHG               `json:"nhg"`
+	Mortgage    *MortgageEstimate `json:"mortgage,omitempty"` // only with an income
+	Loan        float64           `json:"loan"`               // mortgage the NHG premium and fees are based on
+	// BuyerCosts are the kosten koper: transfer tax, fees and NHG premium
+	BuyerCosts       float64 `json:"buyerCosts"`
+	TotalAcquisition float64 `json:"totalAcquisition"` // price plus buyer costs
+	OwnFunds         float64 `json:"ownFunds"`         // what the loan does not cover
+}
+
+// TransferTax is the overdrachtsbelasting due
+type TransferTax struct {
+	Rate      float64 `json:"rate"` // percent
+	Amount    float64 `json:"amount"`
+	Exemption string  `json:"exemption,omitempty"` // "starter" when the startersvrijstelling applies
+	Basis     string  `json:"basis"`
+}
+
+// Fee is an estimated fixed purchase cost
+type Fee struct {
+	Key    string  `json:"key"`
+	Label  string  `json:"label"`
+	Amount float64 `json:"amount"`
+}
+
+// NHG reports Nationale Hypotheek Garantie eligibility and its one-off premium
+type NHG struct {
+	Eligible    bool    `json:"eligible"`
+	Limit       float64 `json:"limit"`
+	PremiumRate float64 `json:"premiumRate"` // percent
+	Premium     float64 `json:"premium"`
+	Reason      string  `json:"reason,omitempty"` // why the purchase is not eligible
+}
+
+// MortgageEstimate is the maximum mortgage on the buyer's income
+type MortgageEstimate struct {
+	Income         float64 `json:"income"`       // counted gross annual income
+	InterestRate   float64 `json:"interestRate"` // percent
+	TermYears      int     `json:"termYears"`
+	Woonquote      float64 `json:"woonquote"`      // share of income for housing costs
+	MonthlyPayment float64 `json:"monthlyPayment"` // gross annuity payment at the maximum
+	MaxByIncome    float64 `json:"maxByIncome"`
+	MaxByValue     float64 `json:"maxByValue"` // loan-to-value limit on the price
+	MaxMortgage    float64 `json:"maxMortgage"`
+}
+
+// Calculate estimates the transfer tax, fees, NHG premium and maximum mortgage of
+// a purchase. Without a requested loan the loan is the maximum mortgage when an
+// income is given, else the loan-to-value limit.
+func Calculate(rules *Rules, p Purchase) (*Estimate, error) {
+	if p.Price <= 0 {
+		return nil, ErrMissingPrice
+	}
+	e := &Estimate{Year: rules.Year, Purchase: p}
+	e.TransferTax = transferTax(rules.TransferTax, p)
+
+	if p.Income > 0 {
+		e.Mortgage = maxMortgage(rules.Mortgage, p)
+	}
+	switch {
+	case p.Cash:
+		e.Loan = 0
+	case p.Loan > 0:
+		e.Loan = p.Loan
+	case e.Mortgage != nil:
+		e.Loan = e.Mortgage.MaxMortgage
+	default:
+		e.Loan = round2(p.Price * rules.Mortgage.MaxLoanToValue)
+	}
+
+	e.Fees = []Fee{}
+	feeTotal := 0.0
+	for _, f := range rules.Fees {
+		if f.MortgageOnly && e.Loan <= 0 {
+			continue
+		}
+		e.Fees = append(e.Fees, Fee{Key: f.Key, Label: f.Label, Amount: f.Amount})
+		feeTotal += f.Amount
+	}
+
+	e.NHG = nhg(rules.NHG, p, e.Loan)
+
+	e.BuyerCosts = round2(e.TransferTax.Amount + feeTotal + e.NHG.Premium)
+	e.TotalAcquisition = round2(p.Price + e.BuyerCosts)
+	e.OwnFunds = round2(math.Max(0, e.TotalAcquisition-e.Loan))
+	return e, nil
+}
+
+// transferTax applies the rate for the kind of property and buyer, and the
+// startersvrijstelling when the buyer qualifies
+func transferTax(rule TransferTaxRule, p Purchase) TransferTax {
+	var t TransferTax
+	switch {
+	case !p.Residential:
+		t.Rate, t.Basis = rule.NonResidentialRate, "non-residential property"
+	case !p.OwnerOccupied:
+		t.Rate, t.Basis = rule.ResidentialRate, "dwelling the buyer will not live in"
+	default:
+		t.Rate, t.Basis = rule.OwnerOccupiedRate, "dwelling the buyer will live in"
+		if p.Starter {
+			s := rule.StarterExemption
+			switch {
+			case p.BuyerAge < s.MinAge || p.BuyerAge > s.MaxAge:
+				t.Basis += fmt.Sprintf("; no starter exemption: buyer must be %d-%d", s.MinAge, s.MaxAge)
+			case p.Price > s.PriceLimit:
+				t.Basis += fmt.Sprintf("; no starter exemption: price above €%.0f", s.PriceLimit)
+			default:
+				t.Rate, t.Exemption = 0, "starter"
+				t.Basis += "; startersvrijstelling"
+			}
+		}
+	}
+	t.Amount = round2(p.Price * t.Rate / 100)
+	return t
+}
+
+// nhg checks the purchase against the NHG cost limit; only owner-occupied homes
+// bought with a mortgage qualify
+func nhg(rule NHGRule, p Purchase, loan float64) NHG {
+	n := NHG{Limit: rule.Limit, PremiumRate: rule.PremiumRate}
+	if p.EnergySaving {
+		n.Limit = rule.EnergySavingLimit
+	}
+	switch {
+	case !p.Residential || !p.OwnerOccupied:
+		n.Reason = "only for a home the buyer will live in"
+	case loan <= 0:
+		n.Reason = "no mortgage"
+	case p.Price > n.Limit:
+		n.Reason = fmt.Sprintf("price above the NHG limit of €%.0f", n.Limit)
+	default:
+		n.Eligible = true
+		n.Premium = round2(loan * rule.PremiumRate / 100)
+	}
+	return n
+}
+
+// maxMortgage estimates the maximum annuity mortgage from the woonquote for the
+// buyer's income and interest rate, capped by the loan-to-value limit
+func maxMortgage(rule MortgageRule, p Purchase) *MortgageEstimate {
+	m := &MortgageEstimate{
+		Income:       p.Income + p.PartnerIncome*rule.PartnerIncomeShare,
+		InterestRate: p.InterestRate,
+		TermYears:    rule.TermYears,
+	}
+	if m.InterestRate <= 0 {
+		m.InterestRate = rule.DefaultInterestRate
+	}
+	m.Woonquote = woonquote(rule.Woonquotes, m.InterestRate, m.Income)
+	m.MonthlyPayment = round2(m.Income * m.Woonquote / 12)
+
+	// Present value of the monthly annuity payments over the term
+	r := m.InterestRate / 100 / 12
+	n := float64(rule.TermYears * 12)
+	m.MaxByIncome = round2(m.MonthlyPayment * (1 - math.Pow(1+r, -n)) / r)
+	m.MaxByValue = round2(p.Price * rule.MaxLoanToValue)
+	m.MaxMortgage = math.Min(m.MaxByIncome, m.MaxByValue)
+	return m
+}
+
+// woonquote looks up the housing cost share for an income at an interest rate;
+// rates above the last table use the last table
+func woonquote(bands []WoonquoteBand, rate, income float64) float64 {
+	band := bands[len(bands)-1]
+	for _, b := range bands {
+		if rate <= b.RateUpTo {
+			band = b
+			break
+		}
+	}
+	ratio := band.Brackets[0].Ratio
+	for _, b := range band.Brackets {
+		if income >= b.IncomeFrom {
+			ratio = b.Ratio
+		}
+	}
+	return ratio
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,161 @@
+package costs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rulesFor(t *testing.T, year int) *Rules {
+	t.Helper()
+	r, err := BuiltinRules().ForYear(year)
+	if err != nil {
+		t.Fatalf("ForYear(%d): %v", year, err)
+	}
+	return r
+}
+
+func TestCalculateStarter(t *testing.T) {
+	home := Purchase{Price: 400000, Residential: true, OwnerOccupied: true, Starter: true, BuyerAge: 30}
+	e, err := Calculate(rulesFor(t, 2025), home)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if e.TransferTax.Amount != 0 || e.TransferTax.Exemption != "starter" {
+		t.Errorf("Expected the startersvrijstelling, got %+v", e.TransferTax)
+	}
+	// Without an income the loan is the full price
+	if e.Loan != 400000 || !e.NHG.Eligible || e.NHG.Premium != 2400 {
+		t.Errorf("Expected NHG at 0.6%% of a 400000 loan, got loan %.0f, %+v", e.Loan, e.NHG)
+	}
+	if len(e.Fees) != 5 || e.BuyerCosts != 8400 || e.TotalAcquisition != 408400 || e.OwnFunds != 8400 {
+		t.Errorf("Unexpected totals: %d fees, costs %.2f, total %.2f, own funds %.2f", len(e.Fees), e.BuyerCosts, e.TotalAcquisition, e.OwnFunds)
+	}
+
+	tooOld := home
+	tooOld.BuyerAge = 35
+	if e, _ := Calculate(rulesFor(t, 2025), tooOld); e.TransferTax.Amount != 8000 || e.TransferTax.Exemption != "" {
+		t.Errorf("Expected 2%% for a 35-year-old, got %+v", e.TransferTax)
+	}
+	expensive := home
+	expensive.Price = 530000
+	if e, _ := Calculate(rulesFor(t, 2025), expensive); e.TransferTax.Amount != 10600 || e.NHG.Eligible {
+		t.Errorf("Expected 2%% above the 2025 starter limit and no NHG, got %+v / %+v", e.TransferTax, e.NHG)
+	}
+	// The 2026 starter limit is higher
+	if e, _ := Calculate(rulesFor(t, 2026), expensive); e.TransferTax.Exemption != "starter" {
+		t.Errorf("Expected the exemption under the 2026 limit, got %+v", e.TransferTax)
+	}
+}
+
+func TestCalculateRates(t *testing.T) {
+	investor := Purchase{Price: 300000, Residential: true}
+	if e, _ := Calculate(rulesFor(t, 2025), investor); e.TransferTax.Rate != 10.4 || e.NHG.Eligible {
+		t.Errorf("Expected 10.4%% and no NHG for an investor in 2025, got %+v / %+v", e.TransferTax, e.NHG)
+	}
+	if e, _ := Calculate(rulesFor(t, 2026), investor); e.TransferTax.Rate != 8 || e.TransferTax.Amount != 24000 {
+		t.Errorf("Expected 8%% for an investor in 2026, got %+v", e.TransferTax)
+	}
+	office := Purchase{Price: 300000, OwnerOccupied: true}
+	if e, _ := Calculate(rulesFor(t, 2026), office); e.TransferTax.Rate != 10.4 {
+		t.Errorf("Expected 10.4%% for non-residential property, got %+v", e.TransferTax)
+	}
+
+	cash := Purchase{Price: 300000, Residential: true, OwnerOccupied: true, Cash: true}
+	e, _ := Calculate(rulesFor(t, 2025), cash)
+	if e.Loan != 0 || e.NHG.Eligible || len(e.Fees) != 2 || e.OwnFunds != e.TotalAcquisition {
+		t.Errorf("Expected no mortgage fees or NHG for a cash buyer, got %+v", e)
+	}
+
+	if _, err := Calculate(rulesFor(t, 2025), Purchase{}); !errors.Is(err, ErrMissingPrice) {
+		t.Errorf("Expected ErrMissingPrice, got %v", err)
+	}
+}
+
+func TestMaxMortgage(t *testing.T) {
+	p := Purchase{Price: 500000, Residential: true, OwnerOccupied: true, Income: 60000, InterestRate: 4}
+	e, err := Calculate(rulesFor(t, 2025), p)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	m := e.Mortgage
+	if m == nil || m.Woonquote != 0.28 || m.MonthlyPayment != 1400 {
+		t.Fatalf("Expected a woonquote of 28%% for 60000 at 4%%, got %+v", m)
+	}
+	// 1400 a month over 30 years at 4% is worth about 293,245
+	if math.Abs(m.MaxByIncome-293245) > 5 || m.MaxMortgage != m.MaxByIncome || e.Loan != m.MaxMortgage {
+		t.Errorf("Unexpected maximum mortgage %+v, loan %.2f", m, e.Loan)
+	}
+	if e.OwnFunds < p.Price-e.Loan {
+		t.Errorf("Expected own funds to cover the shortfall, got %.2f", e.OwnFunds)
+	}
+
+	// A partner income raises the mortgage up to the loan-to-value limit
+	p.PartnerIncome = 80000
+	if e, _ := Calculate(rulesFor(t, 2025), p); e.Mortgage.MaxMortgage != 500000 || e.Mortgage.Income != 140000 {
+		t.Errorf("Expected the mortgage capped at the price, got %+v", e.Mortgage)
+	}
+}
+
+func TestRulesForYear(t *testing.T) {
+	set := BuiltinRules()
+	if r, _ := set.ForYear(2099); r.Year != 2026 {
+		t.Errorf("Expected a later year to use the latest rules, got %d", r.Year)
+	}
+	if r, _ := set.ForYear(0); r.Year > time.Now().Year() {
+		t.Errorf("Expected the current year's rules, got %d", r.Year)
+	}
+	if _, err := set.ForYear(2010); !errors.Is(err, ErrNoRules) {
+		t.Errorf("Expected ErrNoRules for 2010, got %v", err)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	raw, err := builtinRulesFS.ReadFile("rules/2026.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	next := strings.Replace(string(raw), `"year": 2026`, `"year": 2027`, 1)
+	next = strings.Replace(next, `"premiumRate": 0.4`, `"premiumRate": 0.5`, 1)
+	if err := os.WriteFile(filepath.Join(dir, "2027.json"), []byte(next), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadRules(dir)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if r, _ := set.ForYear(2027); r.Year != 2027 || r.NHG.PremiumRate != 0.5 {
+		t.Errorf("Expected the 2027 file to be used, got %d with premium %.1f", r.Year, r.NHG.PremiumRate)
+	}
+	if r, _ := set.ForYear(2024); r.Year != 2024 {
+		t.Errorf("Expected the built-in years to remain, got %d", r.Year)
+	}
+
+	invalid := map[string][]string{
+		"unknown field":  {strings.Replace(next, `"termYears"`, `"term"`, 1)},
+		"negative rate":  {strings.Replace(next, `"ownerOccupiedRate": 2.0`, `"ownerOccupiedRate": -2`, 1)},
+		"bracket order":  {strings.Replace(next, `{"incomeFrom": 25000, "ratio": 0.2}`, `{"incomeFrom": 0, "ratio": 0.2}`, 1)},
+		"duplicate year": {next, next},
+	}
+	for name, files := range invalid {
+		dir := t.TempDir()
+		for i, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d.json", i)), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := LoadRules(dir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := LoadRules(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without rules files")
+	}
+}
@@ -0,0 +1,160 @@
+package costs
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/yearfiles"
+)
+
+// ErrNoRules is returned for a year before the earliest rules on file
+var ErrNoRules = errors.New("no cost rules for year")
+
+// builtinRulesFS holds one rules file per tax year. Add the next year's file each
+// January; until then the latest year's rules are used.
+//
+//go:embed rules/*.json
+var builtinRulesFS embed.FS
+
+// Rules are the tax rates, limits, fees and mortgage norms of one year
+type Rules struct {
+	Year        int             `json:"year"`
+	TransferTax TransferTaxRule `json:"transferTax"`
+	NHG         NHGRule         `json:"nhg"`
+	Fees        []FeeRule       `json:"fees"`
+	Mortgage    MortgageRule    `json:"mortgage"`
+}
+
+// TransferTaxRule holds the overdrachtsbelasting rates in percent
+type TransferTaxRule struct {
+	OwnerOccupiedRate  float64              `json:"ownerOccupiedRate"`  // dwelling the buyer will live in
+	ResidentialRate    float64              `json:"residentialRate"`    // other dwellings, e.g. buy-to-let
+	NonResidentialRate float64              `json:"nonResidentialRate"` // offices, shops, land
+	StarterExemption   StarterExemptionRule `json:"starterExemption"`
+}
+
+// StarterExemptionRule sets who qualifies for the startersvrijstelling: a once-only
+// exemption for young buyers of a home they will live in
+type StarterExemptionRule struct {
+	MinAge     int     `json:"minAge"`
+	MaxAge     int     `json:"maxAge"`
+	PriceLimit float64 `json:"priceLimit"` // above this price the exemption does not apply at all
+}
+
+// NHGRule holds the Nationale Hypotheek Garantie cost limit and premium
+type NHGRule struct {
+	Limit             float64 `json:"limit"`
+	EnergySavingLimit float64 `json:"energySavingLimit"` // limit when energy-saving measures are financed
+	PremiumRate       float64 `json:"premiumRate"`       // percent of the loan
+}
+
+// FeeRule is a fixed purchase cost; mortgage-only fees are skipped for cash buyers
+type FeeRule struct {
+	Key          string  `json:"key"`
+	Label        string  `json:"label"`
+	Amount       float64 `json:"amount"`
+	MortgageOnly bool    `json:"mortgageOnly,omitempty"`
+}
+
+// MortgageRule sets the annuity test and the woonquote tables: the share of gross
+// income that may go to housing costs by interest rate and income
+type MortgageRule struct {
+	TermYears           int             `json:"termYears"`
+	DefaultInterestRate float64         `json:"defaultInterestRate"` // percent, when none is given
+	MaxLoanToValue      float64         `json:"maxLoanToValue"`      // 1 = 100% of the price
+	PartnerIncomeShare  float64         `json:"partnerIncomeShare"`  // share of the second income counted
+	Woonquotes          []WoonquoteBand `json:"woonquotes"`
+}
+
+// WoonquoteBand is the income table for interest rates up to RateUpTo percent
+type WoonquoteBand struct {
+	RateUpTo float64         `json:"rateUpTo"`
+	Brackets []IncomeBracket `json:"brackets"`
+}
+
+// IncomeBracket gives the woonquote for gross incomes from IncomeFrom upwards
+type IncomeBracket struct {
+	IncomeFrom float64 `json:"incomeFrom"`
+	Ratio      float64 `json:"ratio"`
+}
+
+// RuleSet is a validated, read-only set of rules by year
+type RuleSet = yearfiles.Set[Rules]
+
+var builtinRules = func() *RuleSet {
+	set, err := yearfiles.Load[Rules](builtinRulesFS, "rules", ErrNoRules)
+	if err != nil {
+		panic(fmt.Sprintf("costs: invalid built-in rules: %v", err))
+	}
+	return set
+}()
+
+// BuiltinRules returns the rules shipped with the calculator
+func BuiltinRules() *RuleSet {
+	return builtinRules
+}
+
+// LoadRules returns the built-in rules merged with the *.json files in dir, if set.
+// A file for a year that is built in replaces it.
+func LoadRules(dir string) (*RuleSet, error) {
+	if dir == "" {
+		return builtinRules, nil
+	}
+	set, err := yearfiles.Merge(builtinRules, os.DirFS(dir), ".")
+	if err != nil {
+		return nil, fmt.Errorf("invalid cost rules in %s: %w", dir, err)
+	}
+	return set, nil
+}
+
+// Validate checks that rates are percentages, limits and fees are positive and
+// the woonquote tables are ordered
+func (r *Rules) Validate() error {
+	if r.Year < 2000 || r.Year > 2100 {
+		return fmt.Errorf("invalid year %d", r.Year)
+	}
+	t := r.TransferTax
+	for _, rate := range []float64{t.OwnerOccupiedRate, t.ResidentialRate, t.NonResidentialRate, r.NHG.PremiumRate} {
+		if rate < 0 || rate > 100 {
+			return fmt.Errorf("%d: rates must be percentages within 0-100", r.Year)
+		}
+	}
+	if t.StarterExemption.MinAge <= 0 || t.StarterExemption.MaxAge < t.StarterExemption.MinAge || t.StarterExemption.PriceLimit <= 0 {
+		return fmt.Errorf("%d: starter exemption needs an age range and a price limit", r.Year)
+	}
+	if r.NHG.Limit <= 0 || r.NHG.EnergySavingLimit < r.NHG.Limit {
+		return fmt.Errorf("%d: NHG limit must be positive and at most the energy-saving limit", r.Year)
+	}
+	for _, f := range r.Fees {
+		if f.Key == "" || f.Amount < 0 {
+			return fmt.Errorf("%d: fees need a key and a non-negative amount", r.Year)
+		}
+	}
+
+	m := r.Mortgage
+	if m.TermYears <= 0 || m.DefaultInterestRate <= 0 || m.MaxLoanToValue <= 0 || m.PartnerIncomeShare < 0 || m.PartnerIncomeShare > 1 {
+		return fmt.Errorf("%d: mortgage needs a term, default interest rate, loan-to-value and a partner income share within 0-1", r.Year)
+	}
+	if len(m.Woonquotes) == 0 {
+		return fmt.Errorf("%d: no woonquote tables", r.Year)
+	}
+	for i, band := range m.Woonquotes {
+		if i > 0 && band.RateUpTo <= m.Woonquotes[i-1].RateUpTo {
+			return fmt.Errorf("%d: woonquote tables must be ordered by increasing rateUpTo", r.Year)
+		}
+		if len(band.Brackets) == 0 || band.Brackets[0].IncomeFrom != 0 {
+			return fmt.Errorf("%d: woonquote table up to %.2f%% must start at income 0", r.Year, band.RateUpTo)
+		}
+		for j, b := range band.Brackets {
+			if b.Ratio <= 0 || b.Ratio >= 1 {
+				return fmt.Errorf("%d: woonquotes must be within 0-1", r.Year)
+			}
+			if j > 0 && b.IncomeFrom <= band.Brackets[j-1].IncomeFrom {
+				return fmt.Errorf("%d: income brackets must be ordered by increasing incomeFrom", r.Year)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
+)
+
+// minSuggestQueryLength avoids querying the Locatieserver for single keystrokes
+const minSuggestQueryLength = 2
+
+// defaultSuggestLimit is the number of candidates returned without ?limit=
+const defaultSuggestLimit = 10
+
+// AddressSuggestResponse lists ranked address candidates for a query
+type AddressSuggestResponse struct {
+	Query       string                     `json:"query"`
+	Suggestions []models.AddressSuggestion `json:"suggestions"`
+}
+
+// HandleAddressSuggest returns address candidates for autocomplete
+// GET /api/address/suggest?q=<text>&limit=<n>
+func (h *SearchHandler) HandleAddressSuggest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if len([]rune(query)) < minSuggestQueryLength {
+		respondWithError(w, http.StatusBadRequest, "q must be at least 2 characters")
+		return
+	}
+
+	limit := defaultSuggestLimit
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > apiclient.MaxSuggestions {
+			respondWithError(w, http.StatusBadRequest, "limit must be between 1 and 25")
+			return
+		}
+		limit = n
+	}
+
+	suggestions, err := h.aggregator.SuggestAddresses(r.Context(), query, limit)
+	if err != nil {
+		logutil.Errorf("Error fetching address suggestions: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to fetch address suggestions")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, AddressSuggestResponse{
+		Query:       query,
+		Suggestions: suggestions,
+	})
+}
+
+// addressFromQuery reads the address of a request: either a Locatieserver ?id= from
+// /api/address/suggest or ?postcode= and ?houseNumber=. It writes an error response
+// and returns false if neither is usable.
+func addressFromQuery(w http.ResponseWriter, r *http.Request, agg *aggregator.PropertyAggregator) (postcode, houseNumber string, ok bool) {
+	if id := strings.TrimSpace(r.URL.Query().Get("id")); id != "" {
+		return resolveAddressID(w, r, agg, id)
+	}
+
+	postcode = r.URL.Query().Get("postcode")
+	houseNumber = r.URL.Query().Get("houseNumber")
+	if postcode == "" || houseNumber == "" {
+		respondWithError(w, http.StatusBadRequest, "missing id or postcode and houseNumber query parameters")
+		return "", "", false
+	}
+	return parseAddress(w, postcode, houseNumber)
+}
+
+// parseAddress validates a postcode and house number, returning them in canonical
+// form ("12 a" becomes "12A"). It writes a 400 response and returns false if invalid.
+func parseAddress(w http.ResponseWriter, postcode, houseNumber string) (string, string, bool) {
+	addr, err := utils.ParseDutchAddress(postcode, houseNumber)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return "", "", false
+	}
+	return addr.Postcode, addr.HouseNumberString(), true
+}
+
+// AmbiguousAddressResponse lists the units at a house number that did not pick out one
+type AmbiguousAddressResponse struct {
+	Error      string                     `json:"error"`
+	Address    string                     `json:"address"`
+	Candidates []models.AddressSuggestion `json:"candidates"`
+}
+
+// respondWithAddressError writes the response for a failed address lookup: 300 with
+// the candidate units for an ambiguous address, 404 if not found, 500 otherwise
+func respondWithAddressError(w http.ResponseWriter, err error, message string) {
+	var ambiguous *apiclient.AmbiguousAddressError
+	switch {
+	case errors.As(err, &ambiguous):
+		respondWithJSON(w, http.StatusMultipleChoices, AmbiguousAddressResponse{
+			Error:      "ambiguous address, choose one of the candidates",
+			Address:    ambiguous.Address,
+			Candidates: ambiguous.Candidates,
+		})
+	case errors.Is(err, apiclient.ErrAddressNotFound):
+		respondWithError(w, http.StatusNotFound, "address not found")
+	default:
+		respondWithError(w, http.StatusInternalServerError, message)
+	}
+}
+
+// resolveAddressID looks up a Locatieserver address ID, writing an error response
+// and returning false if it cannot be resolved
+func resolveAddressID(w http.ResponseWriter, r *http.Request, agg *aggregator.PropertyAggregator, id string) (postcode, houseNumber string, ok bool) {
+	bagData, err := agg.ResolveAddressID(r.Context(), id)
+	switch {
+	case errors.Is(err, apiclient.ErrAddressNotFound):
+		respondWithError(w, http.StatusNotFound, "address not found")
+		return "", "", false
+	case err != nil:
+		logutil.Errorf("Error resolving address id %s: %v", id, err)
+		respondWithError(w, http.StatusInternalServerError, "failed to resolve address id")
+		return "", "", false
+	case bagData.Postcode == "" || bagData.HouseNumber == "":
+		respondWithError(w, http.StatusNotFound, "address not found")
+		return "", "", false
+	}
+	return bagData.Postcode, bagData.HouseNumber, true
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+)
+
+// AreaResponse is the analysis of a buurt, wijk or postcode-4 area with its scores
+type AreaResponse struct {
+	Analysis *aggregator.AreaAnalysis `json:"analysis"`
+	Scores   *scoring.AreaScores      `json:"scores"`
+}
+
+// HandleGetArea analyses a neighbourhood as a whole
+// GET /api/area/{code} with a CBS buurtcode (BU...), wijkcode (WK...) or 4-digit postcode
+func (h *PropertyHandler) HandleGetArea(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	code := r.PathValue("code")
+	logutil.Infof("Analysing area %s", code)
+
+	analysis, err := h.aggregator.AggregateArea(r.Context(), code)
+	switch {
+	case errors.Is(err, apiclient.ErrInvalidAreaCode):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, apiclient.ErrAreaNotFound):
+		respondWithError(w, http.StatusNotFound, "area not found")
+		return
+	case err != nil:
+		logutil.Errorf("Error analysing area %s: %v", code, err)
+		respondWithError(w, http.StatusInternalServerError, "failed to analyse area")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, AreaResponse{
+		Analysis: analysis,
+		Scores:   h.scoringEngine.CalculateAreaScores(analysis),
+	})
+}
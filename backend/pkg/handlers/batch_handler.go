@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/batch"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+)
+
+// maxBatchBodyBytes caps the size of uploaded batch files
+const maxBatchBodyBytes = 5 << 20
+
+// BatchHandler handles batch address analysis jobs
+type BatchHandler struct {
+	manager *batch.Manager
+}
+
+// NewBatchHandler creates a new batch handler
+func NewBatchHandler(manager *batch.Manager) *BatchHandler {
+	return &BatchHandler{manager: manager}
+}
+
+// BatchJobResponse is returned when a job is created or polled
+type BatchJobResponse struct {
+	Job        *batch.JobStatus `json:"job"`
+	StatusURL  string           `json:"statusUrl"`
+	ResultsURL string           `json:"resultsUrl"`
+}
+
+// BatchResultsResponse is the JSON download of a job's results
+type BatchResultsResponse struct {
+	Job     *batch.JobStatus `json:"job"`
+	Results []batch.Result   `json:"results"`
+}
+
+// HandleCreateBatch queues a batch job from a JSON or CSV list of addresses
+// POST /api/batch
+func (h *BatchHandler) HandleCreateBatch(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/batch" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed, use POST")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)
+
+	addresses, err := parseBatchBody(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := h.manager.Submit(addresses)
+	if err != nil {
+		if errors.Is(err, batch.ErrQueueFull) {
+			respondWithError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logutil.Infof("Created batch job %s with %d addresses", job.ID, job.Total)
+	respondWithJSON(w, http.StatusAccepted, newBatchJobResponse(job))
+}
+
+// HandleGetBatch returns the status and progress of a batch job
+// GET /api/batch/{id}
+func (h *BatchHandler) HandleGetBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed, use GET")
+		return
+	}
+
+	job, ok := h.manager.Status(r.PathValue("id"))
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "batch job not found")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, newBatchJobResponse(job))
+}
+
+// HandleGetBatchResults downloads the results of a batch job as JSON (default) or CSV
+// GET /api/batch/{id}/results?format=csv
+func (h *BatchHandler) HandleGetBatchResults(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed, use GET")
+		return
+	}
+
+	job, results, ok := h.manager.Results(r.PathValue("id"))
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "batch job not found")
+		return
+	}
+
+	if strings.EqualFold(r.URL.Query().Get("format"), "csv") {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="batch-%s.csv"`, job.ID))
+		w.WriteHeader(http.StatusOK)
+		if err := batch.WriteCSV(w, results); err != nil {
+			logutil.Errorf("Error writing batch CSV for job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="batch-%s.json"`, job.ID))
+	respondWithJSON(w, http.StatusOK, BatchResultsResponse{
+		Job:     job,
+		Results: results,
+	})
+}
+
+// parseBatchBody reads addresses from a CSV body, a multipart "file" upload or JSON
+func parseBatchBody(r *http.Request) ([]batch.Address, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "text/csv", "application/csv":
+		return batch.ParseCSV(r.Body)
+	case "multipart/form-data":
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing file upload field 'file'")
+		}
+		defer file.Close()
+		if strings.HasSuffix(strings.ToLower(header.Filename), ".json") {
+			return batch.ParseJSON(file)
+		}
+		return batch.ParseCSV(file)
+	default:
+		return batch.ParseJSON(r.Body)
+	}
+}
+
+func newBatchJobResponse(job *batch.JobStatus) BatchJobResponse {
+	return BatchJobResponse{
+		Job:        job,
+		StatusURL:  "/api/batch/" + job.ID,
+		ResultsURL: "/api/batch/" + job.ID + "/results",
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
+)
+
+// Number of addresses a comparison accepts
+const (
+	minCompareAddresses = 2
+	maxCompareAddresses = 5
+)
+
+// maxCompareBodyBytes caps the size of a comparison request
+const maxCompareBodyBytes = 64 << 10
+
+// CompareRequest lists the properties to compare
+type CompareRequest struct {
+	Addresses []CompareAddress `json:"addresses"`
+}
+
+// CompareAddress identifies a property by postcode and house number, or by a
+// Locatieserver id from /api/address/suggest
+type CompareAddress struct {
+	ID          string `json:"id,omitempty"`
+	Postcode    string `json:"postcode,omitempty"`
+	HouseNumber string `json:"houseNumber,omitempty"`
+}
+
+// ComparedProperty is the outcome for one address in a comparison
+type ComparedProperty struct {
+	Index       int                        `json:"index"`
+	Postcode    string                     `json:"postcode,omitempty"`
+	HouseNumber string                     `json:"houseNumber,omitempty"`
+	Address     string                     `json:"address,omitempty"`
+	Coordinates [2]float64                 `json:"coordinates"`
+	Scores      *scoring.PropertyScores    `json:"scores,omitempty"`
+	Error       string                     `json:"error,omitempty"`
+	Candidates  []models.AddressSuggestion `json:"candidates,omitempty"` // for an ambiguous address
+}
+
+// CompareResponse holds the compared properties and the metric matrix aligned with them
+type CompareResponse struct {
+	Properties []ComparedProperty  `json:"properties"`
+	Comparison *scoring.Comparison `json:"comparison"`
+}
+
+// HandleCompare aggregates and scores 2-5 properties in parallel and compares them
+// metric by metric
+// POST /api/compare, optionally ?profile=<scoring profile>
+func (h *PropertyHandler) HandleCompare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed, use POST")
+		return
+	}
+
+	profile, ok := h.profileFromQuery(w, r)
+	if !ok {
+		return
+	}
+
+	var req CompareRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCompareBodyBytes)).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if n := len(req.Addresses); n < minCompareAddresses || n > maxCompareAddresses {
+		respondWithError(w, http.StatusBadRequest, fmt.Sprintf("compare between %d and %d addresses, got %d", minCompareAddresses, maxCompareAddresses, n))
+		return
+	}
+
+	// Validate every address up front so a typo fails the request rather than one column
+	properties := make([]ComparedProperty, len(req.Addresses))
+	seen := make(map[string]int)
+	for i, a := range req.Addresses {
+		properties[i].Index = i
+		key := strings.TrimSpace(a.ID)
+		if key == "" {
+			addr, err := utils.ParseDutchAddress(a.Postcode, a.HouseNumber)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, fmt.Sprintf("address %d: %v", i, err))
+				return
+			}
+			properties[i].Postcode, properties[i].HouseNumber = addr.Postcode, addr.HouseNumberString()
+			key = addr.String()
+		} else if !apiclient.ValidLocatieserverID(key) {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("address %d: invalid id %q", i, key))
+			return
+		}
+		if j, dup := seen[key]; dup {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("addresses %d and %d are the same", j, i))
+			return
+		}
+		seen[key] = i
+	}
+
+	logutil.Infof("Comparing %d properties", len(properties))
+
+	data := make([]*aggregator.ComprehensivePropertyData, len(properties))
+	scores := make([]*scoring.PropertyScores, len(properties))
+	var wg sync.WaitGroup
+	for i := range properties {
+		wg.Add(1)
+		go func(p *ComparedProperty, id string) {
+			defer wg.Done()
+			d, err := h.compareProperty(r, p, id)
+			if err != nil {
+				logutil.Errorf("Error aggregating compared property %d: %v", p.Index, err)
+				p.Error = "failed to aggregate property data"
+				var ambiguous *apiclient.AmbiguousAddressError
+				switch {
+				case errors.As(err, &ambiguous):
+					p.Error, p.Candidates = "ambiguous address, choose one of the candidates", ambiguous.Candidates
+				case errors.Is(err, apiclient.ErrAddressNotFound):
+					p.Error = "address not found"
+				}
+				return
+			}
+			p.Address, p.Coordinates = d.Address, d.Coordinates
+			data[p.Index] = d
+			scores[p.Index] = h.scoringEngine.CalculateScoresWithProfile(d, profile)
+			p.Scores = scores[p.Index]
+		}(&properties[i], strings.TrimSpace(req.Addresses[i].ID))
+	}
+	wg.Wait()
+
+	respondWithJSON(w, http.StatusOK, CompareResponse{
+		Properties: properties,
+		Comparison: h.scoringEngine.Compare(data, scores),
+	})
+}
+
+// compareProperty resolves a compared address (by id if set) and aggregates it
+func (h *PropertyHandler) compareProperty(r *http.Request, p *ComparedProperty, id string) (*aggregator.ComprehensivePropertyData, error) {
+	if id != "" {
+		bagData, err := h.aggregator.ResolveAddressID(r.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		if bagData.Postcode == "" || bagData.HouseNumber == "" {
+			return nil, fmt.Errorf("%w: %s", apiclient.ErrAddressNotFound, id)
+		}
+		p.Postcode, p.HouseNumber = bagData.Postcode, bagData.HouseNumber
+	}
+	return h.aggregator.AggregatePropertyData(r.Context(), p.Postcode, p.HouseNumber)
+}
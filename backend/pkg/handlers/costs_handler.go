@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/costs"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+)
+
+// PurchaseCostsResponse is the cost estimate of buying a property
+type PurchaseCostsResponse struct {
+	Postcode    string          `json:"postcode,omitempty"`
+	HouseNumber string          `json:"houseNumber,omitempty"`
+	Estimate    *costs.Estimate `json:"estimate"`
+}
+
+// HandleGetPurchaseCosts estimates the transfer tax, fees, NHG premium and maximum
+// mortgage of a purchase. The price is ?price= or the property's market or WOZ
+// value; without an address ?price= is required.
+// GET /api/property/costs
+func (h *PropertyHandler) HandleGetPurchaseCosts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	p := costs.Purchase{Residential: true, OwnerOccupied: true}
+	residentialSet, err := applyPurchaseParams(&p, q)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	year, err := queryYear(q)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rules, err := h.costRules.ForYear(year)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var resp PurchaseCostsResponse
+	hasAddress := q.Get("id") != "" || q.Get("postcode") != "" || q.Get("houseNumber") != ""
+	if hasAddress || p.Price == 0 {
+		postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+		if !ok {
+			return
+		}
+		resp.Postcode, resp.HouseNumber = postcode, houseNumber
+
+		logutil.Infof("Estimating purchase costs for %s %s", postcode, houseNumber)
+
+		data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
+		if err != nil {
+			logutil.Errorf("Error aggregating property data for purchase costs: %v", err)
+			respondWithAddressError(w, err, "failed to aggregate property data")
+			return
+		}
+		data = data.Trusted()
+
+		if p.Price == 0 {
+			switch {
+			case data.MarketValuation != nil && data.MarketValuation.MarketValue > 0:
+				p.Price, p.PriceSource = data.MarketValuation.MarketValue, "market"
+			case data.WOZData != nil && data.WOZData.WOZValue > 0:
+				p.Price, p.PriceSource = data.WOZData.WOZValue, "woz"
+			}
+		}
+		if !residentialSet && data.BAGBuilding != nil && len(data.BAGBuilding.UsageFunctions) > 0 {
+			p.Residential = slices.Contains(data.BAGBuilding.UsageFunctions, "woonfunctie")
+		}
+	}
+
+	estimate, err := costs.Calculate(rules, p)
+	if errors.Is(err, costs.ErrMissingPrice) {
+		respondWithError(w, http.StatusBadRequest, "no market or WOZ value for this address, pass price")
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to estimate purchase costs")
+		return
+	}
+	resp.Estimate = estimate
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// applyPurchaseParams sets the purchase and buyer details given as query
+// parameters. It reports whether ?residential= was given.
+func applyPurchaseParams(p *costs.Purchase, q url.Values) (bool, error) {
+	amounts := []struct {
+		name string
+		dst  *float64
+	}{
+		{"price", &p.Price},
+		{"mortgage", &p.Loan},
+		{"income", &p.Income},
+		{"partnerIncome", &p.PartnerIncome},
+		{"interestRate", &p.InterestRate},
+	}
+	for _, a := range amounts {
+		if v, ok, err := nonNegativeFloat(q, a.name); err != nil {
+			return false, err
+		} else if ok {
+			*a.dst = v
+		}
+	}
+	if p.Price > 0 {
+		p.PriceSource = "input"
+	}
+	// An explicit mortgage=0 is a cash purchase
+	p.Cash = strings.TrimSpace(q.Get("mortgage")) != "" && p.Loan == 0
+	if p.InterestRate > 20 {
+		return false, fmt.Errorf("invalid interestRate: give a percentage such as 4.1")
+	}
+
+	residentialSet := false
+	if v, ok, err := queryBool(q, "residential"); err != nil {
+		return false, err
+	} else if ok {
+		p.Residential, residentialSet = v, true
+	}
+	flags := []struct {
+		name string
+		dst  *bool
+	}{
+		{"ownerOccupied", &p.OwnerOccupied},
+		{"starter", &p.Starter},
+		{"energySaving", &p.EnergySaving},
+	}
+	for _, f := range flags {
+		if v, ok, err := queryBool(q, f.name); err != nil {
+			return false, err
+		} else if ok {
+			*f.dst = v
+		}
+	}
+
+	if raw := strings.TrimSpace(q.Get("age")); raw != "" {
+		age, err := strconv.Atoi(raw)
+		if err != nil || age < 0 || age > 120 {
+			return false, fmt.Errorf("invalid age")
+		}
+		p.BuyerAge = age
+	}
+	if p.Starter && p.BuyerAge == 0 {
+		return false, fmt.Errorf("age is required with starter=true")
+	}
+	return residentialSet, nil
+}
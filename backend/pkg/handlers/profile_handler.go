@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
+)
+
+// ScoringProfilesResponse lists the scoring profiles and names the default
+type ScoringProfilesResponse struct {
+	Default  string                    `json:"default"`
+	Profiles []*scoring.ScoringProfile `json:"profiles"`
+}
+
+// HandleListProfiles lists the scoring profiles selectable with ?profile=
+// GET /api/scoring/profiles
+func (h *PropertyHandler) HandleListProfiles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	profiles := h.scoringEngine.Profiles()
+	respondWithJSON(w, http.StatusOK, ScoringProfilesResponse{
+		Default:  profiles.Default().Name,
+		Profiles: profiles.List(),
+	})
+}
+
+// profileFromQuery returns the scoring profile named by ?profile=, or the default.
+// It writes a 400 response and returns false for an unknown profile.
+func (h *PropertyHandler) profileFromQuery(w http.ResponseWriter, r *http.Request) (*scoring.ScoringProfile, bool) {
+	profile, err := h.scoringEngine.Profiles().Get(strings.TrimSpace(r.URL.Query().Get("profile")))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return profile, true
+}
@@ -2,12 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
 	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
+	"github.com/iman-hussain/nethaddress/backend/pkg/costs"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
 	"github.com/iman-hussain/nethaddress/backend/pkg/scoring"
 )
@@ -16,6 +20,7 @@ import (
 type PropertyHandler struct {
 	aggregator    *aggregator.PropertyAggregator
 	scoringEngine *scoring.EnhancedScoringEngine
+	costRules     *costs.RuleSet
 	apiClient     *apiclient.ApiClient
 	config        *config.Config
 }
@@ -24,12 +29,14 @@ type PropertyHandler struct {
 func NewPropertyHandler(
 	agg *aggregator.PropertyAggregator,
 	scoringEngine *scoring.EnhancedScoringEngine,
+	costRules *costs.RuleSet,
 	apiClient *apiclient.ApiClient,
 	cfg *config.Config,
 ) *PropertyHandler {
 	return &PropertyHandler{
 		aggregator:    agg,
 		scoringEngine: scoringEngine,
+		costRules:     costRules,
 		apiClient:     apiClient,
 		config:        cfg,
 	}
@@ -60,11 +67,8 @@ type RecommendationsResponse struct {
 // HandleGetPropertyData returns comprehensive aggregated property data
 // GET /api/property/:postcode/:houseNumber
 func (h *PropertyHandler) HandleGetPropertyData(w http.ResponseWriter, r *http.Request) {
-	postcode := r.URL.Query().Get("postcode")
-	houseNumber := r.URL.Query().Get("houseNumber")
-
-	if postcode == "" || houseNumber == "" {
-		respondWithError(w, http.StatusBadRequest, "missing postcode or houseNumber query parameters")
+	postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+	if !ok {
 		return
 	}
 
@@ -73,7 +77,7 @@ func (h *PropertyHandler) HandleGetPropertyData(w http.ResponseWriter, r *http.R
 	data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
 	if err != nil {
 		logutil.Errorf("Error aggregating property data: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to aggregate property data")
+		respondWithAddressError(w, err, "failed to aggregate property data")
 		return
 	}
 
@@ -85,11 +89,12 @@ func (h *PropertyHandler) HandleGetPropertyData(w http.ResponseWriter, r *http.R
 // HandleGetPropertyScores returns comprehensive property scores
 // GET /api/property/:postcode/:houseNumber/scores
 func (h *PropertyHandler) HandleGetPropertyScores(w http.ResponseWriter, r *http.Request) {
-	postcode := r.URL.Query().Get("postcode")
-	houseNumber := r.URL.Query().Get("houseNumber")
-
-	if postcode == "" || houseNumber == "" {
-		respondWithError(w, http.StatusBadRequest, "missing postcode or houseNumber query parameters")
+	postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+	if !ok {
+		return
+	}
+	profile, ok := h.profileFromQuery(w, r)
+	if !ok {
 		return
 	}
 
@@ -99,12 +104,12 @@ func (h *PropertyHandler) HandleGetPropertyScores(w http.ResponseWriter, r *http
 	data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
 	if err != nil {
 		logutil.Errorf("Error aggregating property data for scoring: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to aggregate property data")
+		respondWithAddressError(w, err, "failed to aggregate property data")
 		return
 	}
 
 	// Calculate scores
-	scores := h.scoringEngine.CalculateComprehensiveScores(data)
+	scores := h.scoringEngine.CalculateScoresWithProfile(data, profile)
 
 	respondWithJSON(w, http.StatusOK, PropertyScoresResponse{
 		Postcode:    postcode,
@@ -116,11 +121,12 @@ func (h *PropertyHandler) HandleGetPropertyScores(w http.ResponseWriter, r *http
 // HandleGetRecommendations returns smart recommendations for a property
 // GET /api/property/:postcode/:houseNumber/recommendations
 func (h *PropertyHandler) HandleGetRecommendations(w http.ResponseWriter, r *http.Request) {
-	postcode := r.URL.Query().Get("postcode")
-	houseNumber := r.URL.Query().Get("houseNumber")
-
-	if postcode == "" || houseNumber == "" {
-		respondWithError(w, http.StatusBadRequest, "missing postcode or houseNumber query parameters")
+	postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+	if !ok {
+		return
+	}
+	profile, ok := h.profileFromQuery(w, r)
+	if !ok {
 		return
 	}
 
@@ -130,12 +136,12 @@ func (h *PropertyHandler) HandleGetRecommendations(w http.ResponseWriter, r *htt
 	data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
 	if err != nil {
 		logutil.Errorf("Error aggregating property data for recommendations: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to aggregate property data")
+		respondWithAddressError(w, err, "failed to aggregate property data")
 		return
 	}
 
 	// Calculate scores (includes recommendations)
-	scores := h.scoringEngine.CalculateComprehensiveScores(data)
+	scores := h.scoringEngine.CalculateScoresWithProfile(data, profile)
 
 	respondWithJSON(w, http.StatusOK, RecommendationsResponse{
 		Postcode:        postcode,
@@ -147,11 +153,12 @@ func (h *PropertyHandler) HandleGetRecommendations(w http.ResponseWriter, r *htt
 // HandleGetFullAnalysis returns everything - data, scores, and recommendations
 // GET /api/property/:postcode/:houseNumber/analysis
 func (h *PropertyHandler) HandleGetFullAnalysis(w http.ResponseWriter, r *http.Request) {
-	postcode := r.URL.Query().Get("postcode")
-	houseNumber := r.URL.Query().Get("houseNumber")
-
-	if postcode == "" || houseNumber == "" {
-		respondWithError(w, http.StatusBadRequest, "missing postcode or houseNumber query parameters")
+	postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+	if !ok {
+		return
+	}
+	profile, ok := h.profileFromQuery(w, r)
+	if !ok {
 		return
 	}
 
@@ -161,12 +168,12 @@ func (h *PropertyHandler) HandleGetFullAnalysis(w http.ResponseWriter, r *http.R
 	data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
 	if err != nil {
 		logutil.Errorf("Error aggregating property data for analysis: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to aggregate property data")
+		respondWithAddressError(w, err, "failed to aggregate property data")
 		return
 	}
 
 	// Calculate scores
-	scores := h.scoringEngine.CalculateComprehensiveScores(data)
+	scores := h.scoringEngine.CalculateScoresWithProfile(data, profile)
 
 	// Combine everything
 	response := map[string]interface{}{
@@ -179,6 +186,74 @@ func (h *PropertyHandler) HandleGetFullAnalysis(w http.ResponseWriter, r *http.R
 	respondWithJSON(w, http.StatusOK, response)
 }
 
+// maxReverseGeocodeDistance is how far (in metres) the nearest address may be from a
+// point for the point to be analysed as that address
+const maxReverseGeocodeDistance = 50.0
+
+// PropertyAtResponse is the analysis of a map location: the nearest BAG address, or
+// the bare location when no address is close
+type PropertyAtResponse struct {
+	Lat          float64                               `json:"lat"`
+	Lon          float64                               `json:"lon"`
+	Postcode     string                                `json:"postcode,omitempty"`
+	HouseNumber  string                                `json:"houseNumber,omitempty"`
+	Distance     float64                               `json:"distance,omitempty"` // metres from the point to the address
+	LocationOnly bool                                  `json:"locationOnly"`
+	Property     *aggregator.ComprehensivePropertyData `json:"property"`
+}
+
+// HandleGetPropertyAt analyses the address nearest to a point, falling back to the
+// coordinate-based sources when no address lies within 50 m
+// GET /api/property/at?lat=<lat>&lon=<lon>
+func (h *PropertyHandler) HandleGetPropertyAt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	lat, latErr := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	lon, lonErr := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+	if latErr != nil || lonErr != nil || math.IsNaN(lat) || math.IsNaN(lon) || math.IsInf(lat, 0) || math.IsInf(lon, 0) {
+		respondWithError(w, http.StatusBadRequest, "missing or invalid lat and lon query parameters")
+		return
+	}
+	// Rough bounding box of the Netherlands, the coverage of the BAG and most sources
+	if lat < 50.7 || lat > 53.7 || lon < 3.2 || lon > 7.3 {
+		respondWithError(w, http.StatusBadRequest, "coordinates outside the Netherlands")
+		return
+	}
+
+	response := PropertyAtResponse{Lat: lat, Lon: lon}
+
+	bagData, distance, err := h.apiClient.ReverseGeocode(r.Context(), lat, lon)
+	switch {
+	case err == nil && distance <= maxReverseGeocodeDistance && bagData.Postcode != "" && bagData.HouseNumber != "":
+		logutil.Infof("Analysing %s, %.0fm from (%f, %f)", bagData.Address, distance, lat, lon)
+		response.Postcode, response.HouseNumber, response.Distance = bagData.Postcode, bagData.HouseNumber, distance
+		response.Property, err = h.aggregator.AggregatePropertyData(r.Context(), bagData.Postcode, bagData.HouseNumber)
+		if err != nil {
+			logutil.Errorf("Error aggregating property data at (%f, %f): %v", lat, lon, err)
+			respondWithAddressError(w, err, "failed to aggregate property data")
+			return
+		}
+	case err != nil && !errors.Is(err, apiclient.ErrAddressNotFound):
+		logutil.Errorf("Error reverse geocoding (%f, %f): %v", lat, lon, err)
+		respondWithError(w, http.StatusInternalServerError, "failed to reverse geocode location")
+		return
+	default:
+		logutil.Infof("No address near (%f, %f); running location-only analysis", lat, lon)
+		response.LocationOnly = true
+		response.Property, err = h.aggregator.AggregateLocation(r.Context(), lat, lon)
+		if err != nil {
+			logutil.Errorf("Error aggregating location data at (%f, %f): %v", lat, lon, err)
+			respondWithError(w, http.StatusInternalServerError, "failed to aggregate location data")
+			return
+		}
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
 // Utility functions
 
 func respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/wws"
+)
+
+// RentValuationResponse is the WWS rental valuation of a property
+type RentValuationResponse struct {
+	Postcode    string         `json:"postcode"`
+	HouseNumber string         `json:"houseNumber"`
+	Valuation   *wws.Valuation `json:"valuation"`
+}
+
+// HandleGetRentValuation scores a property on the woningwaarderingsstelsel and
+// returns its maximum rent, segment and rental yield. Query parameters override
+// the inputs taken from the aggregated data and supply the facilities.
+// GET /api/property/rent
+func (h *PropertyHandler) HandleGetRentValuation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+	if !ok {
+		return
+	}
+
+	// Validate the overrides before the (slow) aggregation
+	in := wws.Input{}
+	if err := applyRentOverrides(&in, r.URL.Query()); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logutil.Infof("Calculating WWS rental valuation for %s %s", postcode, houseNumber)
+
+	data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
+	if err != nil {
+		logutil.Errorf("Error aggregating property data for rental valuation: %v", err)
+		respondWithAddressError(w, err, "failed to aggregate property data")
+		return
+	}
+
+	in = wws.FromProperty(data)
+	_ = applyRentOverrides(&in, r.URL.Query()) // validated above
+
+	valuation, err := wws.Calculate(in)
+	if errors.Is(err, wws.ErrMissingFloorArea) {
+		respondWithError(w, http.StatusBadRequest, "floor area unknown for this address, pass floorArea")
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "failed to calculate rental valuation")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, RentValuationResponse{
+		Postcode:    postcode,
+		HouseNumber: houseNumber,
+		Valuation:   valuation,
+	})
+}
+
+// applyRentOverrides sets the WWS inputs given as query parameters
+func applyRentOverrides(in *wws.Input, q url.Values) error {
+	floats := []struct {
+		name string
+		dst  *float64
+	}{
+		{"floorArea", &in.FloorArea},
+		{"otherArea", &in.OtherArea},
+		{"woz", &in.WOZValue},
+		{"marketValue", &in.MarketValue},
+	}
+	for _, f := range floats {
+		if v, ok, err := nonNegativeFloat(q, f.name); err != nil {
+			return err
+		} else if ok {
+			*f.dst = v
+		}
+	}
+
+	optionalFloats := []struct {
+		name string
+		dst  **float64
+	}{
+		{"kitchenLength", &in.KitchenLength},
+		{"outdoorArea", &in.OutdoorArea},
+	}
+	for _, f := range optionalFloats {
+		if v, ok, err := nonNegativeFloat(q, f.name); err != nil {
+			return err
+		} else if ok {
+			*f.dst = &v
+		}
+	}
+
+	counts := []struct {
+		name string
+		dst  **int
+	}{
+		{"heatedRooms", &in.HeatedRooms},
+		{"toilets", &in.Toilets},
+		{"washbasins", &in.Washbasins},
+		{"showers", &in.Showers},
+		{"baths", &in.Baths},
+	}
+	for _, c := range counts {
+		raw := strings.TrimSpace(q.Get(c.name))
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid %s: must be a non-negative whole number", c.name)
+		}
+		*c.dst = &n
+	}
+
+	if raw := strings.TrimSpace(q.Get("constructionYear")); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 1000 || year > 2100 {
+			return fmt.Errorf("invalid constructionYear")
+		}
+		in.ConstructionYear = year
+	}
+	if label := strings.TrimSpace(q.Get("energyLabel")); label != "" {
+		in.EnergyLabel = label
+	}
+
+	bools := []struct {
+		name string
+		dst  *bool
+	}{
+		{"multiFamily", &in.MultiFamily},
+		{"monument", &in.Monument},
+	}
+	for _, b := range bools {
+		if v, ok, err := queryBool(q, b.name); err != nil {
+			return err
+		} else if ok {
+			*b.dst = v
+		}
+	}
+	return nil
+}
+
+// queryBool parses an optional true/false query parameter
+func queryBool(q url.Values, name string) (bool, bool, error) {
+	raw := strings.TrimSpace(q.Get(name))
+	if raw == "" {
+		return false, false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, false, fmt.Errorf("invalid %s: must be true or false", name)
+	}
+	return v, true, nil
+}
+
+// queryYear parses the optional year query parameter; 0 means the current year
+func queryYear(q url.Values) (int, error) {
+	raw := strings.TrimSpace(q.Get("year"))
+	if raw == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid year")
+	}
+	return year, nil
+}
+
+// nonNegativeFloat parses an optional non-negative number query parameter
+func nonNegativeFloat(q url.Values, name string) (float64, bool, error) {
+	raw := strings.TrimSpace(q.Get(name))
+	if raw == "" {
+		return 0, false, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, false, fmt.Errorf("invalid %s: must be a non-negative number", name)
+	}
+	return v, true, nil
+}
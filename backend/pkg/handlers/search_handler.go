@@ -11,7 +11,6 @@ import (
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
 	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
-	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
 	"github.com/iman-hussain/nethaddress/backend/pkg/config"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
 	"github.com/iman-hussain/nethaddress/backend/pkg/models"
@@ -24,20 +23,8 @@ type SearchHandler struct {
 	aggregator *aggregator.PropertyAggregator
 }
 
-// NewSearchHandler creates a new search handler
-func NewSearchHandler(apiClient *apiclient.ApiClient, cfg *config.Config) *SearchHandler {
-	// Try to create cache service, but don't fail if Redis is unavailable
-	var cacheService *cache.CacheService
-	if cfg.RedisURL != "" {
-		cs, err := cache.NewCacheService(cfg.RedisURL)
-		if err != nil {
-			logutil.Warnf("Failed to initialize cache: %v", err)
-		} else {
-			cacheService = cs
-		}
-	}
-
-	agg := aggregator.NewPropertyAggregator(apiClient, cacheService, cfg)
+// NewSearchHandler creates a new search handler using the shared aggregator
+func NewSearchHandler(agg *aggregator.PropertyAggregator, apiClient *apiclient.ApiClient, cfg *config.Config) *SearchHandler {
 	return &SearchHandler{
 		apiClient:  apiClient,
 		config:     cfg,
@@ -47,11 +34,12 @@ func NewSearchHandler(apiClient *apiclient.ApiClient, cfg *config.Config) *Searc
 
 // APIResult represents the result of a single API call (success or error)
 type APIResult struct {
-	Name     string      `json:"name"`
-	Status   string      `json:"status"` // "success", "error", "not_configured"
-	Data     interface{} `json:"data,omitempty"`
-	Error    string      `json:"error,omitempty"`
-	Category string      `json:"category"` // "free", "freemium", "premium"
+	Name       string                 `json:"name"`
+	Status     string                 `json:"status"` // "success", "error", "not_configured", "circuit_open"
+	Data       interface{}            `json:"data,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Category   string                 `json:"category"` // "free", "freemium", "premium"
+	Provenance *aggregator.SourceMeta `json:"provenance,omitempty"`
 }
 
 // APIResultsGrouped represents grouped API results by category
@@ -63,16 +51,17 @@ type APIResultsGrouped struct {
 
 // ComprehensiveSearchResponse represents complete property data with all API results
 type ComprehensiveSearchResponse struct {
-	Address     string                `json:"address"`
-	Coordinates [2]float64            `json:"coordinates"`
-	GeoJSON     string                `json:"geojson"`
-	APIResults  APIResultsGrouped     `json:"apiResults"`
-	AISummary   *models.GeminiSummary `json:"aiSummary,omitempty"`
+	Address       string                `json:"address"`
+	Coordinates   [2]float64            `json:"coordinates"`
+	GeoJSON       string                `json:"geojson"`
+	ParcelGeoJSON string                `json:"parcelGeojson,omitempty"` // cadastral parcel polygon
+	APIResults    APIResultsGrouped     `json:"apiResults"`
+	AISummary     *models.GeminiSummary `json:"aiSummary,omitempty"`
 }
 
 // HandleSearch handles the /search endpoint
-// GET /search?address=<postcode+houseNumber>
-// POST /search with form fields postcode and houseNumber
+// GET /search?address=<postcode+houseNumber> or /search?id=<locatieserver id>
+// POST /search with form fields postcode and houseNumber, or id
 func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 	var postcode, houseNumber string
 	var bypassCache bool
@@ -83,13 +72,26 @@ func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusBadRequest, "invalid form data")
 			return
 		}
-		postcode = r.FormValue("postcode")
-		houseNumber = r.FormValue("houseNumber")
 		bypassCache = r.FormValue("bypassCache") == "true"
+		if id := strings.TrimSpace(r.FormValue("id")); id != "" {
+			var ok bool
+			if postcode, houseNumber, ok = resolveAddressID(w, r, h.aggregator, id); !ok {
+				return
+			}
+		} else {
+			postcode = r.FormValue("postcode")
+			houseNumber = r.FormValue("houseNumber")
+		}
 		if postcode == "" || houseNumber == "" {
 			respondWithError(w, http.StatusBadRequest, "missing postcode or houseNumber")
 			return
 		}
+	} else if id := strings.TrimSpace(r.URL.Query().Get("id")); id != "" {
+		bypassCache = r.URL.Query().Get("bypassCache") == "true" || r.URL.Query().Get("refresh") == "true"
+		var ok bool
+		if postcode, houseNumber, ok = resolveAddressID(w, r, h.aggregator, id); !ok {
+			return
+		}
 	} else {
 		// GET request with address parameter
 		addressParam := r.URL.Query().Get("address")
@@ -99,7 +101,7 @@ func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		// Parse address parameter (expected format: "3541ED 53" or "3541ED+53")
+		// Parse address parameter (expected format: "3541ED 53", "3541ED+53" or "3541ED 53 A")
 		parts := strings.Fields(strings.ReplaceAll(addressParam, "+", " "))
 		if len(parts) < 2 {
 			respondWithError(w, http.StatusBadRequest, "invalid address format, expected: postcode houseNumber")
@@ -107,12 +109,14 @@ func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 		}
 
 		postcode = parts[0]
-		houseNumber = parts[1]
+		houseNumber = strings.Join(parts[1:], " ")
 	}
 
-	// Normalize inputs for consistent caching
-	postcode = strings.ToUpper(strings.ReplaceAll(postcode, " ", ""))
-	houseNumber = strings.TrimSpace(houseNumber)
+	// Validate and normalize inputs for consistent caching
+	var ok bool
+	if postcode, houseNumber, ok = parseAddress(w, postcode, houseNumber); !ok {
+		return
+	}
 
 	// Security: Only allow cache bypass if authenticated as admin (or if no secret is configured)
 	if bypassCache {
@@ -132,7 +136,7 @@ func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 	bagData, err := h.apiClient.FetchBAGData(r.Context(), postcode, houseNumber)
 	if err != nil {
 		logutil.Errorf("Error fetching BAG data: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "failed to fetch property data")
+		respondWithAddressError(w, err, "failed to fetch property data")
 		return
 	}
 
@@ -159,11 +163,12 @@ func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 
 	// Build response
 	response := ComprehensiveSearchResponse{
-		Address:     bagData.Address,
-		Coordinates: bagData.Coordinates,
-		GeoJSON:     bagData.GeoJSON,
-		APIResults:  apiResults,
-		AISummary:   comprehensiveData.AISummary,
+		Address:       bagData.Address,
+		Coordinates:   bagData.Coordinates,
+		GeoJSON:       bagData.GeoJSON,
+		ParcelGeoJSON: comprehensiveData.ParcelGeoJSON,
+		APIResults:    apiResults,
+		AISummary:     comprehensiveData.AISummary,
 	}
 
 	// Serialize to JSON for embedding in HTML
@@ -216,13 +221,14 @@ func (h *SearchHandler) buildAPIResults(data *aggregator.ComprehensivePropertyDa
 	}
 
 	// Helper function to add result to appropriate category
-	addResult := func(name, status, error, category string, data interface{}) {
+	addResult := func(name, status, error, category string, value interface{}) {
 		result := APIResult{
-			Name:     name,
-			Status:   status,
-			Error:    error,
-			Category: category,
-			Data:     data,
+			Name:       name,
+			Status:     status,
+			Error:      error,
+			Category:   category,
+			Data:       value,
+			Provenance: data.Provenance[name],
 		}
 		switch category {
 		case "free":
@@ -237,207 +243,26 @@ func (h *SearchHandler) buildAPIResults(data *aggregator.ComprehensivePropertyDa
 	// BAG Address - FREE
 	addResult("BAG Address", "success", "", "free", map[string]interface{}{"address": data.Address, "coordinates": data.Coordinates})
 
-	// Property & Land Data
-	if data.KadasterInfo != nil {
-		addResult("Kadaster Object Info", "success", "", "premium", data.KadasterInfo)
-	} else {
-		addResult("Kadaster Object Info", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.WOZData != nil {
-		addResult("Altum WOZ", "success", "", "premium", data.WOZData)
-	} else {
-		addResult("Altum WOZ", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.MarketValuation != nil {
-		addResult("Matrixian Property Value+", "success", "", "premium", data.MarketValuation)
-	} else {
-		addResult("Matrixian Property Value+", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.TransactionHistory != nil {
-		addResult("Altum Transactions", "success", "", "premium", data.TransactionHistory)
-	} else {
-		addResult("Altum Transactions", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	// Weather & Climate - FREE
-	if data.Weather != nil {
-		addResult("KNMI Weather", "success", "", "free", data.Weather)
-	} else {
-		addResult("KNMI Weather", "error", getErrorMessage(data, "KNMI Weather", "Failed to fetch weather data"), "free", nil)
-	}
-
-	if data.SolarPotential != nil {
-		addResult("KNMI Solar", "success", "", "free", data.SolarPotential)
-	} else {
-		addResult("KNMI Solar", "error", getErrorMessage(data, "KNMI Solar", "Failed to fetch solar data"), "free", nil)
-	}
-
-	// Environmental Quality - FREE
-	if data.AirQuality != nil {
-		addResult("Luchtmeetnet Air Quality", "success", "", "free", data.AirQuality)
-	} else {
-		addResult("Luchtmeetnet Air Quality", "error", getErrorMessage(data, "Luchtmeetnet Air Quality", "Failed to fetch air quality data"), "free", nil)
-	}
-
-	if data.NoisePollution != nil {
-		addResult("Noise Pollution", "success", "", "freemium", data.NoisePollution)
-	} else {
-		addResult("Noise Pollution", "not_configured", "API not configured", "freemium", nil)
-	}
-
-	// Demographics - FREE
-	if data.Population != nil {
-		addResult("CBS Population", "success", "", "free", data.Population)
-	} else {
-		addResult("CBS Population", "error", "Failed to fetch population data", "free", nil)
-	}
-
-	if data.SquareStats != nil {
-		addResult("CBS Square Statistics", "success", "", "free", data.SquareStats)
-	} else {
-		addResult("CBS Square Statistics", "error", "Failed to fetch square stats", "free", nil)
-	}
-
-	// Soil & Geology
-	if data.SoilData != nil {
-		addResult("WUR Soil Physicals", "success", "", "freemium", data.SoilData)
-	} else {
-		addResult("WUR Soil Physicals", "not_configured", "API agreement required", "freemium", nil)
-	}
-
-	if data.Subsidence != nil {
-		addResult("SkyGeo Subsidence", "success", "", "premium", data.Subsidence)
-	} else {
-		addResult("SkyGeo Subsidence", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.SoilQuality != nil {
-		addResult("Soil Quality", "success", "", "freemium", data.SoilQuality)
-	} else {
-		addResult("Soil Quality", "not_configured", "API key required", "freemium", nil)
-	}
-
-	if data.BROSoilMap != nil {
-		addResult("BRO Soil Map", "success", "", "free", data.BROSoilMap)
-	} else {
-		addResult("BRO Soil Map", "error", "Failed to fetch BRO data", "free", nil)
-	}
-
-	// Energy & Sustainability
-	if data.EnergyClimate != nil {
-		addResult("Altum Energy & Climate", "success", "", "premium", data.EnergyClimate)
-	} else {
-		addResult("Altum Energy & Climate", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.Sustainability != nil {
-		addResult("Altum Sustainability", "success", "", "premium", data.Sustainability)
-	} else {
-		addResult("Altum Sustainability", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	// Traffic & Mobility
-	if len(data.TrafficData) > 0 {
-		addResult("NDW Traffic", "success", "", "free", data.TrafficData)
-	} else {
-		addResult("NDW Traffic", "error", "No traffic data available", "free", nil)
-	}
-
-	if data.PublicTransport != nil {
-		addResult("openOV Public Transport", "success", "", "free", data.PublicTransport)
-	} else {
-		addResult("openOV Public Transport", "error", "Failed to fetch transport data", "free", nil)
-	}
-
-	if data.ParkingData != nil {
-		addResult("Parking Availability", "success", "", "freemium", data.ParkingData)
-	} else {
-		addResult("Parking Availability", "not_configured", "API varies by municipality", "freemium", nil)
-	}
-
-	// Water & Safety
-	if data.FloodRisk != nil {
-		addResult("Flood Risk", "success", "", "free", data.FloodRisk)
-	} else {
-		addResult("Flood Risk", "error", "Failed to fetch flood risk", "free", nil)
-	}
-
-	if data.WaterQuality != nil {
-		addResult("Digital Delta Water Quality", "success", "", "freemium", data.WaterQuality)
-	} else {
-		addResult("Digital Delta Water Quality", "not_configured", "Water authority account required", "freemium", nil)
-	}
-
-	if data.Safety != nil {
-		addResult("CBS Safety Experience", "success", "", "freemium", data.Safety)
-	} else {
-		addResult("CBS Safety Experience", "not_configured", "API key required", "freemium", nil)
-	}
-
-	if data.SchipholFlights != nil {
-		addResult("Schiphol Flight Noise", "success", "", "premium", data.SchipholFlights)
-	} else {
-		addResult("Schiphol Flight Noise", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	// Infrastructure & Facilities - FREE
-	if data.GreenSpaces != nil {
-		addResult("Green Spaces", "success", "", "free", data.GreenSpaces)
-	} else {
-		addResult("Green Spaces", "error", "Failed to fetch green spaces", "free", nil)
-	}
-
-	if data.Education != nil {
-		addResult("Education Facilities", "success", "", "free", data.Education)
-	} else {
-		addResult("Education Facilities", "error", "Failed to fetch education data", "free", nil)
-	}
-
-	if data.BuildingPermits != nil {
-		addResult("Building Permits", "success", "", "freemium", data.BuildingPermits)
-	} else {
-		addResult("Building Permits", "not_configured", "API varies by region", "freemium", nil)
-	}
-
-	if data.Facilities != nil {
-		addResult("Facilities & Amenities", "success", "", "free", data.Facilities)
-	} else {
-		addResult("Facilities & Amenities", "error", "Failed to fetch facilities", "free", nil)
-	}
-
-	if data.Elevation != nil {
-		addResult("AHN Height Model", "success", "", "free", data.Elevation)
-	} else {
-		addResult("AHN Height Model", "error", "Failed to fetch elevation data", "free", nil)
-	}
-
-	// Heritage
-	if data.MonumentStatus != nil {
-		addResult("Monument Status", "success", "", "free", data.MonumentStatus)
-	} else {
-		addResult("Monument Status", "error", "No monument data (or Amsterdam only)", "free", nil)
-	}
-
-	// Comprehensive Platforms - FREE
-	if data.PDOKData != nil {
-		addResult("PDOK Platform", "success", "", "free", data.PDOKData)
-	} else {
-		addResult("PDOK Platform", "error", "Failed to fetch PDOK data", "free", nil)
-	}
-
-	if data.StratopoEnvironment != nil {
-		addResult("Stratopo Environment", "success", "", "premium", data.StratopoEnvironment)
-	} else {
-		addResult("Stratopo Environment", "not_configured", "API key not configured", "premium", nil)
-	}
-
-	if data.LandUse != nil {
-		addResult("Land Use & Zoning", "success", "", "free", data.LandUse)
-	} else {
-		addResult("Land Use & Zoning", "error", "Failed to fetch land use data", "free", nil)
+	for _, src := range aggregator.Sources() {
+		// Placeholder payloads are reported with their real status instead of as data
+		switch status := data.SourceStatus(src.Name()); status {
+		case aggregator.StatusError, aggregator.StatusNotConfigured, aggregator.StatusCircuitOpen:
+			message := data.Provenance[src.Name()].Message
+			if message == "" {
+				_, message = src.Unavailable()
+			}
+			addResult(src.Name(), status, message, string(src.Tier()), nil)
+			continue
+		}
+		if value, ok := src.Value(data); ok {
+			addResult(src.Name(), "success", "", string(src.Tier()), value)
+			continue
+		}
+		status, message := src.Unavailable()
+		if status == aggregator.StatusError {
+			message = getErrorMessage(data, src.Name(), message)
+		}
+		addResult(src.Name(), status, message, string(src.Tier()), nil)
 	}
 
 	return results
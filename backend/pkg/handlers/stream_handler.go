@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html"
 	"net/http"
@@ -9,7 +10,10 @@ import (
 	"time"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
 	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
+	"github.com/iman-hussain/nethaddress/backend/pkg/utils"
 )
 
 // HandleSearchStream handles the /api/search/stream endpoint for SSE
@@ -28,16 +32,37 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 	}
 	flusher.Flush()
 
-	// Normalize inputs for consistent caching
-	postcode := strings.ToUpper(strings.ReplaceAll(r.URL.Query().Get("postcode"), " ", ""))
-	houseNumber := strings.TrimSpace(r.URL.Query().Get("houseNumber"))
+	metrics.SSEConnections.Inc()
+	defer metrics.SSEConnections.Dec()
+
+	postcode := r.URL.Query().Get("postcode")
+	houseNumber := r.URL.Query().Get("houseNumber")
 	bypassCache := r.URL.Query().Get("bypassCache") == "true"
 
+	// A Locatieserver ID from /api/address/suggest replaces postcode and house number
+	if id := strings.TrimSpace(r.URL.Query().Get("id")); id != "" {
+		bagData, err := h.aggregator.ResolveAddressID(r.Context(), id)
+		if err != nil {
+			logutil.Warnf("Failed to resolve address id %s: %v", id, err)
+			sendSSEError(w, flusher, "Address not found")
+			return
+		}
+		postcode, houseNumber = bagData.Postcode, bagData.HouseNumber
+	}
+
 	if postcode == "" || houseNumber == "" {
 		sendSSEError(w, flusher, "Missing postcode or houseNumber")
 		return
 	}
 
+	// Validate and normalize inputs for consistent caching
+	addr, err := utils.ParseDutchAddress(postcode, houseNumber)
+	if err != nil {
+		sendSSEError(w, flusher, err.Error())
+		return
+	}
+	postcode, houseNumber = addr.Postcode, addr.HouseNumberString()
+
 	// Parse optional user provided API keys
 	var userKeys map[string]string
 	if keysParam := r.URL.Query().Get("apiKeys"); keysParam != "" {
@@ -66,14 +91,13 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 	logutil.Infof("Starting stream search for %s %s", postcode, houseNumber)
 
 	// Check if data is in cache (quick check before streaming)
-	cacheData, isCacheHit := h.aggregator.GetCachedData(postcode, houseNumber)
+	cacheData, isCacheHit := h.aggregator.GetCachedData(r.Context(), postcode, houseNumber)
 
 	// Create progress channel
 	progressCh := make(chan aggregator.ProgressEvent, 50) // Buffer to prevent blocking
 
-	// Channel for final result
-	resultCh := make(chan *aggregator.ComprehensivePropertyData)
-	errCh := make(chan error)
+	// Final result; buffered so the aggregation goroutine never blocks if the client has gone
+	resultCh := make(chan streamResult, 1)
 
 	// If cache hit and not bypassing, return immediately without streaming
 	if isCacheHit && !bypassCache && cacheData != nil {
@@ -82,11 +106,12 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 		// Build full response from cache
 		apiResults := h.buildAPIResults(cacheData)
 		response := ComprehensiveSearchResponse{
-			Address:     cacheData.Address,
-			Coordinates: cacheData.Coordinates,
-			GeoJSON:     cacheData.GeoJSON,
-			APIResults:  apiResults,
-			AISummary:   cacheData.AISummary,
+			Address:       cacheData.Address,
+			Coordinates:   cacheData.Coordinates,
+			GeoJSON:       cacheData.GeoJSON,
+			ParcelGeoJSON: cacheData.ParcelGeoJSON,
+			APIResults:    apiResults,
+			AISummary:     cacheData.AISummary,
 		}
 
 		// Send cached data immediately
@@ -125,24 +150,11 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Start aggregation in a goroutine
+	// Start aggregation in a goroutine. The request context cancels the work when
+	// the client disconnects; progress stops before AggregatePropertyDataWithOptions returns.
 	go func() {
-		// Ensure all channels are closed on exit to unblock main loop
-		defer close(progressCh)
-		defer close(resultCh)
-		defer close(errCh)
-
-		// Create a context that is cancelled when this goroutine returns
-		// (though we use request context for fetch operations)
-
-		// Use request context for fetching so client disconnect cancels work
 		data, err := h.aggregator.AggregatePropertyDataWithOptions(r.Context(), postcode, houseNumber, bypassCache, progressCh, userKeys)
-		if err != nil {
-			// If context was cancelled, err will effectively be ignored by receiver loop
-			errCh <- err
-			return
-		}
-		resultCh <- data
+		resultCh <- streamResult{data: data, err: err}
 	}()
 
 	// Send initial event
@@ -156,13 +168,7 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 	// Main loop: Listen for progress, results, errors, or client disconnect
 	for {
 		select {
-		case ev, ok := <-progressCh:
-			if !ok {
-				// Progress channel closed, wait for result or error
-				progressCh = nil // Disable this case
-				continue
-			}
-
+		case ev := <-progressCh:
 			if ev.Data != nil {
 				// Send partial update with data
 				payload, _ := json.Marshal(ev) // Includes Data field
@@ -174,19 +180,38 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 			}
 			flusher.Flush()
 
-		case data := <-resultCh:
-			if data == nil {
-				return // Should not happen if errCh logic is correct
+		case res := <-resultCh:
+			if res.err != nil {
+				var ambiguous *apiclient.AmbiguousAddressError
+				switch {
+				case r.Context().Err() != nil:
+				case errors.As(res.err, &ambiguous):
+					// Let the user pick the unit instead of failing
+					payload, _ := json.Marshal(AmbiguousAddressResponse{
+						Error:      "ambiguous address, choose one of the candidates",
+						Address:    ambiguous.Address,
+						Candidates: ambiguous.Candidates,
+					})
+					fmt.Fprintf(w, "event: ambiguous\ndata: %s\n\n", payload)
+					flusher.Flush()
+				default:
+					logutil.Errorf("Stream aggregation error: %v", res.err)
+					sendSSEError(w, flusher, res.err.Error())
+				}
+				return
 			}
+			data := res.data
+
 			// Build full response
 			apiResults := h.buildAPIResults(data)
 
 			response := ComprehensiveSearchResponse{
-				Address:     data.Address,
-				Coordinates: data.Coordinates,
-				GeoJSON:     data.GeoJSON, // Use aggregated GeoJSON
-				APIResults:  apiResults,
-				AISummary:   data.AISummary,
+				Address:       data.Address,
+				Coordinates:   data.Coordinates,
+				GeoJSON:       data.GeoJSON,
+				ParcelGeoJSON: data.ParcelGeoJSON, // Use aggregated GeoJSON
+				APIResults:    apiResults,
+				AISummary:     data.AISummary,
 			}
 
 			// Serialize to JSON
@@ -241,13 +266,6 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 			flusher.Flush()
 			return
 
-		case err := <-errCh:
-			if err != nil {
-				logutil.Errorf("Stream aggregation error: %v", err)
-				sendSSEError(w, flusher, err.Error())
-			}
-			return
-
 		case <-ticker.C:
 			// Send keep-alive comment
 			fmt.Fprintf(w, ": keepalive\n\n")
@@ -260,6 +278,12 @@ func (h *SearchHandler) HandleSearchStream(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// streamResult is the outcome of a streamed aggregation
+type streamResult struct {
+	data *aggregator.ComprehensivePropertyData
+	err  error
+}
+
 func sendSSEError(w http.ResponseWriter, flusher http.Flusher, msg string) {
 	// Escape the message for JSON string
 	safeMsg, _ := json.Marshal(msg)
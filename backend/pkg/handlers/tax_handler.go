@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/localtax"
+	"github.com/iman-hussain/nethaddress/backend/pkg/logutil"
+)
+
+// LocalTaxesResponse is the municipal housing tax estimate of a property
+type LocalTaxesResponse struct {
+	Postcode    string             `json:"postcode,omitempty"`
+	HouseNumber string             `json:"houseNumber,omitempty"`
+	Estimate    *localtax.Estimate `json:"estimate"`
+}
+
+// HandleGetLocalTaxes estimates the OZB, rioolheffing and afvalstoffenheffing of a
+// property for an owner-occupier and a landlord. ?woz= and ?municipality= override
+// the property's WOZ value and gemeentecode; with both the address may be left out.
+// GET /api/property/taxes
+func (h *PropertyHandler) HandleGetLocalTaxes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	q := r.URL.Query()
+	var in localtax.Input
+	woz, _, err := nonNegativeFloat(q, "woz")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if raw := strings.TrimSpace(q.Get("municipality")); raw != "" {
+		if in.MunicipalityCode, err = localtax.NormalizeMunicipalityCode(raw); err != nil {
+			respondWithError(w, http.StatusBadRequest, "invalid municipality: give a gemeentecode such as GM0344")
+			return
+		}
+	}
+	if in.SinglePerson, _, err = queryBool(q, "singlePerson"); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	year, err := queryYear(q)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rates, err := h.scoringEngine.LocalTaxRates().ForYear(year)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var resp LocalTaxesResponse
+	hasAddress := q.Get("id") != "" || q.Get("postcode") != "" || q.Get("houseNumber") != ""
+	if hasAddress || woz == 0 || in.MunicipalityCode == "" {
+		postcode, houseNumber, ok := addressFromQuery(w, r, h.aggregator)
+		if !ok {
+			return
+		}
+		resp.Postcode, resp.HouseNumber = postcode, houseNumber
+
+		logutil.Infof("Estimating local taxes for %s %s", postcode, houseNumber)
+
+		data, err := h.aggregator.AggregatePropertyData(r.Context(), postcode, houseNumber)
+		if err != nil {
+			logutil.Errorf("Error aggregating property data for local taxes: %v", err)
+			respondWithAddressError(w, err, "failed to aggregate property data")
+			return
+		}
+		fromProperty := localtax.FromProperty(data)
+		if in.MunicipalityCode == "" {
+			in.MunicipalityCode = fromProperty.MunicipalityCode
+		}
+		in.WOZValue = fromProperty.WOZValue
+	}
+	if woz > 0 {
+		in.WOZValue = woz
+	}
+
+	estimate, err := localtax.Calculate(rates, in)
+	if errors.Is(err, localtax.ErrMissingWOZ) {
+		respondWithError(w, http.StatusBadRequest, "no WOZ value for this address, pass woz")
+		return
+	}
+	if err != nil {
+		logutil.Errorf("Error estimating local taxes: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "failed to estimate local taxes")
+		return
+	}
+	resp.Estimate = estimate
+	respondWithJSON(w, http.StatusOK, resp)
+}
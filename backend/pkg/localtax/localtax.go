@@ -0,0 +1,118 @@
+package localtax
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+)
+
+// Errors returned when an estimate lacks an input
+var (
+	ErrMissingWOZ          = errors.New("a WOZ value is required for a local tax estimate")
+	ErrInvalidMunicipality = errors.New("invalid municipality code")
+)
+
+// Input identifies the property the taxes are estimated for
+type Input struct {
+	MunicipalityCode string  `json:"municipalityCode"` // CBS gemeentecode, e.g. GM0344; empty uses the national average
+	WOZValue         float64 `json:"wozValue"`
+	SinglePerson     bool    `json:"singlePerson"` // single-person household, for the afvalstoffenheffing
+}
+
+// Charges are the yearly housing taxes one party pays, in euros
+type Charges struct {
+	OZB   float64 `json:"ozb"`   // OZB eigenaren
+	Sewer float64 `json:"sewer"` // rioolheffing
+	Waste float64 `json:"waste"` // afvalstoffenheffing
+	Total float64 `json:"total"`
+}
+
+// Estimate is the yearly municipal housing tax for a property. An owner-occupier
+// pays every charge; a landlord pays the OZB and the owner's share of the
+// rioolheffing, while the tenant pays the rest.
+type Estimate struct {
+	Year             int     `json:"year"` // year of the rates applied
+	Source           string  `json:"source"`
+	MunicipalityCode string  `json:"municipalityCode,omitempty"`
+	Municipality     string  `json:"municipality"`
+	NationalAverage  bool    `json:"nationalAverage"` // the municipality's rates were not on file
+	WOZValue         float64 `json:"wozValue"`
+	OZBRate          float64 `json:"ozbRate"` // percent of the WOZ value
+	SinglePerson     bool    `json:"singlePerson"`
+	OwnerOccupier    Charges `json:"ownerOccupier"`
+	Landlord         Charges `json:"landlord"`
+}
+
+// Calculate estimates the OZB, rioolheffing and afvalstoffenheffing of a property
+// from the rates of its municipality, or the national average when it is unknown
+func Calculate(rates *YearRates, in Input) (*Estimate, error) {
+	if in.WOZValue <= 0 {
+		return nil, ErrMissingWOZ
+	}
+	e := &Estimate{Year: rates.Year, Source: rates.Source, WOZValue: in.WOZValue, SinglePerson: in.SinglePerson}
+
+	m := rates.Default
+	e.NationalAverage = true
+	if in.MunicipalityCode != "" {
+		code, err := NormalizeMunicipalityCode(in.MunicipalityCode)
+		if err != nil {
+			return nil, err
+		}
+		e.MunicipalityCode = code
+		if found, ok := rates.Municipalities[code]; ok {
+			m, e.NationalAverage = found, false
+		}
+	}
+	e.Municipality = m.Name
+	e.OZBRate = m.OZBOwnerRate
+
+	ozb := round2(in.WOZValue * m.OZBOwnerRate / 100)
+	waste := m.WasteMulti
+	if in.SinglePerson {
+		waste = m.WasteSingle
+	}
+	e.OwnerOccupier = charges(ozb, m.SewerOwner+m.SewerUser, waste)
+	e.Landlord = charges(ozb, m.SewerOwner, 0)
+	return e, nil
+}
+
+// NormalizeMunicipalityCode returns a gemeentecode written the BAG way ("0344")
+// or the CBS way ("GM0344") in the CBS form
+func NormalizeMunicipalityCode(code string) (string, error) {
+	digits := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(code)), "GM")
+	if len(digits) == 0 || len(digits) > 4 || strings.Trim(digits, "0123456789") != "" {
+		return "", fmt.Errorf("%w %q", ErrInvalidMunicipality, code)
+	}
+	n, _ := strconv.Atoi(digits)
+	if n == 0 {
+		return "", fmt.Errorf("%w %q", ErrInvalidMunicipality, code)
+	}
+	return fmt.Sprintf("GM%04d", n), nil
+}
+
+// FromProperty takes the municipality and WOZ value from aggregated property data,
+// using trusted source values only
+func FromProperty(data *aggregator.ComprehensivePropertyData) Input {
+	data = data.Trusted()
+
+	in := Input{MunicipalityCode: data.MunicipalityCode}
+	switch {
+	case data.WOZData != nil && data.WOZData.WOZValue > 0:
+		in.WOZValue = data.WOZData.WOZValue
+	case data.KadasterInfo != nil:
+		in.WOZValue = data.KadasterInfo.WOZValue
+	}
+	return in
+}
+
+func charges(ozb, sewer, waste float64) Charges {
+	return Charges{OZB: ozb, Sewer: sewer, Waste: waste, Total: round2(ozb + sewer + waste)}
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
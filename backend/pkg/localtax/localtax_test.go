@@ -0,0 +1,151 @@
+package localtax
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func ratesFor(t *testing.T, year int) *YearRates {
+	t.Helper()
+	r, err := BuiltinRates().ForYear(year)
+	if err != nil {
+		t.Fatalf("ForYear(%d): %v", year, err)
+	}
+	return r
+}
+
+func TestCalculate(t *testing.T) {
+	// Utrecht 2026: OZB 0.0698%, rioolheffing 242 for the owner, afval 420 / 315
+	e, err := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: "0344", WOZValue: 400000})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if e.MunicipalityCode != "GM0344" || e.Municipality != "Utrecht" || e.NationalAverage {
+		t.Errorf("Expected Utrecht's rates, got %s %q (average %v)", e.MunicipalityCode, e.Municipality, e.NationalAverage)
+	}
+	if e.OwnerOccupier.OZB != 279.2 || e.OwnerOccupier.Sewer != 242 || e.OwnerOccupier.Waste != 420 || e.OwnerOccupier.Total != 941.2 {
+		t.Errorf("Unexpected owner-occupier charges %+v", e.OwnerOccupier)
+	}
+	if e.Landlord.OZB != 279.2 || e.Landlord.Sewer != 242 || e.Landlord.Waste != 0 || e.Landlord.Total != 521.2 {
+		t.Errorf("Unexpected landlord charges %+v", e.Landlord)
+	}
+
+	single, _ := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: "GM0344", WOZValue: 400000, SinglePerson: true})
+	if single.OwnerOccupier.Waste != 315 {
+		t.Errorf("Expected the single-person afvalstoffenheffing, got %+v", single.OwnerOccupier)
+	}
+
+	// Amsterdam charges the rioolheffing to the occupant only
+	ams, _ := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: "GM0363", WOZValue: 400000})
+	if ams.Landlord.Sewer != 0 || ams.OwnerOccupier.Sewer != 210 {
+		t.Errorf("Expected the rioolheffing on the occupant, got %+v / %+v", ams.Landlord, ams.OwnerOccupier)
+	}
+
+	for _, code := range []string{"GM9999", ""} {
+		e, err := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: code, WOZValue: 300000})
+		if err != nil || !e.NationalAverage || e.Municipality != "Netherlands average" {
+			t.Errorf("%q: expected the national average, got %+v, %v", code, e, err)
+		}
+	}
+	if _, err := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: "GM0344"}); !errors.Is(err, ErrMissingWOZ) {
+		t.Errorf("Expected ErrMissingWOZ, got %v", err)
+	}
+	if _, err := Calculate(ratesFor(t, 2026), Input{MunicipalityCode: "Utrecht", WOZValue: 1}); !errors.Is(err, ErrInvalidMunicipality) {
+		t.Errorf("Expected ErrInvalidMunicipality, got %v", err)
+	}
+}
+
+func TestNormalizeMunicipalityCode(t *testing.T) {
+	valid := map[string]string{"GM0344": "GM0344", "gm0344": "GM0344", "0344": "GM0344", "344": "GM0344", " 14 ": "GM0014"}
+	for in, want := range valid {
+		if got, err := NormalizeMunicipalityCode(in); err != nil || got != want {
+			t.Errorf("NormalizeMunicipalityCode(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "GM", "0000", "GM12345", "-344", "GM03a4"} {
+		if _, err := NormalizeMunicipalityCode(in); err == nil {
+			t.Errorf("NormalizeMunicipalityCode(%q): expected an error", in)
+		}
+	}
+}
+
+func TestFromProperty(t *testing.T) {
+	data := &aggregator.ComprehensivePropertyData{
+		MunicipalityCode: "GM0599",
+		KadasterInfo:     &models.KadasterObjectInfo{WOZValue: 250000},
+	}
+	if in := FromProperty(data); in.MunicipalityCode != "GM0599" || in.WOZValue != 250000 {
+		t.Errorf("Expected the Kadaster WOZ value, got %+v", in)
+	}
+	data.WOZData = &models.AltumWOZData{WOZValue: 275000}
+	if in := FromProperty(data); in.WOZValue != 275000 {
+		t.Errorf("Expected the WOZ source to take precedence, got %+v", in)
+	}
+}
+
+func TestRatesForYear(t *testing.T) {
+	set := BuiltinRates()
+	if r, _ := set.ForYear(2099); r.Year != 2026 {
+		t.Errorf("Expected a later year to use the latest rates, got %d", r.Year)
+	}
+	if r, _ := set.ForYear(0); r.Year > time.Now().Year() {
+		t.Errorf("Expected the current year's rates, got %d", r.Year)
+	}
+	if _, err := set.ForYear(2010); !errors.Is(err, ErrNoRates) {
+		t.Errorf("Expected ErrNoRates for 2010, got %v", err)
+	}
+}
+
+func TestLoadRates(t *testing.T) {
+	raw, err := builtinRatesFS.ReadFile("rates/2026.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	next := strings.Replace(string(raw), `"year": 2026`, `"year": 2027`, 1)
+	next = strings.Replace(next, `"ozbOwnerRate": 0.0698`, `"ozbOwnerRate": 0.0721`, 1)
+	if err := os.WriteFile(filepath.Join(dir, "2027.json"), []byte(next), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := LoadRates(dir)
+	if err != nil {
+		t.Fatalf("LoadRates: %v", err)
+	}
+	if r, _ := set.ForYear(2027); r.Year != 2027 || r.Municipalities["GM0344"].OZBOwnerRate != 0.0721 {
+		t.Errorf("Expected the 2027 file to be used, got %d with %+v", r.Year, r.Municipalities["GM0344"])
+	}
+	if r, _ := set.ForYear(2025); r.Year != 2025 {
+		t.Errorf("Expected the built-in years to remain, got %d", r.Year)
+	}
+
+	invalid := map[string][]string{
+		"unknown field":     {strings.Replace(next, `"sewerOwner": 242`, `"sewer": 242`, 1)},
+		"negative charge":   {strings.Replace(next, `"wasteMulti": 420`, `"wasteMulti": -420`, 1)},
+		"rate not percent":  {strings.Replace(next, `"ozbOwnerRate": 0.0721`, `"ozbOwnerRate": 7.21`, 1)},
+		"municipality code": {strings.Replace(next, `"GM0344"`, `"0344"`, 1)},
+		"duplicate year":    {next, next},
+	}
+	for name, files := range invalid {
+		dir := t.TempDir()
+		for i, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%d.json", i)), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := LoadRates(dir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := LoadRates(t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without rates files")
+	}
+}
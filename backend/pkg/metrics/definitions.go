@@ -0,0 +1,49 @@
+package metrics
+
+// Upstream API calls, recorded centrally by the apiclient
+var (
+	UpstreamRequests = NewCounterVec("nethaddress_upstream_requests_total",
+		"Upstream API requests by API and HTTP status code (\"error\" when no response was received).",
+		"api", "code")
+	UpstreamDuration = NewHistogramVec("nethaddress_upstream_request_duration_seconds",
+		"Upstream API request latency in seconds.",
+		nil, "api")
+	UpstreamRetries = NewCounterVec("nethaddress_upstream_retries_total",
+		"Upstream API retries after a failed attempt.",
+		"api")
+)
+
+// Circuit breakers per upstream API
+var (
+	CircuitBreakerState = NewGaugeVec("nethaddress_circuit_breaker_state",
+		"Circuit breaker state per upstream API (0 closed, 1 half-open, 2 open).",
+		"api")
+	CircuitBreakerRejections = NewCounterVec("nethaddress_circuit_breaker_rejections_total",
+		"Upstream requests rejected because the API's circuit breaker was open.",
+		"api")
+)
+
+// Cache lookups by key family (aggregated, context, ai-summary, suggest, address)
+var CacheRequests = NewCounterVec("nethaddress_cache_requests_total",
+	"Cache lookups by key family and result (hit or miss).",
+	"family", "result")
+
+// Aggregation pipeline
+var (
+	AggregationPhaseDuration = NewHistogramVec("nethaddress_aggregation_phase_duration_seconds",
+		"Time spent in each aggregation phase (bag, area, property, supplemental, ai_summary, total).",
+		nil, "phase")
+	AggregationTimeouts = NewCounterVec("nethaddress_aggregation_timeouts_total",
+		"Aggregations whose data phases hit the collection deadline.")
+	AggregationsCoalesced = NewCounterVec("nethaddress_aggregations_coalesced_total",
+		"Aggregation requests served by joining an in-flight run for the same address.")
+)
+
+// SSEConnections tracks open /api/search/stream connections
+var SSEConnections = NewGaugeVec("nethaddress_sse_connections",
+	"Open server-sent event streams.")
+
+// GeminiTokens counts Gemini token usage by prompt kind and token type (prompt, output)
+var GeminiTokens = NewCounterVec("nethaddress_gemini_tokens_total",
+	"Gemini tokens used by summary kind and token type.",
+	"kind", "type")
@@ -0,0 +1,299 @@
+// Package metrics records counters, gauges and histograms in memory and exposes
+// them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are histogram buckets in seconds suited to upstream API latencies
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]collector{}
+)
+
+// collector is a metric family that can write itself in exposition format
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+func register(name string, c collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("metrics: %q registered twice", name))
+	}
+	registry[name] = c
+}
+
+// family holds the metadata and label names shared by all series of a metric
+type family struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (f family) header(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+}
+
+// key joins label values into a map key, checking the label count
+func (f family) key(values []string) string {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelPairs formats label values as {a="x",b="y"}, adding any extra pairs
+func (f family) labelPairs(values []string, extra ...string) string {
+	if len(values) == 0 && len(extra) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(values)+len(extra)/2)
+	for i, v := range values {
+		pairs = append(pairs, f.labels[i]+`="`+escapeLabel(v)+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escapeLabel(extra[i+1])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// valueVec is a set of float series keyed by label values (counters and gauges)
+type valueVec struct {
+	family
+	mu     sync.Mutex
+	series map[string]*valueSeries
+}
+
+type valueSeries struct {
+	labels []string
+	value  float64
+}
+
+func (v *valueVec) add(delta float64, labels []string) {
+	key := v.key(labels)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[key]
+	if !ok {
+		s = &valueSeries{labels: append([]string(nil), labels...)}
+		v.series[key] = s
+	}
+	s.value += delta
+}
+
+func (v *valueVec) set(value float64, labels []string) {
+	key := v.key(labels)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[key]
+	if !ok {
+		s = &valueSeries{labels: append([]string(nil), labels...)}
+		v.series[key] = s
+	}
+	s.value = value
+}
+
+func (v *valueVec) get(labels []string) float64 {
+	key := v.key(labels)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, ok := v.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (v *valueVec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.header(w)
+	for _, key := range sortedKeys(v.series) {
+		s := v.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", v.name, v.labelPairs(s.labels), formatFloat(s.value))
+	}
+}
+
+// CounterVec is a monotonically increasing counter partitioned by labels
+type CounterVec struct{ valueVec }
+
+// NewCounterVec creates and registers a counter
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{valueVec{family: family{name, help, "counter", labels}, series: map[string]*valueSeries{}}}
+	register(name, c)
+	return c
+}
+
+// Inc adds one to the series with the given label values
+func (c *CounterVec) Inc(labels ...string) { c.add(1, labels) }
+
+// Add adds delta (which must not be negative) to the series
+func (c *CounterVec) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		return
+	}
+	c.add(delta, labels)
+}
+
+// Value returns the current value of the series
+func (c *CounterVec) Value(labels ...string) float64 { return c.get(labels) }
+
+// GaugeVec is a value that can go up and down, partitioned by labels
+type GaugeVec struct{ valueVec }
+
+// NewGaugeVec creates and registers a gauge
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{valueVec{family: family{name, help, "gauge", labels}, series: map[string]*valueSeries{}}}
+	register(name, g)
+	return g
+}
+
+// Inc adds one to the series
+func (g *GaugeVec) Inc(labels ...string) { g.add(1, labels) }
+
+// Dec subtracts one from the series
+func (g *GaugeVec) Dec(labels ...string) { g.add(-1, labels) }
+
+// Set replaces the value of the series
+func (g *GaugeVec) Set(value float64, labels ...string) { g.set(value, labels) }
+
+// Value returns the current value of the series
+func (g *GaugeVec) Value(labels ...string) float64 { return g.get(labels) }
+
+// HistogramVec counts observations into cumulative buckets, partitioned by labels
+type HistogramVec struct {
+	family
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labels []string
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewHistogramVec creates and registers a histogram. nil buckets use DefaultBuckets.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	h := &HistogramVec{
+		family:  family{name, help, "histogram", labels},
+		buckets: sorted,
+		series:  map[string]*histogramSeries{},
+	}
+	register(name, h)
+	return h
+}
+
+// Observe records a value in the series with the given label values
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	key := h.key(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{labels: append([]string(nil), labels...), counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.counts[i]++
+	}
+	s.count++
+	s.sum += value
+}
+
+// ObserveSince records the seconds elapsed since start
+func (h *HistogramVec) ObserveSince(start time.Time, labels ...string) {
+	h.Observe(time.Since(start).Seconds(), labels...)
+}
+
+// Count returns the number of observations in the series
+func (h *HistogramVec) Count(labels ...string) uint64 {
+	key := h.key(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, ok := h.series[key]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.header(w)
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.labels, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(s.labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labelPairs(s.labels), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labelPairs(s.labels), s.count)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Handler serves all registered metrics in the Prometheus text format
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		bw := bufio.NewWriter(w)
+		defer bw.Flush()
+
+		registryMu.Lock()
+		names := sortedKeys(registry)
+		collectors := make([]collector, len(names))
+		for i, name := range names {
+			collectors[i] = registry[name]
+		}
+		registryMu.Unlock()
+
+		for _, c := range collectors {
+			c.write(bw)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestCounterAndGauge(t *testing.T) {
+	requests := NewCounterVec("test_requests_total", "Test requests.", "api", "code")
+	requests.Inc("BAG", "200")
+	requests.Add(2, "BAG", "200")
+	requests.Inc(`Say "hi"`, "error")
+	requests.Add(-1, "BAG", "200") // counters never decrease
+
+	open := NewGaugeVec("test_open", "Open things.")
+	open.Inc()
+	open.Inc()
+	open.Dec()
+
+	out := scrape(t)
+	for _, want := range []string{
+		"# HELP test_requests_total Test requests.\n# TYPE test_requests_total counter\n",
+		`test_requests_total{api="BAG",code="200"} 3` + "\n",
+		`test_requests_total{api="Say \"hi\"",code="error"} 1` + "\n",
+		"# TYPE test_open gauge\ntest_open 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	latency := NewHistogramVec("test_latency_seconds", "Test latency.", []float64{1, 0.1}, "api")
+	latency.Observe(0.05, "CBS")
+	latency.Observe(0.1, "CBS")
+	latency.Observe(0.5, "CBS")
+	latency.Observe(7, "CBS")
+
+	if got := latency.Count("CBS"); got != 4 {
+		t.Errorf("Expected 4 observations, got %d", got)
+	}
+
+	out := scrape(t)
+	for _, want := range []string{
+		"# TYPE test_latency_seconds histogram\n",
+		`test_latency_seconds_bucket{api="CBS",le="0.1"} 2` + "\n",
+		`test_latency_seconds_bucket{api="CBS",le="1"} 3` + "\n",
+		`test_latency_seconds_bucket{api="CBS",le="+Inf"} 4` + "\n",
+		`test_latency_seconds_sum{api="CBS"} 7.65` + "\n",
+		`test_latency_seconds_count{api="CBS"} 4` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestLabelCountMismatchPanics(t *testing.T) {
+	c := NewCounterVec("test_mismatch_total", "Mismatch.", "api")
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for wrong number of label values")
+		}
+	}()
+	c.Inc("a", "b")
+}
@@ -16,6 +16,8 @@ type BAGData struct {
 	Coordinates        [2]float64 `json:"coordinates"`
 	GeoJSON            string     `json:"geojson"`
 	ID                 string     `json:"id,omitempty"`
+	Postcode           string     `json:"postcode,omitempty"`
+	HouseNumber        string     `json:"house_number,omitempty"` // huisnummer with letter and toevoeging
 	NummeraanduidingID string     `json:"nummeraanduiding_id,omitempty"`
 	VerblijfsobjectID  string     `json:"verblijfsobject_id,omitempty"`
 	PandID             string     `json:"pand_id,omitempty"`
@@ -25,6 +27,20 @@ type BAGData struct {
 	ProvinceCode       string     `json:"province_code,omitempty"`
 }
 
+// BAGBuildingData holds BAG register attributes of the pand (building) and the
+// verblijfsobject (unit) at an address
+type BAGBuildingData struct {
+	PandID            string   `json:"pandId"`
+	VerblijfsobjectID string   `json:"verblijfsobjectId,omitempty"`
+	ConstructionYear  int      `json:"constructionYear"`           // bouwjaar
+	Status            string   `json:"status"`                     // pandstatus, e.g. "Pand in gebruik"
+	UsageFunctions    []string `json:"usageFunctions"`             // gebruiksdoelen, e.g. woonfunctie
+	FloorArea         float64  `json:"floorArea"`                  // m², gebruiksoppervlakte of the verblijfsobject
+	UnitsInBuilding   int      `json:"unitsInBuilding"`            // verblijfsobjecten in the pand
+	FootprintArea     float64  `json:"footprintArea,omitempty"`    // m², computed from the pand polygon
+	FootprintGeoJSON  string   `json:"footprintGeojson,omitempty"` // pand polygon geometry
+}
+
 // PDOKData represents data from PDOK
 // ZoningInfo: zoning information string
 // Restrictions: list of restrictions
@@ -74,6 +90,7 @@ type BagResponse struct {
 // BagDocument contains fields from BAG API
 type BagDocument struct {
 	ID                 string  `json:"id"`
+	Type               string  `json:"type"`
 	NummeraanduidingID string  `json:"nummeraanduiding_id"`
 	VerblijfsobjectID  string  `json:"verblijfsobject_id"`
 	PandID             string  `json:"pand_id"`
@@ -91,6 +108,38 @@ type BagDocument struct {
 	Provinciecode      string  `json:"provinciecode"`
 	CentroidLL         string  `json:"centroide_ll"`
 	GeometriePolygoon  string  `json:"geometrie_polygoon"`
+	Afstand            float64 `json:"afstand"` // metres from the point, reverse service only
+}
+
+// AddressSuggestion is a ranked address candidate from the Locatieserver suggest service
+type AddressSuggestion struct {
+	ID          string  `json:"id"` // Locatieserver ID, accepted by the search endpoints
+	Label       string  `json:"label"`
+	Highlight   string  `json:"highlight,omitempty"` // label with the matched terms in <b> tags
+	Street      string  `json:"street,omitempty"`
+	HouseNumber string  `json:"houseNumber,omitempty"` // huisnummer with letter and toevoeging
+	Postcode    string  `json:"postcode,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+// LocatieserverSuggestResponse mirrors the PDOK Locatieserver suggest endpoint JSON payload
+type LocatieserverSuggestResponse struct {
+	Response struct {
+		Docs []struct {
+			ID             string  `json:"id"`
+			Type           string  `json:"type"`
+			Weergavenaam   string  `json:"weergavenaam"`
+			Straatnaam     string  `json:"straatnaam"`
+			HuisNLT        string  `json:"huis_nlt"`
+			Postcode       string  `json:"postcode"`
+			WoonplaatsNaam string  `json:"woonplaatsnaam"`
+			Score          float64 `json:"score"`
+		} `json:"docs"`
+	} `json:"response"`
+	Highlighting map[string]struct {
+		Suggest []string `json:"suggest"`
+	} `json:"highlighting"`
 }
 
 // KNMIWeatherData represents weather data
@@ -281,22 +330,22 @@ type OverpassFacilitiesResponse struct {
 	} `json:"elements"`
 }
 
-// AHNHeightData represents elevation and terrain data
+// AHNHeightData represents elevation and terrain data sampled from the AHN4 DTM/DSM
 type AHNHeightData struct {
-	Elevation     float64   `json:"elevation"`     // meters above NAP
-	TerrainSlope  float64   `json:"terrainSlope"`  // degrees
-	FloodRisk     string    `json:"floodRisk"`     // Low, Medium, High based on elevation
-	ViewPotential string    `json:"viewPotential"` // Poor, Fair, Good, Excellent
-	Surrounding   []float64 `json:"surrounding"`   // Elevations of nearby points
-}
-
-// OpenElevationResponse represents Open-Elevation API response
-type OpenElevationResponse struct {
-	Results []struct {
-		Elevation float64 `json:"elevation"`
-		Latitude  float64 `json:"latitude"`
-		Longitude float64 `json:"longitude"`
-	} `json:"results"`
+	Available         bool      `json:"available"`                   // false when no AHN data could be sampled
+	UnavailableReason string    `json:"unavailableReason,omitempty"` // why Available is false
+	Elevation         float64   `json:"elevation"`                   // ground level (DTM), meters above NAP
+	SurfaceElevation  *float64  `json:"surfaceElevation,omitempty"`  // highest surface (DSM) at the point, meters above NAP
+	BuildingHeight    *float64  `json:"buildingHeight,omitempty"`    // DSM minus DTM, 0 if no structure
+	TerrainSlope      float64   `json:"terrainSlope"`                // degrees
+	RelativeHeight    float64   `json:"relativeHeight"`              // meters above (+) or below (-) the surrounding average
+	SurroundingMin    float64   `json:"surroundingMin"`              // lowest ground level in the surrounding area
+	SurroundingMax    float64   `json:"surroundingMax"`              // highest ground level in the surrounding area
+	SurroundingAvg    float64   `json:"surroundingAvg"`              // average ground level in the surrounding area
+	FloodRisk         string    `json:"floodRisk"`                   // Low, Medium, High based on elevation
+	ViewPotential     string    `json:"viewPotential"`               // Poor, Fair, Good, Excellent
+	Surrounding       []float64 `json:"surrounding"`                 // Ground levels at the compass points of the surrounding area
+	Source            string    `json:"source,omitempty"`            // dataset the values were sampled from
 }
 
 // WeerliveWeatherData represents weather data from Weerlive API
@@ -515,12 +564,15 @@ type PDOKPlatformData struct {
 
 // CadastralInfo represents cadastral parcel information
 type CadastralInfo struct {
-	ParcelID     string  `json:"parcelId"`
-	Municipality string  `json:"municipality"`
-	Section      string  `json:"section"`
-	ParcelNumber string  `json:"parcelNumber"`
-	Area         float64 `json:"area"` // m²
-	LandUse      string  `json:"landUse"`
+	ParcelID         string  `json:"parcelId"`
+	Municipality     string  `json:"municipality"`     // Kadastrale gemeente name
+	MunicipalityCode string  `json:"municipalityCode"` // Kadastrale gemeente code, e.g. ASD04
+	Section          string  `json:"section"`
+	ParcelNumber     string  `json:"parcelNumber"`
+	Area             float64 `json:"area"`                     // m², computed from the parcel polygon
+	RegisteredArea   float64 `json:"registeredArea,omitempty"` // m², kadastrale grootte as registered
+	LandUse          string  `json:"landUse"`
+	GeoJSON          string  `json:"geojson,omitempty"` // Parcel polygon geometry
 }
 
 // AddressInfo represents comprehensive address data
@@ -571,16 +623,38 @@ type LandUseData struct {
 	BuildingRights  *BuildingRights   `json:"buildingRights"`
 	ProtectedStatus string            `json:"protectedStatus"` // None, Monument, Conservation Area
 	FuturePlans     []DevelopmentPlan `json:"futurePlans"`
+	Plans           []ZoningPlan      `json:"plans,omitempty"` // Every plan applying at the location
 }
 
 // BuildingRights represents development rights
 type BuildingRights struct {
-	MaxHeight      float64 `json:"maxHeight"`      // meters
-	MaxBuildArea   float64 `json:"maxBuildArea"`   // m²
-	FloorAreaRatio float64 `json:"floorAreaRatio"` // FSI
-	GroundCoverage float64 `json:"groundCoverage"` // percentage
-	CanSubdivide   bool    `json:"canSubdivide"`
-	CanExpand      bool    `json:"canExpand"`
+	MaxHeight       float64 `json:"maxHeight"`       // meters
+	MaxGutterHeight float64 `json:"maxGutterHeight"` // meters
+	MaxBuildArea    float64 `json:"maxBuildArea"`    // m²
+	FloorAreaRatio  float64 `json:"floorAreaRatio"`  // FSI
+	GroundCoverage  float64 `json:"groundCoverage"`  // percentage
+	InBuildingPlane bool    `json:"inBuildingPlane"` // location lies within a bouwvlak
+	CanSubdivide    bool    `json:"canSubdivide"`
+	CanExpand       bool    `json:"canExpand"`
+}
+
+// ZoningPlan is a spatial plan (bestemmingsplan, omgevingsplan, ...) applying at a point,
+// with the bestemmingsvlakken and maatvoeringen found at that point
+type ZoningPlan struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`   // bestemmingsplan, omgevingsplan, beheersverordening, ...
+	Status            string   `json:"status"` // voorontwerp, ontwerp, vastgesteld, onherroepelijk
+	StatusDate        string   `json:"statusDate,omitempty"`
+	InForce           bool     `json:"inForce"`
+	MainUse           string   `json:"mainUse,omitempty"`      // enkelbestemming
+	MainUseGroup      string   `json:"mainUseGroup,omitempty"` // bestemmingshoofdgroep of the enkelbestemming
+	Overlays          []string `json:"overlays,omitempty"`     // dubbelbestemmingen
+	InBuildingPlane   bool     `json:"inBuildingPlane"`        // location lies within a bouwvlak
+	MaxBuildingHeight float64  `json:"maxBuildingHeight,omitempty"`
+	MaxGutterHeight   float64  `json:"maxGutterHeight,omitempty"`
+	BuildingCoverage  float64  `json:"buildingCoverage,omitempty"` // maximum bebouwingspercentage
+	URL               string   `json:"url,omitempty"`
 }
 
 // DevelopmentPlan represents future development
@@ -594,14 +668,24 @@ type DevelopmentPlan struct {
 
 // MonumentData represents heritage status
 type MonumentData struct {
-	IsMonument bool   `json:"isMonument"`
-	Type       string `json:"type"`
-	Date       string `json:"date"`
-	Name       string `json:"name,omitempty"`
-	Number     string `json:"number,omitempty"`
+	IsMonument    bool           `json:"isMonument"`  // rijksmonument; see LocalStatus for the other registers
+	Type          string         `json:"type"`        // Rijksmonument; provincial and municipal monuments are not covered
+	LocalStatus   string         `json:"localStatus"` // gemeentelijk or provinciaal monument status: "unknown", those registers are not checked
+	Date          string         `json:"date"`        // Date the protection took effect
+	Name          string         `json:"name,omitempty"`
+	Number        string         `json:"number,omitempty"` // Monument register number
+	URL           string         `json:"url,omitempty"`
+	ProtectedArea *ProtectedArea `json:"protectedArea,omitempty"` // Beschermd stads- of dorpsgezicht
 }
 
-// MonumentResponse represents the PDOK RCE INSPIRE monuments API response
+// ProtectedArea represents a beschermd stads- of dorpsgezicht containing the property
+type ProtectedArea struct {
+	Name string `json:"name"`
+	Date string `json:"date,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// MonumentResponse represents the PDOK RCE INSPIRE protected sites API response
 type MonumentResponse struct {
 	Type     string `json:"type"`
 	Features []struct {
@@ -609,14 +693,25 @@ type MonumentResponse struct {
 		ID         string `json:"id"`
 		Properties struct {
 			// INSPIRE format fields
-			Text                string `json:"text"`                // Monument name
-			LegalFoundationDate string `json:"legalfoundationdate"` // Date of designation
-			CICitation          string `json:"ci_citation"`         // Link to monumentenregister
+			Text                string `json:"text"`                         // Site name
+			LegalFoundationDate string `json:"legalfoundationdate"`          // Date of designation
+			CICitation          string `json:"ci_citation"`                  // Link to the designation
+			Classification      string `json:"siteprotectionclassification"` // cultural, archaeological
 		} `json:"properties"`
 	} `json:"features"`
 	NumberReturned int `json:"numberReturned"`
 }
 
+// SPARQLResponse represents a SPARQL 1.1 JSON query result
+type SPARQLResponse struct {
+	Results struct {
+		Bindings []map[string]struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"bindings"`
+	} `json:"results"`
+}
+
 // MatrixianPropertyValue represents comprehensive market valuation data
 type MatrixianPropertyValue struct {
 	MarketValue          float64                `json:"marketValue"`
@@ -681,6 +776,23 @@ type NeighborhoodRegionCodes struct {
 	MunicipalityName string `json:"municipalityName"`
 }
 
+// AreaData describes a CBS buurt, wijk or postcode-4 area: its outline and key figures
+type AreaData struct {
+	Code              string       `json:"code"`
+	Level             string       `json:"level"` // buurt, wijk or pc4
+	Name              string       `json:"name"`
+	MunicipalityName  string       `json:"municipalityName,omitempty"`
+	GeoJSON           string       `json:"geojson,omitempty"`
+	Centroid          [2]float64   `json:"centroid"`    // [lon, lat]
+	SurfaceArea       float64      `json:"surfaceArea"` // m²
+	Population        int          `json:"population"`
+	Households        int          `json:"households"`
+	PopulationDensity float64      `json:"populationDensity"` // inhabitants per km²
+	AverageIncome     float64      `json:"averageIncome"`     // EUR, standardised household income
+	AverageWOZ        float64      `json:"averageWOZ"`        // EUR
+	SamplePoints      [][2]float64 `json:"samplePoints"`      // [lon, lat] points spread across the area
+}
+
 // CBSPopulationData represents neighbourhood-based population data from CBS buurten
 type CBSPopulationData struct {
 	TotalPopulation   int                    `json:"totalPopulation"`
@@ -785,6 +897,30 @@ type EnergyClimateData struct {
 	HeatLoss         float64 `json:"heatLoss"`         // W/m²K
 }
 
+// EnergyLabelData is the energy label registered for a unit in RVO EP-Online
+type EnergyLabelData struct {
+	VerblijfsobjectID string  `json:"verblijfsobjectId,omitempty"`
+	Label             string  `json:"label"`                       // energieklasse, A++++ to G
+	RegistrationDate  string  `json:"registrationDate"`            // YYYY-MM-DD
+	InspectionDate    string  `json:"inspectionDate,omitempty"`    // opnamedatum, YYYY-MM-DD
+	ValidUntil        string  `json:"validUntil"`                  // YYYY-MM-DD
+	Expired           bool    `json:"expired"`                     // validUntil has passed
+	EP2               float64 `json:"ep2,omitempty"`               // kWh/m²/yr primary fossil energy (NTA 8800 labels, 2021+)
+	EnergyIndex       float64 `json:"energyIndex,omitempty"`       // energie-index of pre-2021 labels
+	CalculationMethod string  `json:"calculationMethod,omitempty"` // berekeningstype, e.g. "NTA 8800:2023 (basismethode)"
+	BuildingType      string  `json:"buildingType,omitempty"`      // gebouwtype
+	// ProvisionalLabel is the pre-2021 "voorlopig" label, if one was registered
+	ProvisionalLabel *ProvisionalEnergyLabel `json:"provisionalLabel,omitempty"`
+}
+
+// ProvisionalEnergyLabel is a voorlopig energielabel, assigned from building age and
+// type without an inspection before the 2021 NTA 8800 method
+type ProvisionalEnergyLabel struct {
+	Label            string `json:"label"`
+	RegistrationDate string `json:"registrationDate"`
+	ValidUntil       string `json:"validUntil,omitempty"`
+}
+
 // SustainabilityData represents sustainability measures and CO2 savings potential
 type SustainabilityData struct {
 	CurrentRating       string                  `json:"currentRating"`
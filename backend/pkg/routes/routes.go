@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
 	"github.com/iman-hussain/nethaddress/backend/pkg/cache"
 	"github.com/iman-hussain/nethaddress/backend/pkg/handlers"
+	"github.com/iman-hussain/nethaddress/backend/pkg/metrics"
 )
 
 // Build-time variables (set by main.go during initialization)
@@ -34,19 +36,25 @@ func SetFrontendBuildInfo(commit, date string) {
 type Router struct {
 	propertyHandler *handlers.PropertyHandler
 	searchHandler   *handlers.SearchHandler
-	cacheService    *cache.CacheService
+	batchHandler    *handlers.BatchHandler
+	cacheService    cache.Cache
+	apiClient       *apiclient.ApiClient
 }
 
 // NewRouter creates a new router with all handlers
 func NewRouter(
 	propertyHandler *handlers.PropertyHandler,
 	searchHandler *handlers.SearchHandler,
-	cacheService *cache.CacheService,
+	batchHandler *handlers.BatchHandler,
+	cacheService cache.Cache,
+	apiClient *apiclient.ApiClient,
 ) *Router {
 	return &Router{
 		propertyHandler: propertyHandler,
 		searchHandler:   searchHandler,
+		batchHandler:    batchHandler,
 		cacheService:    cacheService,
+		apiClient:       apiClient,
 	}
 }
 
@@ -55,20 +63,47 @@ func (router *Router) SetupRoutes(mux *http.ServeMux) {
 	// Health check
 	mux.HandleFunc("/healthz", handleHealthCheck)
 	mux.HandleFunc("/build-info", handleBuildInfo)
+	mux.Handle("/metrics", metrics.Handler())
 	mux.HandleFunc("/", handleRoot)
 
 	// Admin endpoints
 	mux.HandleFunc("/admin/cache/flush", router.handleCacheFlush)
+	mux.HandleFunc("/admin/circuit-breakers", router.handleCircuitBreakers)
+	mux.HandleFunc("/admin/circuit-breakers/reset", router.handleCircuitBreakerReset)
 
 	// Legacy endpoint (backward compatibility)
 	mux.HandleFunc("/search", router.searchHandler.HandleSearch)
 
+	// Real-time search with progress events (SSE)
+	mux.HandleFunc("/api/search/stream", router.searchHandler.HandleSearchStream)
+
+	// Address autocomplete
+	mux.HandleFunc("/api/address/suggest", router.searchHandler.HandleAddressSuggest)
+
 	// New comprehensive API endpoints - longest paths first for proper matching
 	mux.HandleFunc("/api/property/analysis", router.propertyHandler.HandleGetFullAnalysis)
 	mux.HandleFunc("/api/property/scores", router.propertyHandler.HandleGetPropertyScores)
 	mux.HandleFunc("/api/property/recommendations", router.propertyHandler.HandleGetRecommendations)
 	mux.HandleFunc("/api/property/solar", router.propertyHandler.HandleCheckSolarEligibility)
+	mux.HandleFunc("/api/property/rent", router.propertyHandler.HandleGetRentValuation)
+	mux.HandleFunc("/api/property/costs", router.propertyHandler.HandleGetPurchaseCosts)
+	mux.HandleFunc("/api/property/taxes", router.propertyHandler.HandleGetLocalTaxes)
+	mux.HandleFunc("/api/property/at", router.propertyHandler.HandleGetPropertyAt)
 	mux.HandleFunc("/api/property", router.propertyHandler.HandleGetPropertyData)
+
+	// Scoring profiles selectable with ?profile=
+	mux.HandleFunc("/api/scoring/profiles", router.propertyHandler.HandleListProfiles)
+
+	// Side-by-side comparison of 2-5 properties
+	mux.HandleFunc("/api/compare", router.propertyHandler.HandleCompare)
+
+	// Neighbourhood analysis by buurtcode, wijkcode or postcode-4
+	mux.HandleFunc("/api/area/{code}", router.propertyHandler.HandleGetArea)
+
+	// Batch analysis jobs
+	mux.HandleFunc("/api/batch", router.batchHandler.HandleCreateBatch)
+	mux.HandleFunc("/api/batch/{id}", router.batchHandler.HandleGetBatch)
+	mux.HandleFunc("/api/batch/{id}/results", router.batchHandler.HandleGetBatchResults)
 }
 
 // Health check endpoint
@@ -94,28 +129,7 @@ func (router *Router) handleCacheFlush(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Only allow POST requests
-	if r.Method != http.MethodPost {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": "method not allowed, use POST",
-		})
-		return
-	}
-	// Check for strict admin secret
-	adminSecret := os.Getenv("ADMIN_SECRET")
-	authHeader := r.Header.Get("X-Admin-Secret")
-
-	// If ADMIN_SECRET is not set in env, fail secure (deny all)
-	if adminSecret == "" {
-		log.Println("⚠️  Admin flush attempted but ADMIN_SECRET not configured")
-		http.Error(w, "Endpoint configuration error", http.StatusForbidden)
-		return
-	}
-
-	if authHeader != adminSecret {
-		log.Printf("⚠️  Unauthorized cache flush attempt from %s", r.RemoteAddr)
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+	if !requireMethod(w, r, http.MethodPost) || !requireAdmin(w, r, "cache flush") {
 		return
 	}
 
@@ -128,7 +142,7 @@ func (router *Router) handleCacheFlush(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := router.cacheService.FlushAll(); err != nil {
+	if err := router.cacheService.FlushAll(r.Context()); err != nil {
 		log.Printf("Cache flush error: %v", err)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -147,6 +161,72 @@ func (router *Router) handleCacheFlush(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// requireMethod writes a 405 response and returns false unless r uses method
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": "method not allowed, use " + method,
+	})
+	return false
+}
+
+// requireAdmin checks the X-Admin-Secret header against ADMIN_SECRET, writing an
+// error response and returning false if the request is not authorised
+func requireAdmin(w http.ResponseWriter, r *http.Request, action string) bool {
+	adminSecret := os.Getenv("ADMIN_SECRET")
+	authHeader := r.Header.Get("X-Admin-Secret")
+
+	// If ADMIN_SECRET is not set in env, fail secure (deny all)
+	if adminSecret == "" {
+		log.Printf("⚠️  Admin %s attempted but ADMIN_SECRET not configured", action)
+		http.Error(w, "Endpoint configuration error", http.StatusForbidden)
+		return false
+	}
+
+	if authHeader != adminSecret {
+		log.Printf("⚠️  Unauthorized %s attempt from %s", action, r.RemoteAddr)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+// Circuit breaker state endpoint (admin): lists each upstream's breaker
+func (router *Router) handleCircuitBreakers(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) || !requireAdmin(w, r, "circuit breaker status") {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"breakers": router.apiClient.CircuitBreakers(),
+	})
+}
+
+// Circuit breaker reset endpoint (admin): closes one breaker (?name=) or all of them
+func (router *Router) handleCircuitBreakerReset(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) || !requireAdmin(w, r, "circuit breaker reset") {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	w.Header().Set("Content-Type", "application/json")
+	if !router.apiClient.ResetCircuitBreaker(name) && name != "" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": "no circuit breaker named " + name,
+		})
+		return
+	}
+	log.Printf("✅ Circuit breaker reset via admin endpoint (%q)", name)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "ok",
+		"breakers": router.apiClient.CircuitBreakers(),
+	})
+}
+
 // Build info endpoint
 func handleBuildInfo(w http.ResponseWriter, r *http.Request) {
 	// Only accept exact path match
@@ -182,14 +262,27 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 			"GET /healthz":                      "Health check",
 			"GET /build-info":                   "Build information",
 			"GET /search":                       "Legacy search endpoint",
+			"GET /api/address/suggest":          "Address autocomplete (?q=, optional ?limit=)",
 			"GET /api/property":                 "Get comprehensive property data",
 			"GET /api/property/scores":          "Get property scores (ESG, Profit, Opportunity)",
 			"GET /api/property/recommendations": "Get smart recommendations",
 			"GET /api/property/analysis":        "Get full analysis (data + scores + recommendations)",
+			"GET /api/property/at":              "Analyse the address nearest to ?lat=&lon=, or the bare location if none is close",
+			"GET /api/property/rent":            "WWS points, maximum rent, rental segment and yield (facilities and missing inputs as query parameters)",
+			"GET /api/property/costs":           "Purchase costs: transfer tax, fees, NHG and maximum mortgage (?price=, ?income=, ?starter=&age=, ?year=)",
+			"GET /api/property/taxes":           "Municipal OZB, rioolheffing and afvalstoffenheffing for owner-occupiers and landlords (?woz=, ?municipality=, ?year=)",
+			"GET /api/area/{code}":              "Analyse a whole buurt (BU...), wijk (WK...) or postcode-4 area with area score and AI summary",
+			"GET /api/scoring/profiles":         "List the scoring profiles with their weights and thresholds",
+			"POST /api/compare":                 "Compare 2-5 properties metric by metric with rankings and best-worst deltas",
+			"POST /api/batch":                   "Create a batch analysis job from a JSON or CSV address list",
+			"GET /api/batch/{id}":               "Get batch job status and progress",
+			"GET /api/batch/{id}/results":       "Download batch results (?format=csv for CSV)",
 		},
 		"query_parameters": map[string]string{
 			"postcode":    "Dutch postcode (e.g., 3541ED)",
 			"houseNumber": "House number (e.g., 53)",
+			"id":          "Locatieserver address ID from /api/address/suggest, instead of postcode and houseNumber",
+			"profile":     "Scoring profile for scores, recommendations, analysis and compare (see /api/scoring/profiles)",
 		},
 	})
 }
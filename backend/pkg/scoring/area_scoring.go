@@ -0,0 +1,65 @@
+package scoring
+
+import (
+	"math"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+)
+
+// National reference figures an area's income and WOZ are compared against
+const (
+	nationalAverageWOZ    = 400000.0 // EUR, CBS 2024
+	nationalAverageIncome = 36000.0  // EUR, standardised household income
+)
+
+// AreaScores rates a buurt, wijk or postcode-4 area as a whole
+type AreaScores struct {
+	OverallScore float64 `json:"overallScore"` // 0-100
+	Affluence    float64 `json:"affluence"`    // 0-100, income and WOZ against the national average
+	Liveability  float64 `json:"liveability"`  // 0-100, green share and amenities
+	FloodSafety  float64 `json:"floodSafety"`  // 0-100 (higher is safer)
+	RiskLevel    string  `json:"riskLevel"`    // Low, Medium, High
+}
+
+// CalculateAreaScores computes the area score; unknown figures score as neutral
+func (se *EnhancedScoringEngine) CalculateAreaScores(analysis *aggregator.AreaAnalysis) *AreaScores {
+	scores := &AreaScores{}
+
+	// Affluence: 50 at the national average, 100 at twice the average
+	incomeScore, wozScore := 50.0, 50.0
+	if analysis.Area != nil && analysis.Area.AverageIncome > 0 {
+		incomeScore = math.Min(100, analysis.Area.AverageIncome/nationalAverageIncome*50)
+	}
+	if analysis.Area != nil && analysis.Area.AverageWOZ > 0 {
+		wozScore = math.Min(100, analysis.Area.AverageWOZ/nationalAverageWOZ*50)
+	}
+	scores.Affluence = incomeScore*0.5 + wozScore*0.5
+
+	// Liveability (green share + amenities across the sample points)
+	green, amenities := 50.0, 50.0
+	if analysis.Samples["Green Spaces"] > 0 {
+		green = math.Min(100, analysis.GreenPercentage)
+	}
+	if analysis.Samples["Facilities & Amenities"] > 0 {
+		amenities = analysis.AmenitiesScore
+	}
+	scores.Liveability = green*0.4 + amenities*0.6
+
+	// Flood safety falls with the share of the area in a flood risk zone
+	scores.FloodSafety = 70 // Assume moderate if unknown
+	scores.RiskLevel = "Unknown"
+	if analysis.Samples["Flood Risk"] > 0 {
+		scores.FloodSafety = 90 - analysis.FloodRiskShare*60
+		switch {
+		case analysis.FloodRiskShare >= 0.5:
+			scores.RiskLevel = "High"
+		case analysis.FloodRiskShare > 0:
+			scores.RiskLevel = "Medium"
+		default:
+			scores.RiskLevel = "Low"
+		}
+	}
+
+	scores.OverallScore = scores.Affluence*0.35 + scores.Liveability*0.40 + scores.FloodSafety*0.25
+	return scores
+}
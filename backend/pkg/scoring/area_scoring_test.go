@@ -0,0 +1,39 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func TestCalculateAreaScores(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+
+	// Unknown figures score as neutral
+	unknown := engine.CalculateAreaScores(&aggregator.AreaAnalysis{Area: &models.AreaData{}})
+	if unknown.Affluence != 50 || unknown.Liveability != 50 || unknown.FloodSafety != 70 || unknown.RiskLevel != "Unknown" {
+		t.Errorf("Unexpected scores without data: %+v", unknown)
+	}
+
+	affluent := &aggregator.AreaAnalysis{
+		Area:            &models.AreaData{AverageIncome: 72000, AverageWOZ: 800000},
+		GreenPercentage: 40,
+		AmenitiesScore:  80,
+		Samples:         map[string]int{"Green Spaces": 4, "Facilities & Amenities": 4, "Flood Risk": 4},
+	}
+	scores := engine.CalculateAreaScores(affluent)
+	if scores.Affluence != 100 || scores.Liveability != 64 || scores.FloodSafety != 90 || scores.RiskLevel != "Low" {
+		t.Errorf("Unexpected scores for an affluent, safe area: %+v", scores)
+	}
+
+	flooded := *affluent
+	flooded.FloodRiskShare = 0.75
+	floodScores := engine.CalculateAreaScores(&flooded)
+	if floodScores.RiskLevel != "High" || floodScores.FloodSafety != 45 {
+		t.Errorf("Unexpected flood scores: %+v", floodScores)
+	}
+	if floodScores.OverallScore >= scores.OverallScore {
+		t.Errorf("Expected flood risk to lower the overall score (%.1f >= %.1f)", floodScores.OverallScore, scores.OverallScore)
+	}
+}
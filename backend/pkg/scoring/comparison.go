@@ -0,0 +1,237 @@
+package scoring
+
+import (
+	"math"
+	"sort"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+)
+
+// Directions in which a compared metric improves
+const (
+	BetterHigher = "higher"
+	BetterLower  = "lower"
+)
+
+// Comparison is a matrix of metrics aligned with the compared properties
+type Comparison struct {
+	Metrics []ComparisonMetric `json:"metrics"`
+	// Wins counts the metrics each property ranks first on (ties included)
+	Wins []int `json:"wins"`
+}
+
+// ComparisonMetric holds one metric for every compared property, in input order.
+// Values are null where a property failed or the metric is unknown for it.
+type ComparisonMetric struct {
+	Key    string     `json:"key"`              // e.g. "esg.floodRisk", "distance.nearestStop"
+	Group  string     `json:"group"`            // overall, esg, profit, opportunity, risk, distance
+	Unit   string     `json:"unit"`             // score, eur, percent, m, level
+	Better string     `json:"better,omitempty"` // higher or lower; empty when neither is better
+	Values []*float64 `json:"values"`
+	Labels []string   `json:"labels,omitempty"` // display values for ordinal metrics such as risk level
+	// Ranks are 1 for the best value, shared on ties, and 0 where the value is
+	// unknown; omitted for metrics where neither direction is better
+	Ranks []int    `json:"ranks,omitempty"`
+	Best  *float64 `json:"best,omitempty"`
+	Worst *float64 `json:"worst,omitempty"`
+	Delta *float64 `json:"delta,omitempty"` // absolute difference between best and worst
+}
+
+// comparisonMetric extracts one metric from a property's data and scores
+type comparisonMetric struct {
+	key, group, unit, better string
+	value                    func(d *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool)
+}
+
+func scoreMetric(key, group, unit string, f func(s *PropertyScores) float64) comparisonMetric {
+	return comparisonMetric{key: key, group: group, unit: unit, better: BetterHigher,
+		value: func(_ *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool) { return f(s), true }}
+}
+
+func distanceMetric(key string, f func(d *aggregator.ComprehensivePropertyData) (float64, bool)) comparisonMetric {
+	return comparisonMetric{key: key, group: "distance", unit: "m", better: BetterLower,
+		value: func(d *aggregator.ComprehensivePropertyData, _ *PropertyScores) (float64, bool) { return f(d) }}
+}
+
+// riskLevels orders the risk levels from calculateRiskLevel, lowest first
+var riskLevels = []string{"Low", "Medium", "High", "Very High"}
+
+var comparisonMetrics = []comparisonMetric{
+	scoreMetric("overall", "overall", "score", func(s *PropertyScores) float64 { return s.OverallScore }),
+	scoreMetric("esg", "esg", "score", func(s *PropertyScores) float64 { return s.ESGScore }),
+	scoreMetric("esg.energyEfficiency", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.EnergyEfficiency }),
+	scoreMetric("esg.environmentalRisk", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.EnvironmentalRisk }),
+	scoreMetric("esg.socialLivability", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.SocialLivability }),
+	scoreMetric("esg.sustainability", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.Sustainability }),
+	scoreMetric("esg.floodRisk", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.FloodRisk }),
+	scoreMetric("esg.airQuality", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.AirQuality }),
+	scoreMetric("esg.noiseLevel", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.NoiseLevel }),
+	scoreMetric("esg.greenSpaceAccess", "esg", "score", func(s *PropertyScores) float64 { return s.Breakdown.ESG.GreenSpaceAccess }),
+	scoreMetric("profit", "profit", "score", func(s *PropertyScores) float64 { return s.ProfitScore }),
+	{key: "profit.currentValue", group: "profit", unit: "eur", value: func(_ *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool) {
+		return s.Breakdown.Profit.CurrentValue, s.Breakdown.Profit.CurrentValue > 0
+	}},
+	{key: "profit.marketValue", group: "profit", unit: "eur", value: func(_ *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool) {
+		return s.Breakdown.Profit.MarketValue, s.Breakdown.Profit.MarketValue > 0
+	}},
+	scoreMetric("profit.priceAppreciation", "profit", "score", func(s *PropertyScores) float64 { return s.Breakdown.Profit.PriceAppreciation }),
+	{key: "profit.rentalYield", group: "profit", unit: "percent", better: BetterHigher, value: func(_ *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool) {
+		return s.Breakdown.Profit.RentalYield, s.Breakdown.Profit.RentalYield > 0
+	}},
+	scoreMetric("profit.marketDemand", "profit", "score", func(s *PropertyScores) float64 { return s.Breakdown.Profit.MarketDemand }),
+	scoreMetric("profit.liquidityScore", "profit", "score", func(s *PropertyScores) float64 { return s.Breakdown.Profit.LiquidityScore }),
+	scoreMetric("profit.capitalGrowth", "profit", "score", func(s *PropertyScores) float64 { return s.Breakdown.Profit.CapitalGrowth }),
+	scoreMetric("opportunity", "opportunity", "score", func(s *PropertyScores) float64 { return s.OpportunityScore }),
+	scoreMetric("opportunity.developmentPotential", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.DevelopmentPotential }),
+	scoreMetric("opportunity.renovationROI", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.RenovationROI }),
+	scoreMetric("opportunity.energyUpgradeROI", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.EnergyUpgradeROI }),
+	scoreMetric("opportunity.neighborhoodGrowth", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.NeighborhoodGrowth }),
+	scoreMetric("opportunity.accessibility", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.Accessibility }),
+	scoreMetric("opportunity.amenitiesScore", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.AmenitiesScore }),
+	scoreMetric("opportunity.futureDevelopment", "opportunity", "score", func(s *PropertyScores) float64 { return s.Breakdown.Opportunity.FutureDevelopment }),
+	{key: "risk.level", group: "risk", unit: "level", better: BetterLower, value: func(_ *aggregator.ComprehensivePropertyData, s *PropertyScores) (float64, bool) {
+		for i, level := range riskLevels {
+			if s.RiskLevel == level {
+				return float64(i + 1), true
+			}
+		}
+		return 0, false
+	}},
+	distanceMetric("distance.nearestAmenity", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		return nearestFacility(d, func(_, _ string) bool { return true })
+	}),
+	distanceMetric("distance.nearestSupermarket", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		return nearestFacility(d, func(_, facilityType string) bool { return facilityType == "Supermarket" })
+	}),
+	distanceMetric("distance.nearestHealthcare", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		return nearestFacility(d, func(category, _ string) bool { return category == "Healthcare" })
+	}),
+	distanceMetric("distance.nearestPark", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		if d.GreenSpaces == nil || d.GreenSpaces.NearestPark == "" {
+			return 0, false
+		}
+		return d.GreenSpaces.ParkDistance, true
+	}),
+	distanceMetric("distance.nearestStop", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		return nearestStop(d, "")
+	}),
+	distanceMetric("distance.nearestTrainStation", func(d *aggregator.ComprehensivePropertyData) (float64, bool) {
+		return nearestStop(d, "Train")
+	}),
+}
+
+// nearestFacility returns the distance to the closest facility matching the filter
+func nearestFacility(d *aggregator.ComprehensivePropertyData, match func(category, facilityType string) bool) (float64, bool) {
+	if d.Facilities == nil {
+		return 0, false
+	}
+	nearest, found := math.MaxFloat64, false
+	for _, f := range d.Facilities.TopFacilities {
+		if match(f.Category, f.Type) && f.Distance < nearest {
+			nearest, found = f.Distance, true
+		}
+	}
+	return nearest, found
+}
+
+// nearestStop returns the distance to the closest public transport stop, of stopType if set
+func nearestStop(d *aggregator.ComprehensivePropertyData, stopType string) (float64, bool) {
+	if d.PublicTransport == nil {
+		return 0, false
+	}
+	nearest, found := math.MaxFloat64, false
+	for _, s := range d.PublicTransport.NearestStops {
+		if (stopType == "" || s.Type == stopType) && s.Distance < nearest {
+			nearest, found = s.Distance, true
+		}
+	}
+	return nearest, found
+}
+
+// Compare builds the comparison matrix for properties and their scores, given in the
+// same order. A nil entry (a property that could not be analysed) has no values.
+func (se *EnhancedScoringEngine) Compare(properties []*aggregator.ComprehensivePropertyData, scores []*PropertyScores) *Comparison {
+	trusted := make([]*aggregator.ComprehensivePropertyData, len(properties))
+	for i, d := range properties {
+		if d != nil && scores[i] != nil {
+			trusted[i] = d.Trusted()
+		}
+	}
+
+	comparison := &Comparison{
+		Metrics: make([]ComparisonMetric, 0, len(comparisonMetrics)),
+		Wins:    make([]int, len(properties)),
+	}
+	for _, m := range comparisonMetrics {
+		metric := ComparisonMetric{
+			Key:    m.key,
+			Group:  m.group,
+			Unit:   m.unit,
+			Better: m.better,
+			Values: make([]*float64, len(properties)),
+		}
+		for i, d := range trusted {
+			if d == nil {
+				continue
+			}
+			if v, ok := m.value(d, scores[i]); ok {
+				v = math.Round(v*10) / 10
+				metric.Values[i] = &v
+			}
+		}
+		if m.key == "risk.level" {
+			metric.Labels = make([]string, len(properties))
+			for i, v := range metric.Values {
+				if v != nil {
+					metric.Labels[i] = riskLevels[int(*v)-1]
+				}
+			}
+		}
+		rankMetric(&metric)
+		for i, rank := range metric.Ranks {
+			if rank == 1 {
+				comparison.Wins[i]++
+			}
+		}
+		comparison.Metrics = append(comparison.Metrics, metric)
+	}
+	return comparison
+}
+
+// rankMetric fills in the ranks, best, worst and delta of a metric from its values
+func rankMetric(m *ComparisonMetric) {
+	var known []int
+	for i, v := range m.Values {
+		if v != nil {
+			known = append(known, i)
+		}
+	}
+	if len(known) == 0 {
+		return
+	}
+
+	sort.SliceStable(known, func(a, b int) bool {
+		va, vb := *m.Values[known[a]], *m.Values[known[b]]
+		if m.Better == BetterLower {
+			return va < vb
+		}
+		return va > vb
+	})
+	best, worst := *m.Values[known[0]], *m.Values[known[len(known)-1]]
+	delta := math.Round(math.Abs(best-worst)*10) / 10
+	m.Delta = &delta
+	if m.Better == "" {
+		// Neither direction is better: report the spread from the highest to the lowest value
+		return
+	}
+	m.Best, m.Worst = &best, &worst
+
+	m.Ranks = make([]int, len(m.Values))
+	for pos, i := range known {
+		if pos > 0 && *m.Values[i] == *m.Values[known[pos-1]] {
+			m.Ranks[i] = m.Ranks[known[pos-1]]
+		} else {
+			m.Ranks[i] = pos + 1
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package scoring
+
+import (
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func findMetric(t *testing.T, c *Comparison, key string) ComparisonMetric {
+	t.Helper()
+	for _, m := range c.Metrics {
+		if m.Key == key {
+			return m
+		}
+	}
+	t.Fatalf("metric %s not found", key)
+	return ComparisonMetric{}
+}
+
+func TestCompare(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+
+	properties := []*aggregator.ComprehensivePropertyData{
+		{
+			FloodRisk:       &models.FloodRiskData{RiskLevel: "Low"},
+			PublicTransport: &models.OpenOVTransportData{NearestStops: []models.PublicTransportStop{{Type: "Bus", Distance: 300}, {Type: "Train", Distance: 1200}}},
+			Facilities:      &models.FacilitiesData{TopFacilities: []models.Facility{{Category: "Retail", Type: "Supermarket", Distance: 250}}},
+		},
+		nil, // failed to aggregate
+		{
+			FloodRisk:       &models.FloodRiskData{RiskLevel: "High"},
+			PublicTransport: &models.OpenOVTransportData{NearestStops: []models.PublicTransportStop{{Type: "Bus", Distance: 120}}},
+		},
+		{
+			FloodRisk:       &models.FloodRiskData{RiskLevel: "Low"},
+			PublicTransport: &models.OpenOVTransportData{NearestStops: []models.PublicTransportStop{{Type: "Tram", Distance: 300}}},
+		},
+	}
+	scores := make([]*PropertyScores, len(properties))
+	for i, d := range properties {
+		if d != nil {
+			scores[i] = engine.CalculateComprehensiveScores(d)
+		}
+	}
+
+	c := engine.Compare(properties, scores)
+
+	// Lower is better for distances; ties share a rank and unknowns have none
+	stop := findMetric(t, c, "distance.nearestStop")
+	if stop.Better != BetterLower || stop.Values[1] != nil {
+		t.Errorf("Unexpected nearestStop metric: %+v", stop)
+	}
+	if want := []int{2, 0, 1, 2}; !equalInts(stop.Ranks, want) {
+		t.Errorf("nearestStop ranks = %v, want %v", stop.Ranks, want)
+	}
+	if *stop.Best != 120 || *stop.Worst != 300 || *stop.Delta != 180 {
+		t.Errorf("nearestStop best/worst/delta = %v/%v/%v", *stop.Best, *stop.Worst, *stop.Delta)
+	}
+
+	train := findMetric(t, c, "distance.nearestTrainStation")
+	if want := []int{1, 0, 0, 0}; !equalInts(train.Ranks, want) || *train.Delta != 0 {
+		t.Errorf("Expected only the first property to have a train station, got %+v", train)
+	}
+
+	risk := findMetric(t, c, "risk.level")
+	if risk.Labels[0] != "Low" || risk.Labels[2] != "Medium" || risk.Ranks[0] != 1 || risk.Ranks[2] != 3 {
+		t.Errorf("Unexpected risk metric: labels %v ranks %v", risk.Labels, risk.Ranks)
+	}
+
+	// Neither a higher nor a lower market value is better, so it is not ranked
+	if value := findMetric(t, c, "profit.marketValue"); value.Ranks != nil || value.Best != nil {
+		t.Errorf("Expected an unranked market value, got %+v", value)
+	}
+
+	if len(c.Wins) != len(properties) || c.Wins[1] != 0 || c.Wins[0] == 0 {
+		t.Errorf("Unexpected wins %v", c.Wins)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
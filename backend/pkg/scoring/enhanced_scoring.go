@@ -5,6 +5,8 @@ import (
 	"math"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/localtax"
+	"github.com/iman-hussain/nethaddress/backend/pkg/wws"
 )
 
 // PropertyScores contains all calculated scores for a property
@@ -14,7 +16,9 @@ type PropertyScores struct {
 	OpportunityScore float64        `json:"opportunityScore"` // 0-100
 	OverallScore     float64        `json:"overallScore"`     // 0-100
 	RiskLevel        string         `json:"riskLevel"`        // Low, Medium, High, Very High
+	Profile          string         `json:"profile"`          // scoring profile the scores were weighted with
 	Breakdown        ScoreBreakdown `json:"breakdown"`
+	Explanation      Explanation    `json:"explanation"` // how each score was derived and how much real data backs it
 	Recommendations  []string       `json:"recommendations"`
 }
 
@@ -42,7 +46,9 @@ type ProfitBreakdown struct {
 	CurrentValue      float64 `json:"currentValue"`      // EUR
 	MarketValue       float64 `json:"marketValue"`       // EUR
 	PriceAppreciation float64 `json:"priceAppreciation"` // 0-100
-	RentalYield       float64 `json:"rentalYield"`       // percentage
+	RentalYield       float64 `json:"rentalYield"`       // gross percentage
+	NetRentalYield    float64 `json:"netRentalYield"`    // percentage less holding costs, 0 unless both are known
+	HoldingCosts      float64 `json:"holdingCosts"`      // EUR per year of local taxes a landlord pays
 	MarketDemand      float64 `json:"marketDemand"`      // 0-100
 	LiquidityScore    float64 `json:"liquidityScore"`    // 0-100
 	CapitalGrowth     float64 `json:"capitalGrowth"`     // 0-100
@@ -60,53 +66,104 @@ type OpportunityBreakdown struct {
 }
 
 // EnhancedScoringEngine calculates comprehensive property scores
-type EnhancedScoringEngine struct{}
+type EnhancedScoringEngine struct {
+	profiles *ProfileSet
+	taxRates *localtax.RateSet
+}
 
-// NewEnhancedScoringEngine creates a new enhanced scoring engine
+// NewEnhancedScoringEngine creates a new enhanced scoring engine with the built-in profiles
 func NewEnhancedScoringEngine() *EnhancedScoringEngine {
-	return &EnhancedScoringEngine{}
+	return NewEnhancedScoringEngineWithProfiles(BuiltinProfiles())
+}
+
+// NewEnhancedScoringEngineWithProfiles creates a scoring engine using profiles from LoadProfiles
+func NewEnhancedScoringEngineWithProfiles(profiles *ProfileSet) *EnhancedScoringEngine {
+	return &EnhancedScoringEngine{profiles: profiles, taxRates: localtax.BuiltinRates()}
+}
+
+// WithLocalTaxRates makes the engine estimate holding costs from rates loaded by
+// localtax.LoadRates instead of the built-in rates
+func (se *EnhancedScoringEngine) WithLocalTaxRates(rates *localtax.RateSet) *EnhancedScoringEngine {
+	se.taxRates = rates
+	return se
+}
+
+// Profiles returns the scoring profiles the engine can weight scores with
+func (se *EnhancedScoringEngine) Profiles() *ProfileSet {
+	return se.profiles
+}
+
+// LocalTaxRates returns the municipal tax rates holding costs are estimated with
+func (se *EnhancedScoringEngine) LocalTaxRates() *localtax.RateSet {
+	return se.taxRates
 }
 
 // CalculateComprehensiveScores computes all scores for comprehensive property data
+// using the default profile.
+// Placeholder, empty and fallback values are ignored so they score as unknown.
 func (se *EnhancedScoringEngine) CalculateComprehensiveScores(data *aggregator.ComprehensivePropertyData) *PropertyScores {
+	return se.CalculateScoresWithProfile(data, se.profiles.Default())
+}
+
+// CalculateScoresWithProfile computes all scores weighted by the given profile
+func (se *EnhancedScoringEngine) CalculateScoresWithProfile(data *aggregator.ComprehensivePropertyData, profile *ScoringProfile) *PropertyScores {
+	data = data.Trusted()
+
 	scores := &PropertyScores{
+		Profile:         profile.Name,
 		Breakdown:       ScoreBreakdown{},
 		Recommendations: []string{},
 	}
 
 	// Calculate ESG Score
-	scores.ESGScore, scores.Breakdown.ESG = se.calculateESGScore(data)
+	scores.ESGScore, scores.Breakdown.ESG, scores.Explanation.ESG = se.calculateESGScore(data, profile.Weights)
 
 	// Calculate Profit Score
-	scores.ProfitScore, scores.Breakdown.Profit = se.calculateProfitScore(data)
+	scores.ProfitScore, scores.Breakdown.Profit, scores.Explanation.Profit = se.calculateProfitScore(data, profile.Weights.Profit)
 
 	// Calculate Opportunity Score
-	scores.OpportunityScore, scores.Breakdown.Opportunity = se.calculateOpportunityScore(data)
+	scores.OpportunityScore, scores.Breakdown.Opportunity, scores.Explanation.Opportunity = se.calculateOpportunityScore(data, profile.Weights.Opportunity)
 
 	// Calculate Overall Score (weighted average)
-	scores.OverallScore = (scores.ESGScore*0.3 + scores.ProfitScore*0.4 + scores.OpportunityScore*0.3)
+	scores.Explanation.Overall = explainOverall(profile.Weights.Overall, scores.Explanation.ESG, scores.Explanation.Profit, scores.Explanation.Opportunity)
+	scores.OverallScore = scores.Explanation.Overall.Score
 
 	// Determine Risk Level
-	scores.RiskLevel = se.calculateRiskLevel(data)
+	scores.RiskLevel = se.calculateRiskLevel(data, profile.Thresholds)
 
 	// Generate Recommendations
-	scores.Recommendations = se.generateRecommendations(data, scores)
+	scores.Recommendations = se.generateRecommendations(data, scores, profile.Thresholds)
 
 	return scores
 }
 
-func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.ComprehensivePropertyData) (float64, ESGBreakdown) {
+func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.ComprehensivePropertyData, pw ProfileWeights) (float64, ESGBreakdown, ScoreExplanation) {
 	breakdown := ESGBreakdown{}
-
-	// Energy Efficiency (from energy label)
-	if data.EnergyClimate != nil {
-		breakdown.EnergyEfficiency = se.energyLabelToScore(data.EnergyClimate.EnergyLabel)
+	w := pw.ESG
+
+	// Energy Efficiency (from energy label, else estimated from the BAG construction year)
+	label, energyInputs, hasLabel := preferredEnergyLabel(data)
+	energyKnown := false
+	if hasLabel {
+		breakdown.EnergyEfficiency = se.energyLabelToScore(label)
+		_, energyKnown = energyLabelScores[label]
+	} else if data.BAGBuilding != nil && data.BAGBuilding.ConstructionYear > 0 {
+		breakdown.EnergyEfficiency = se.constructionYearToScore(data.BAGBuilding.ConstructionYear)
+		energyInputs = map[string]any{"constructionYear": data.BAGBuilding.ConstructionYear}
+		energyKnown = true
 	} else {
 		breakdown.EnergyEfficiency = 50.0 // Neutral if unknown
 	}
 
 	// Environmental Risk (inverse of contamination, subsidence, etc.)
 	envRisk := 100.0
+	envInputs := map[string]any{}
+	if data.SoilQuality != nil {
+		envInputs["contaminationLevel"] = data.SoilQuality.ContaminationLevel
+	}
+	if data.Subsidence != nil {
+		envInputs["stabilityRating"] = data.Subsidence.StabilityRating
+	}
 	if data.SoilQuality != nil && data.SoilQuality.ContaminationLevel == "Severe" {
 		envRisk -= 40
 	} else if data.SoilQuality != nil && data.SoilQuality.ContaminationLevel == "Moderate" {
@@ -119,28 +176,40 @@ func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.Comprehensiv
 
 	// Social Livability (safety + amenities + education)
 	livability := 50.0
+	livabilityInputs := map[string]any{}
 	if data.Safety != nil {
-		livability = data.Safety.SafetyScore * 0.4
+		livability = data.Safety.SafetyScore * pw.SocialLivability.Safety
+		livabilityInputs["safetyScore"] = data.Safety.SafetyScore
 	}
 	if data.Facilities != nil {
-		livability += data.Facilities.AmenitiesScore * 0.3
+		livability += data.Facilities.AmenitiesScore * pw.SocialLivability.Amenities
+		livabilityInputs["amenitiesScore"] = data.Facilities.AmenitiesScore
 	}
 	if data.Education != nil {
-		livability += data.Education.AverageQuality * 10 * 0.3
+		livability += data.Education.AverageQuality * 10 * pw.SocialLivability.Education
+		livabilityInputs["educationQuality"] = data.Education.AverageQuality
 	}
 	breakdown.SocialLivability = math.Min(100, livability)
 
 	// Sustainability (solar potential + energy efficiency)
-	sustainability := breakdown.EnergyEfficiency * 0.6
+	sustainability := breakdown.EnergyEfficiency * pw.Sustainability.EnergyEfficiency
+	sustainabilityInputs := map[string]any{"energyEfficiency": breakdown.EnergyEfficiency}
+	solarKnown := false
 	if data.SolarPotential != nil && data.SolarPotential.SolarRadiation > 0 {
 		// Normalize solar radiation (good values 400-600 W/m²)
 		solarScore := math.Min(100, (data.SolarPotential.SolarRadiation/600.0)*100)
-		sustainability += solarScore * 0.4
+		sustainability += solarScore * pw.Sustainability.SolarPotential
+		sustainabilityInputs["solarRadiation"] = data.SolarPotential.SolarRadiation
+		solarKnown = true
 	}
 	breakdown.Sustainability = sustainability
 
 	// Flood Risk (inverse - higher score is safer)
+	floodInputs := map[string]any{}
+	floodKnown := false
 	if data.FloodRisk != nil {
+		floodInputs["riskLevel"] = data.FloodRisk.RiskLevel
+		floodKnown = true
 		switch data.FloodRisk.RiskLevel {
 		case "Low":
 			breakdown.FloodRisk = 90
@@ -152,24 +221,29 @@ func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.Comprehensiv
 			breakdown.FloodRisk = 10
 		default:
 			breakdown.FloodRisk = 70
+			floodKnown = false
 		}
 	} else {
 		breakdown.FloodRisk = 70 // Assume moderate if unknown
 	}
 
 	// Air Quality (AQI to score - lower AQI is better)
+	airInputs := map[string]any{}
 	if data.AirQuality != nil {
 		// AQI: 0-50 Good, 51-100 Moderate, 101+ Unhealthy
 		aqi := float64(data.AirQuality.AQI)
 		breakdown.AirQuality = math.Max(0, 100-(aqi*0.8))
+		airInputs["aqi"] = data.AirQuality.AQI
 	} else {
 		breakdown.AirQuality = 70
 	}
 
 	// Noise Level (inverse - higher score is quieter)
+	noiseInputs := map[string]any{}
 	if data.NoisePollution != nil {
 		// Noise below 50 dB is good, above 65 is bad
 		noise := data.NoisePollution.TotalNoise
+		noiseInputs["totalNoiseDb"] = noise
 		if noise < 50 {
 			breakdown.NoiseLevel = 100
 		} else if noise < 55 {
@@ -186,8 +260,11 @@ func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.Comprehensiv
 	}
 
 	// Green Space Access
+	greenInputs := map[string]any{}
 	if data.GreenSpaces != nil {
 		breakdown.GreenSpaceAccess = data.GreenSpaces.GreenPercentage
+		greenInputs["greenPercentage"] = data.GreenSpaces.GreenPercentage
+		greenInputs["parkDistance"] = data.GreenSpaces.ParkDistance
 		// Boost if park is nearby
 		if data.GreenSpaces.ParkDistance < 500 {
 			breakdown.GreenSpaceAccess = math.Min(100, breakdown.GreenSpaceAccess+20)
@@ -197,19 +274,21 @@ func (se *EnhancedScoringEngine) calculateESGScore(data *aggregator.Comprehensiv
 	}
 
 	// Calculate overall ESG score (weighted average)
-	esgScore := (breakdown.EnergyEfficiency*0.20 +
-		breakdown.EnvironmentalRisk*0.15 +
-		breakdown.SocialLivability*0.15 +
-		breakdown.Sustainability*0.15 +
-		breakdown.FloodRisk*0.10 +
-		breakdown.AirQuality*0.10 +
-		breakdown.NoiseLevel*0.10 +
-		breakdown.GreenSpaceAccess*0.05)
-
-	return esgScore, breakdown
+	explanation := explain(
+		factor("energyEfficiency", breakdown.EnergyEfficiency, w.EnergyEfficiency, energyKnown, energyInputs),
+		factor("environmentalRisk", breakdown.EnvironmentalRisk, w.EnvironmentalRisk, len(envInputs) > 0, envInputs),
+		factor("socialLivability", breakdown.SocialLivability, w.SocialLivability, len(livabilityInputs) > 0, livabilityInputs),
+		factor("sustainability", breakdown.Sustainability, w.Sustainability, energyKnown || solarKnown, sustainabilityInputs),
+		factor("floodRisk", breakdown.FloodRisk, w.FloodRisk, floodKnown, floodInputs),
+		factor("airQuality", breakdown.AirQuality, w.AirQuality, data.AirQuality != nil, airInputs),
+		factor("noiseLevel", breakdown.NoiseLevel, w.NoiseLevel, data.NoisePollution != nil, noiseInputs),
+		factor("greenSpaceAccess", breakdown.GreenSpaceAccess, w.GreenSpaceAccess, data.GreenSpaces != nil, greenInputs),
+	)
+
+	return explanation.Score, breakdown, explanation
 }
 
-func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.ComprehensivePropertyData) (float64, ProfitBreakdown) {
+func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.ComprehensivePropertyData, w ProfitWeights) (float64, ProfitBreakdown, ScoreExplanation) {
 	breakdown := ProfitBreakdown{}
 
 	// Current Value (WOZ or Kadaster)
@@ -227,10 +306,15 @@ func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.Comprehen
 	}
 
 	// Price Appreciation (from transaction history)
+	appreciationInputs := map[string]any{}
+	appreciationKnown := false
 	if data.TransactionHistory != nil && len(data.TransactionHistory.Transactions) > 0 {
 		// Calculate average annual appreciation
 		firstPrice := data.TransactionHistory.Transactions[len(data.TransactionHistory.Transactions)-1].PurchasePrice
 		currentPrice := breakdown.MarketValue
+		appreciationInputs["firstPurchasePrice"] = firstPrice
+		appreciationInputs["marketValue"] = currentPrice
+		appreciationKnown = firstPrice > 0 && currentPrice > 0
 		if firstPrice > 0 && currentPrice > firstPrice {
 			appreciation := ((currentPrice - firstPrice) / firstPrice) * 100
 			breakdown.PriceAppreciation = math.Min(100, appreciation*2) // Scale to 0-100
@@ -241,14 +325,53 @@ func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.Comprehen
 		breakdown.PriceAppreciation = 50
 	}
 
-	// Rental Yield (estimate based on location and type)
-	if breakdown.MarketValue > 0 {
-		// Netherlands average rental yield is 3-5%
+	// Holding Costs: the OZB and rioolheffing a landlord pays on the WOZ value
+	yieldInputs := map[string]any{}
+	if rates, err := se.taxRates.ForYear(0); err == nil {
+		if taxes, err := localtax.Calculate(rates, localtax.FromProperty(data)); err == nil {
+			breakdown.HoldingCosts = taxes.Landlord.Total
+			yieldInputs["holdingCosts"] = taxes.Landlord.Total
+			yieldInputs["taxMunicipality"] = taxes.Municipality
+		}
+	}
+
+	// Rental Yield: the WWS maximum rent against the market or WOZ value, else the
+	// Netherlands average of 3-5%. It is scored net of holding costs when both are known.
+	yieldKnown, netKnown := false, false
+	if valuation, err := wws.Calculate(wws.FromProperty(data)); err == nil && valuation.GrossYield > 0 {
+		breakdown.RentalYield = valuation.GrossYield
+		if breakdown.HoldingCosts > 0 {
+			breakdown.NetRentalYield = math.Round((valuation.AnnualRent-breakdown.HoldingCosts)/valuation.Value*10000) / 100
+			netKnown = true
+			yieldInputs["netRentalYieldPercent"] = breakdown.NetRentalYield
+		}
+		yieldKnown = true
+		yieldInputs["wwsPoints"] = valuation.Points
+		yieldInputs["maxRent"] = valuation.MaxRent
+		yieldInputs["segment"] = valuation.Segment
+		yieldInputs["valueSource"] = valuation.ValueSource
+		yieldInputs["grossYieldPercent"] = valuation.GrossYield
+	} else if breakdown.MarketValue > 0 {
 		breakdown.RentalYield = 4.0 // Default estimate
 	}
+	yieldInputs["rentalYieldPercent"] = breakdown.RentalYield
+	scoredYield := breakdown.RentalYield
+	if netKnown {
+		scoredYield = breakdown.NetRentalYield
+	}
 
 	// Market Demand (based on demographics and building activity)
 	demand := 50.0
+	demandInputs := map[string]any{}
+	if data.Population != nil {
+		demandInputs["population"] = data.Population.TotalPopulation
+	}
+	if data.BuildingPermits != nil {
+		demandInputs["growthTrend"] = data.BuildingPermits.GrowthTrend
+	}
+	if data.StatLineData != nil {
+		demandInputs["employmentRate"] = data.StatLineData.EmploymentRate
+	}
 	if data.Population != nil && data.Population.TotalPopulation > 10000 {
 		demand += 20
 	}
@@ -262,6 +385,16 @@ func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.Comprehen
 
 	// Liquidity Score (based on location desirability)
 	liquidity := 50.0
+	liquidityInputs := map[string]any{}
+	if data.PublicTransport != nil {
+		liquidityInputs["nearbyStops"] = len(data.PublicTransport.NearestStops)
+	}
+	if data.Facilities != nil {
+		liquidityInputs["amenitiesScore"] = data.Facilities.AmenitiesScore
+	}
+	if data.StatLineData != nil {
+		liquidityInputs["averageIncome"] = data.StatLineData.AverageIncome
+	}
 	if data.PublicTransport != nil && len(data.PublicTransport.NearestStops) > 2 {
 		liquidity += 15
 	}
@@ -275,7 +408,11 @@ func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.Comprehen
 
 	// Capital Growth (neighborhood trends)
 	growth := 50.0
+	growthInputs := map[string]any{}
+	growthKnown := false
 	if data.BuildingPermits != nil {
+		growthInputs["growthTrend"] = data.BuildingPermits.GrowthTrend
+		growthKnown = true
 		switch data.BuildingPermits.GrowthTrend {
 		case "Increasing":
 			growth = 80
@@ -283,27 +420,35 @@ func (se *EnhancedScoringEngine) calculateProfitScore(data *aggregator.Comprehen
 			growth = 60
 		case "Decreasing":
 			growth = 30
+		default:
+			growthKnown = false
 		}
 	}
 	breakdown.CapitalGrowth = growth
 
 	// Calculate overall profit score
-	profitScore := ((breakdown.PriceAppreciation)*0.25 +
-		(breakdown.MarketDemand)*0.25 +
-		(breakdown.LiquidityScore)*0.20 +
-		(breakdown.CapitalGrowth)*0.20 +
-		(breakdown.RentalYield*10)*0.10) // Scale rental yield to 0-100
-
-	return profitScore, breakdown
+	explanation := explain(
+		factor("priceAppreciation", breakdown.PriceAppreciation, w.PriceAppreciation, appreciationKnown, appreciationInputs),
+		factor("marketDemand", breakdown.MarketDemand, w.MarketDemand, len(demandInputs) > 0, demandInputs),
+		factor("liquidityScore", breakdown.LiquidityScore, w.LiquidityScore, len(liquidityInputs) > 0, liquidityInputs),
+		factor("capitalGrowth", breakdown.CapitalGrowth, w.CapitalGrowth, growthKnown, growthInputs),
+		// Scale rental yield to 0-100: 10% or more scores full marks
+		factor("rentalYield", math.Max(0, math.Min(100, scoredYield*10)), w.RentalYield, yieldKnown, yieldInputs),
+	)
+
+	return explanation.Score, breakdown, explanation
 }
 
-func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.ComprehensivePropertyData) (float64, OpportunityBreakdown) {
+func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.ComprehensivePropertyData, w OpportunityWeights) (float64, OpportunityBreakdown, ScoreExplanation) {
 	breakdown := OpportunityBreakdown{}
 
 	// Development Potential (zoning and building rights)
 	development := 50.0
+	developmentInputs := map[string]any{}
 	if data.LandUse != nil {
 		if data.LandUse.BuildingRights != nil {
+			developmentInputs["canExpand"] = data.LandUse.BuildingRights.CanExpand
+			developmentInputs["canSubdivide"] = data.LandUse.BuildingRights.CanSubdivide
 			if data.LandUse.BuildingRights.CanExpand {
 				development += 25
 			}
@@ -312,12 +457,22 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 			}
 		}
 	}
+	// Room to extend: a single-unit building covering little of its parcel
+	if data.BAGBuilding != nil && data.BAGBuilding.UnitsInBuilding <= 1 && data.BAGBuilding.FootprintArea > 0 &&
+		data.PDOKData != nil && data.PDOKData.CadastralData != nil && data.PDOKData.CadastralData.Area > 0 {
+		coverage := data.BAGBuilding.FootprintArea / data.PDOKData.CadastralData.Area
+		developmentInputs["plotCoverage"] = coverage
+		if coverage < 0.4 {
+			development += 10
+		}
+	}
 	breakdown.DevelopmentPotential = math.Min(100, development)
 
 	// Renovation ROI (based on current condition and energy label)
 	renovation := 50.0
-	if data.EnergyClimate != nil {
-		label := data.EnergyClimate.EnergyLabel
+	renovationInputs := map[string]any{}
+	if label, _, ok := preferredEnergyLabel(data); ok {
+		renovationInputs["energyLabel"] = label
 		if label == "E" || label == "F" || label == "G" {
 			renovation = 85 // High ROI for poor energy labels
 		} else if label == "D" || label == "C" {
@@ -325,11 +480,26 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 		} else {
 			renovation = 30 // Low ROI if already efficient
 		}
+	} else if data.BAGBuilding != nil && data.BAGBuilding.ConstructionYear > 0 {
+		// Older buildings have more to gain from renovation
+		renovationInputs["constructionYear"] = data.BAGBuilding.ConstructionYear
+		switch year := data.BAGBuilding.ConstructionYear; {
+		case year < 1975:
+			renovation = 80
+		case year < 1992:
+			renovation = 65
+		case year < 2006:
+			renovation = 50
+		default:
+			renovation = 30
+		}
 	}
 	breakdown.RenovationROI = renovation
 
 	// Energy Upgrade ROI (from sustainability data)
+	upgradeInputs := map[string]any{}
 	if data.Sustainability != nil {
+		upgradeInputs["paybackPeriodYears"] = data.Sustainability.PaybackPeriod
 		if data.Sustainability.PaybackPeriod > 0 && data.Sustainability.PaybackPeriod < 10 {
 			breakdown.EnergyUpgradeROI = 100 - (data.Sustainability.PaybackPeriod * 10)
 		} else if data.Sustainability.PaybackPeriod >= 10 {
@@ -343,13 +513,17 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 
 	// Neighborhood Growth
 	growth := 50.0
+	growthInputs := map[string]any{}
 	if data.BuildingPermits != nil {
+		growthInputs["newConstruction"] = data.BuildingPermits.NewConstruction
+		growthInputs["growthTrend"] = data.BuildingPermits.GrowthTrend
 		growth = float64(data.BuildingPermits.NewConstruction) / 10.0 // Scale based on permits
 		if data.BuildingPermits.GrowthTrend == "Increasing" {
 			growth += 30
 		}
 	}
 	if data.StatLineData != nil {
+		growthInputs["population"] = data.StatLineData.Population
 		if data.StatLineData.Population > 50000 {
 			growth += 10
 		}
@@ -358,8 +532,10 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 
 	// Accessibility
 	accessibility := 50.0
+	accessibilityInputs := map[string]any{}
 	if data.PublicTransport != nil {
 		stopCount := len(data.PublicTransport.NearestStops)
+		accessibilityInputs["nearbyStops"] = stopCount
 		accessibility = math.Min(100, 50+float64(stopCount)*10)
 	}
 	if len(data.TrafficData) > 0 {
@@ -369,6 +545,7 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 			avgSpeed += traffic.AverageSpeed
 		}
 		avgSpeed /= float64(len(data.TrafficData))
+		accessibilityInputs["averageTrafficSpeed"] = avgSpeed
 		if avgSpeed > 40 {
 			accessibility = math.Min(100, accessibility+10)
 		}
@@ -376,14 +553,20 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 	breakdown.Accessibility = accessibility
 
 	// Amenities Score
+	amenitiesInputs := map[string]any{}
 	if data.Facilities != nil {
 		breakdown.AmenitiesScore = data.Facilities.AmenitiesScore
+		amenitiesInputs["facilities"] = len(data.Facilities.TopFacilities)
 	} else {
 		breakdown.AmenitiesScore = 50
 	}
 
 	// Future Development (from land use plans)
 	futureDev := 50.0
+	futureInputs := map[string]any{}
+	if data.LandUse != nil {
+		futureInputs["futurePlans"] = len(data.LandUse.FuturePlans)
+	}
 	if data.LandUse != nil && len(data.LandUse.FuturePlans) > 0 {
 		for _, plan := range data.LandUse.FuturePlans {
 			if plan.Status == "Approved" && plan.Impact == "Positive" {
@@ -394,18 +577,20 @@ func (se *EnhancedScoringEngine) calculateOpportunityScore(data *aggregator.Comp
 	breakdown.FutureDevelopment = math.Min(100, futureDev)
 
 	// Calculate overall opportunity score
-	opportunityScore := (breakdown.DevelopmentPotential*0.20 +
-		breakdown.RenovationROI*0.15 +
-		breakdown.EnergyUpgradeROI*0.15 +
-		breakdown.NeighborhoodGrowth*0.15 +
-		breakdown.Accessibility*0.15 +
-		breakdown.AmenitiesScore*0.10 +
-		breakdown.FutureDevelopment*0.10)
-
-	return opportunityScore, breakdown
+	explanation := explain(
+		factor("developmentPotential", breakdown.DevelopmentPotential, w.DevelopmentPotential, len(developmentInputs) > 0, developmentInputs),
+		factor("renovationROI", breakdown.RenovationROI, w.RenovationROI, len(renovationInputs) > 0, renovationInputs),
+		factor("energyUpgradeROI", breakdown.EnergyUpgradeROI, w.EnergyUpgradeROI, data.Sustainability != nil && data.Sustainability.PaybackPeriod > 0, upgradeInputs),
+		factor("neighborhoodGrowth", breakdown.NeighborhoodGrowth, w.NeighborhoodGrowth, len(growthInputs) > 0, growthInputs),
+		factor("accessibility", breakdown.Accessibility, w.Accessibility, len(accessibilityInputs) > 0, accessibilityInputs),
+		factor("amenitiesScore", breakdown.AmenitiesScore, w.AmenitiesScore, data.Facilities != nil, amenitiesInputs),
+		factor("futureDevelopment", breakdown.FutureDevelopment, w.FutureDevelopment, data.LandUse != nil, futureInputs),
+	)
+
+	return explanation.Score, breakdown, explanation
 }
 
-func (se *EnhancedScoringEngine) calculateRiskLevel(data *aggregator.ComprehensivePropertyData) string {
+func (se *EnhancedScoringEngine) calculateRiskLevel(data *aggregator.ComprehensivePropertyData, t ProfileThresholds) string {
 	riskPoints := 0
 
 	// Flood risk
@@ -444,21 +629,21 @@ func (se *EnhancedScoringEngine) calculateRiskLevel(data *aggregator.Comprehensi
 	}
 
 	// Categorize risk
-	if riskPoints >= 6 {
+	if riskPoints >= t.RiskVeryHigh {
 		return "Very High"
-	} else if riskPoints >= 4 {
+	} else if riskPoints >= t.RiskHigh {
 		return "High"
-	} else if riskPoints >= 2 {
+	} else if riskPoints >= t.RiskMedium {
 		return "Medium"
 	}
 	return "Low"
 }
 
-func (se *EnhancedScoringEngine) generateRecommendations(data *aggregator.ComprehensivePropertyData, scores *PropertyScores) []string {
+func (se *EnhancedScoringEngine) generateRecommendations(data *aggregator.ComprehensivePropertyData, scores *PropertyScores, t ProfileThresholds) []string {
 	recommendations := []string{}
 
 	// Energy recommendations
-	if scores.Breakdown.ESG.EnergyEfficiency < 60 {
+	if scores.Breakdown.ESG.EnergyEfficiency < t.LowEnergyEfficiency {
 		recommendations = append(recommendations, "Consider energy efficiency improvements (insulation, double glazing, solar panels)")
 	}
 
@@ -467,55 +652,98 @@ func (se *EnhancedScoringEngine) generateRecommendations(data *aggregator.Compre
 		recommendations = append(recommendations, fmt.Sprintf("Energy upgrades could save €%.0f/year", data.Sustainability.TotalCostSavings))
 	}
 
+	// An expired label must be renewed before the property is sold or let
+	if data.EnergyLabel != nil && data.EnergyLabel.Expired {
+		recommendations = append(recommendations, fmt.Sprintf("Registered energy label expired on %s - a new label is required to sell or let", data.EnergyLabel.ValidUntil))
+	}
+
 	// Flood risk
 	if data.FloodRisk != nil && (data.FloodRisk.RiskLevel == "High" || data.FloodRisk.RiskLevel == "Very High") {
 		recommendations = append(recommendations, "High flood risk - ensure comprehensive insurance coverage")
 	}
 
 	// Development opportunities
-	if scores.Breakdown.Opportunity.DevelopmentPotential > 70 {
+	if scores.Breakdown.Opportunity.DevelopmentPotential > t.HighDevelopmentPotential {
 		recommendations = append(recommendations, "Property has significant development potential - check zoning regulations")
 	}
 
 	// Market timing
-	if scores.ProfitScore > 75 {
+	if scores.ProfitScore > t.StrongMarket {
 		recommendations = append(recommendations, "Strong market conditions - good time for investment or sale")
-	} else if scores.ProfitScore < 40 {
+	} else if scores.ProfitScore < t.WeakMarket {
 		recommendations = append(recommendations, "Weak market indicators - consider holding or substantial improvements")
 	}
 
 	// Accessibility
-	if scores.Breakdown.Opportunity.Accessibility < 50 {
+	if scores.Breakdown.Opportunity.Accessibility < t.LowAccessibility {
 		recommendations = append(recommendations, "Limited accessibility may affect resale value")
 	}
 
 	// Capital improvements
-	if scores.Breakdown.Opportunity.RenovationROI > 70 {
+	if scores.Breakdown.Opportunity.RenovationROI > t.HighRenovationROI {
 		recommendations = append(recommendations, "High ROI potential for renovations - prioritize kitchen and bathroom upgrades")
 	}
 
 	return recommendations
 }
 
+// preferredEnergyLabel returns the property's energy label and the inputs it came
+// from, preferring the official EP-Online registration (or its voorlopig label) over Altum
+func preferredEnergyLabel(data *aggregator.ComprehensivePropertyData) (string, map[string]any, bool) {
+	if l := data.EnergyLabel; l != nil {
+		if l.Label != "" {
+			inputs := map[string]any{"energyLabel": l.Label, "source": "EP-Online", "registrationDate": l.RegistrationDate}
+			if l.EP2 > 0 {
+				inputs["ep2"] = l.EP2
+			}
+			if l.Expired {
+				inputs["expired"] = true
+			}
+			return l.Label, inputs, true
+		}
+		if l.ProvisionalLabel != nil {
+			return l.ProvisionalLabel.Label, map[string]any{"energyLabel": l.ProvisionalLabel.Label, "source": "EP-Online", "provisional": true}, true
+		}
+	}
+	if data.EnergyClimate != nil {
+		return data.EnergyClimate.EnergyLabel, map[string]any{"energyLabel": data.EnergyClimate.EnergyLabel, "source": "Altum"}, true
+	}
+	return "", nil, false
+}
+
+// energyLabelScores maps energy labels to an energy efficiency score
+var energyLabelScores = map[string]float64{
+	"A++++": 95, "A+++": 95, "A++": 95, "A+": 95,
+	"A": 85,
+	"B": 75,
+	"C": 60,
+	"D": 45,
+	"E": 30,
+	"F": 20,
+	"G": 10,
+}
+
+// energyLabelToScore scores an energy label, or 50 (neutral) for an unknown label
 func (se *EnhancedScoringEngine) energyLabelToScore(label string) float64 {
-	switch label {
-	case "A++++", "A+++", "A++", "A+":
-		return 95
-	case "A":
+	if score, ok := energyLabelScores[label]; ok {
+		return score
+	}
+	return 50
+}
+
+// constructionYearToScore estimates energy efficiency from the construction year,
+// following the tightening of Dutch insulation requirements in the building code
+func (se *EnhancedScoringEngine) constructionYearToScore(year int) float64 {
+	switch {
+	case year >= 2015:
 		return 85
-	case "B":
-		return 75
-	case "C":
+	case year >= 2006:
+		return 70
+	case year >= 1992:
 		return 60
-	case "D":
+	case year >= 1975:
 		return 45
-	case "E":
-		return 30
-	case "F":
-		return 20
-	case "G":
-		return 10
 	default:
-		return 50
+		return 30
 	}
 }
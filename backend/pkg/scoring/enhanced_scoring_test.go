@@ -1,6 +1,7 @@
 package scoring
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
@@ -114,10 +115,76 @@ func TestCalculateRiskLevel(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			riskLevel := engine.calculateRiskLevel(tt.data)
+			riskLevel := engine.calculateRiskLevel(tt.data, BuiltinProfiles().Default().Thresholds)
 			if riskLevel != tt.expected {
 				t.Errorf("Expected risk level '%s', got '%s'", tt.expected, riskLevel)
 			}
 		})
 	}
 }
+
+func TestCalculateComprehensiveScores_BAGBuilding(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+
+	old := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		BAGBuilding: &models.BAGBuildingData{ConstructionYear: 1930, UnitsInBuilding: 1, FootprintArea: 60},
+		PDOKData:    &models.PDOKPlatformData{CadastralData: &models.CadastralInfo{Area: 400}},
+	})
+	recent := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		BAGBuilding: &models.BAGBuildingData{ConstructionYear: 2018, UnitsInBuilding: 40, FootprintArea: 900},
+	})
+
+	if old.Breakdown.ESG.EnergyEfficiency >= recent.Breakdown.ESG.EnergyEfficiency {
+		t.Errorf("Expected a 2018 building to score as more efficient than a 1930 one (%f vs %f)",
+			recent.Breakdown.ESG.EnergyEfficiency, old.Breakdown.ESG.EnergyEfficiency)
+	}
+	if old.Breakdown.Opportunity.RenovationROI != 80 || recent.Breakdown.Opportunity.RenovationROI != 30 {
+		t.Errorf("Unexpected renovation ROI by age: %f (1930), %f (2018)",
+			old.Breakdown.Opportunity.RenovationROI, recent.Breakdown.Opportunity.RenovationROI)
+	}
+	if old.Breakdown.Opportunity.DevelopmentPotential != 60 || recent.Breakdown.Opportunity.DevelopmentPotential != 50 {
+		t.Errorf("Expected extension room only for the detached house, got %f and %f",
+			old.Breakdown.Opportunity.DevelopmentPotential, recent.Breakdown.Opportunity.DevelopmentPotential)
+	}
+
+	// A known energy label takes precedence over the age estimate
+	labelled := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		EnergyClimate: &models.EnergyClimateData{EnergyLabel: "A"},
+		BAGBuilding:   &models.BAGBuildingData{ConstructionYear: 1930},
+	})
+	if labelled.Breakdown.ESG.EnergyEfficiency != 85 {
+		t.Errorf("Expected energy label score 85, got %f", labelled.Breakdown.ESG.EnergyEfficiency)
+	}
+}
+
+func TestCalculateComprehensiveScores_OfficialEnergyLabel(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+
+	// The EP-Online registration takes precedence over Altum's label
+	scores := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		EnergyLabel:   &models.EnergyLabelData{Label: "A", EP2: 143, ValidUntil: "2020-01-01", Expired: true},
+		EnergyClimate: &models.EnergyClimateData{EnergyLabel: "E"},
+	})
+	if scores.Breakdown.ESG.EnergyEfficiency != 85 || scores.Breakdown.Opportunity.RenovationROI != 30 {
+		t.Errorf("Expected the official A label to be scored, got %+v", scores.Breakdown)
+	}
+	energy := findFactor(t, scores.Explanation.ESG, "energyEfficiency")
+	if energy.Inputs["source"] != "EP-Online" || energy.Inputs["ep2"] != 143.0 || energy.Inputs["expired"] != true {
+		t.Errorf("Unexpected energy inputs %v", energy.Inputs)
+	}
+	found := false
+	for _, r := range scores.Recommendations {
+		found = found || strings.Contains(r, "expired on 2020-01-01")
+	}
+	if !found {
+		t.Errorf("Expected a recommendation to renew the expired label, got %v", scores.Recommendations)
+	}
+
+	// A voorlopig label is used when no definitive label is registered
+	provisional := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		EnergyLabel: &models.EnergyLabelData{ProvisionalLabel: &models.ProvisionalEnergyLabel{Label: "G"}},
+	})
+	if provisional.Breakdown.ESG.EnergyEfficiency != 10 {
+		t.Errorf("Expected the voorlopig G label to be scored, got %f", provisional.Breakdown.ESG.EnergyEfficiency)
+	}
+}
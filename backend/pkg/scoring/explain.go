@@ -0,0 +1,85 @@
+package scoring
+
+// Explanation shows how each top-level score was built from its weighted factors
+type Explanation struct {
+	Overall     ScoreExplanation `json:"overall"`
+	ESG         ScoreExplanation `json:"esg"`
+	Profit      ScoreExplanation `json:"profit"`
+	Opportunity ScoreExplanation `json:"opportunity"`
+}
+
+// ScoreExplanation breaks a score down into the factors it is the weighted sum of
+type ScoreExplanation struct {
+	Score float64 `json:"score"`
+	// Confidence is the share of the weight (0-1) backed by real data rather than
+	// neutral defaults; for the overall score it is the weighted mean of the others
+	Confidence float64       `json:"confidence"`
+	Factors    []ScoreFactor `json:"factors"`
+}
+
+// ScoreFactor is one weighted component of a score
+type ScoreFactor struct {
+	Key          string  `json:"key"`          // breakdown field, e.g. "energyEfficiency"
+	Value        float64 `json:"value"`        // 0-100
+	Weight       float64 `json:"weight"`       // from the scoring profile
+	Contribution float64 `json:"contribution"` // value × weight: points added to the parent score
+	// Default is true when no data was available and a neutral value was used
+	Default bool `json:"default"`
+	// Inputs are the data values the factor was derived from
+	Inputs map[string]any `json:"inputs,omitempty"`
+}
+
+// factor describes a component; known reports whether it was derived from real data
+func factor(key string, value, weight float64, known bool, inputs map[string]any) ScoreFactor {
+	if len(inputs) == 0 {
+		inputs = nil
+	}
+	return ScoreFactor{Key: key, Value: value, Weight: weight, Default: !known, Inputs: inputs}
+}
+
+// explain sums the weighted factors into a score and rates how much of it rests on real data
+func explain(factors ...ScoreFactor) ScoreExplanation {
+	e := ScoreExplanation{Factors: factors}
+	total, known := 0.0, 0.0
+	for i := range e.Factors {
+		f := &e.Factors[i]
+		f.Contribution = f.Value * f.Weight
+		e.Score += f.Contribution
+		total += f.Weight
+		if !f.Default {
+			known += f.Weight
+		}
+	}
+	if total > 0 {
+		e.Confidence = known / total
+	}
+	return e
+}
+
+// explainOverall combines the ESG, profit and opportunity explanations with the
+// profile's overall weights. A component only counts as a default when none of
+// its own factors had data.
+func explainOverall(w OverallWeights, esg, profit, opportunity ScoreExplanation) ScoreExplanation {
+	components := []struct {
+		key    string
+		weight float64
+		e      ScoreExplanation
+	}{
+		{"esg", w.ESG, esg},
+		{"profit", w.Profit, profit},
+		{"opportunity", w.Opportunity, opportunity},
+	}
+
+	factors := make([]ScoreFactor, 0, len(components))
+	confidence, total := 0.0, 0.0
+	for _, c := range components {
+		factors = append(factors, factor(c.key, c.e.Score, c.weight, c.e.Confidence > 0, map[string]any{"confidence": c.e.Confidence}))
+		confidence += c.weight * c.e.Confidence
+		total += c.weight
+	}
+	overall := explain(factors...)
+	if total > 0 {
+		overall.Confidence = confidence / total
+	}
+	return overall
+}
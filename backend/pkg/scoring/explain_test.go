@@ -0,0 +1,102 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func findFactor(t *testing.T, e ScoreExplanation, key string) ScoreFactor {
+	t.Helper()
+	for _, f := range e.Factors {
+		if f.Key == key {
+			return f
+		}
+	}
+	t.Fatalf("factor %s not found", key)
+	return ScoreFactor{}
+}
+
+func TestExplanation(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+
+	// Without data every factor is a neutral default
+	empty := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{})
+	if empty.Explanation.ESG.Confidence != 0 || empty.Explanation.Overall.Confidence != 0 {
+		t.Errorf("Expected zero confidence without data, got %+v", empty.Explanation.Overall)
+	}
+	if f := findFactor(t, empty.Explanation.ESG, "energyEfficiency"); !f.Default || f.Value != 50 || f.Inputs != nil {
+		t.Errorf("Expected a neutral default energy efficiency, got %+v", f)
+	}
+
+	scores := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		EnergyClimate: &models.EnergyClimateData{EnergyLabel: "D"},
+		FloodRisk:     &models.FloodRiskData{RiskLevel: "Unknown"},
+		Facilities:    &models.FacilitiesData{AmenitiesScore: 80},
+	})
+
+	// A real "D" is distinguishable from missing data
+	energy := findFactor(t, scores.Explanation.ESG, "energyEfficiency")
+	if energy.Default || energy.Value != 45 || energy.Inputs["energyLabel"] != "D" || energy.Contribution != 45*energy.Weight {
+		t.Errorf("Unexpected energy factor %+v", energy)
+	}
+	// An unrecognised flood risk level falls back to the neutral score
+	if flood := findFactor(t, scores.Explanation.ESG, "floodRisk"); !flood.Default || flood.Inputs["riskLevel"] != "Unknown" {
+		t.Errorf("Expected an unknown flood risk level to be a default, got %+v", flood)
+	}
+	// Without a floor area and value the rental yield is the national average
+	if yield := findFactor(t, scores.Explanation.Profit, "rentalYield"); !yield.Default {
+		t.Errorf("Expected the rental yield to be a default, got %+v", yield)
+	}
+
+	// With them it is the WWS maximum rent against the value, scored net of the landlord's local taxes
+	withYield := engine.CalculateComprehensiveScores(&aggregator.ComprehensivePropertyData{
+		MunicipalityCode: "GM0344",
+		BAGBuilding:      &models.BAGBuildingData{FloorArea: 70, UnitsInBuilding: 12},
+		WOZData:          &models.AltumWOZData{WOZValue: 300000},
+	})
+	yield := findFactor(t, withYield.Explanation.Profit, "rentalYield")
+	profit := withYield.Breakdown.Profit
+	if yield.Default || yield.Inputs["wwsPoints"] == nil || profit.RentalYield <= 0 || yield.Value > 100 {
+		t.Errorf("Expected a WWS based rental yield, got %+v (yield %.2f%%)", yield, profit.RentalYield)
+	}
+	if gross, _ := yield.Inputs["grossYieldPercent"].(float64); profit.RentalYield != gross {
+		t.Errorf("Expected the rental yield to stay gross, got %.2f%% for gross %.2f%%", profit.RentalYield, gross)
+	}
+	if profit.HoldingCosts <= 0 || profit.NetRentalYield <= 0 || profit.NetRentalYield >= profit.RentalYield || yield.Inputs["taxMunicipality"] != "Utrecht" {
+		t.Errorf("Expected a net yield after Utrecht's local taxes, got %.2f%% of gross %.2f%% with holding costs %.2f", profit.NetRentalYield, profit.RentalYield, profit.HoldingCosts)
+	}
+	if yield.Value != math.Min(100, profit.NetRentalYield*10) {
+		t.Errorf("Expected the net yield to be scored, got %.2f for net %.2f%%", yield.Value, profit.NetRentalYield)
+	}
+
+	for name, e := range map[string]ScoreExplanation{
+		"esg":         scores.Explanation.ESG,
+		"profit":      scores.Explanation.Profit,
+		"opportunity": scores.Explanation.Opportunity,
+		"overall":     scores.Explanation.Overall,
+	} {
+		sum := 0.0
+		for _, f := range e.Factors {
+			sum += f.Contribution
+		}
+		if math.Abs(sum-e.Score) > 1e-9 {
+			t.Errorf("%s: contributions sum to %f, score is %f", name, sum, e.Score)
+		}
+	}
+	if scores.ESGScore != scores.Explanation.ESG.Score || scores.OverallScore != scores.Explanation.Overall.Score {
+		t.Error("Expected the explanations to match the reported scores")
+	}
+
+	// Energy efficiency (0.20), social livability (0.15) and sustainability (0.15) have data
+	if got := scores.Explanation.ESG.Confidence; math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("ESG confidence = %f, want 0.5", got)
+	}
+	e := scores.Explanation
+	want := 0.3*e.ESG.Confidence + 0.4*e.Profit.Confidence + 0.3*e.Opportunity.Confidence
+	if math.Abs(e.Overall.Confidence-want) > 1e-9 {
+		t.Errorf("Overall confidence = %f, want %f", e.Overall.Confidence, want)
+	}
+}
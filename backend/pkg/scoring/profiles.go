@@ -0,0 +1,275 @@
+package scoring
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+)
+
+// ErrUnknownProfile is returned when a scoring profile name is not defined
+var ErrUnknownProfile = errors.New("unknown scoring profile")
+
+// builtinProfilesJSON holds the profiles available without a profiles file. The
+// "balanced" profile carries the weights the engine has always used.
+//
+//go:embed profiles.json
+var builtinProfilesJSON []byte
+
+// weightTolerance is how far a weight group may sum from 1 to allow for rounding
+const weightTolerance = 0.001
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ScoringProfile weights the score components and sets the thresholds used for
+// the risk level and recommendations
+type ScoringProfile struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Weights     ProfileWeights    `json:"weights"`
+	Thresholds  ProfileThresholds `json:"thresholds"`
+}
+
+// ProfileWeights weights each score from its components, and the breakdowns that
+// combine several inputs from theirs; every group sums to 1
+type ProfileWeights struct {
+	Overall          OverallWeights          `json:"overall"`
+	ESG              ESGWeights              `json:"esg"`
+	Profit           ProfitWeights           `json:"profit"`
+	Opportunity      OpportunityWeights      `json:"opportunity"`
+	SocialLivability SocialLivabilityWeights `json:"socialLivability"`
+	Sustainability   SustainabilityWeights   `json:"sustainability"`
+}
+
+// OverallWeights combines the ESG, profit and opportunity scores into the overall score
+type OverallWeights struct {
+	ESG         float64 `json:"esg"`
+	Profit      float64 `json:"profit"`
+	Opportunity float64 `json:"opportunity"`
+}
+
+// ESGWeights combines the ESGBreakdown components into the ESG score
+type ESGWeights struct {
+	EnergyEfficiency  float64 `json:"energyEfficiency"`
+	EnvironmentalRisk float64 `json:"environmentalRisk"`
+	SocialLivability  float64 `json:"socialLivability"`
+	Sustainability    float64 `json:"sustainability"`
+	FloodRisk         float64 `json:"floodRisk"`
+	AirQuality        float64 `json:"airQuality"`
+	NoiseLevel        float64 `json:"noiseLevel"`
+	GreenSpaceAccess  float64 `json:"greenSpaceAccess"`
+}
+
+// ProfitWeights combines the ProfitBreakdown components into the profit score
+type ProfitWeights struct {
+	PriceAppreciation float64 `json:"priceAppreciation"`
+	MarketDemand      float64 `json:"marketDemand"`
+	LiquidityScore    float64 `json:"liquidityScore"`
+	CapitalGrowth     float64 `json:"capitalGrowth"`
+	RentalYield       float64 `json:"rentalYield"`
+}
+
+// OpportunityWeights combines the OpportunityBreakdown components into the opportunity score
+type OpportunityWeights struct {
+	DevelopmentPotential float64 `json:"developmentPotential"`
+	RenovationROI        float64 `json:"renovationROI"`
+	EnergyUpgradeROI     float64 `json:"energyUpgradeROI"`
+	NeighborhoodGrowth   float64 `json:"neighborhoodGrowth"`
+	Accessibility        float64 `json:"accessibility"`
+	AmenitiesScore       float64 `json:"amenitiesScore"`
+	FutureDevelopment    float64 `json:"futureDevelopment"`
+}
+
+// SocialLivabilityWeights combines safety, amenities and education into ESGBreakdown.SocialLivability
+type SocialLivabilityWeights struct {
+	Safety    float64 `json:"safety"`
+	Amenities float64 `json:"amenities"`
+	Education float64 `json:"education"`
+}
+
+// SustainabilityWeights combines energy efficiency and solar potential into ESGBreakdown.Sustainability
+type SustainabilityWeights struct {
+	EnergyEfficiency float64 `json:"energyEfficiency"`
+	SolarPotential   float64 `json:"solarPotential"`
+}
+
+// ProfileThresholds are the cut-offs for the risk level (in risk points) and for
+// recommendations (on 0-100 scores)
+type ProfileThresholds struct {
+	RiskMedium   int `json:"riskMedium"`
+	RiskHigh     int `json:"riskHigh"`
+	RiskVeryHigh int `json:"riskVeryHigh"`
+
+	LowEnergyEfficiency      float64 `json:"lowEnergyEfficiency"`
+	StrongMarket             float64 `json:"strongMarket"`
+	WeakMarket               float64 `json:"weakMarket"`
+	HighDevelopmentPotential float64 `json:"highDevelopmentPotential"`
+	LowAccessibility         float64 `json:"lowAccessibility"`
+	HighRenovationROI        float64 `json:"highRenovationROI"`
+}
+
+// ProfileSet is a validated, read-only set of scoring profiles with a default
+type ProfileSet struct {
+	defaultName string
+	profiles    map[string]*ScoringProfile
+	order       []string
+}
+
+// profilesFile is the layout of the built-in and user profile files
+type profilesFile struct {
+	Default  string           `json:"default"`
+	Profiles []ScoringProfile `json:"profiles"`
+}
+
+var builtinProfiles = func() *ProfileSet {
+	set, err := parseProfiles(&ProfileSet{profiles: map[string]*ScoringProfile{}}, builtinProfilesJSON)
+	if err != nil {
+		panic(fmt.Sprintf("scoring: invalid built-in profiles: %v", err))
+	}
+	return set
+}()
+
+// BuiltinProfiles returns the profiles shipped with the engine
+func BuiltinProfiles() *ProfileSet {
+	return builtinProfiles
+}
+
+// LoadProfiles returns the built-in profiles merged with those in the JSON file at
+// path, if set. A profile in the file replaces a built-in one with the same name
+// and must define all of its weights and thresholds.
+func LoadProfiles(path string) (*ProfileSet, error) {
+	if path == "" {
+		return builtinProfiles, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring profiles: %w", err)
+	}
+	set, err := parseProfiles(builtinProfiles, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scoring profiles in %s: %w", path, err)
+	}
+	return set, nil
+}
+
+// parseProfiles merges the profiles in raw over base and validates the result
+func parseProfiles(base *ProfileSet, raw []byte) (*ProfileSet, error) {
+	var file profilesFile
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	set := &ProfileSet{
+		defaultName: base.defaultName,
+		profiles:    make(map[string]*ScoringProfile, len(base.profiles)+len(file.Profiles)),
+		order:       append([]string(nil), base.order...),
+	}
+	for name, p := range base.profiles {
+		set.profiles[name] = p
+	}
+	seen := make(map[string]bool)
+	for i := range file.Profiles {
+		p := &file.Profiles[i]
+		if seen[p.Name] {
+			return nil, fmt.Errorf("profile %q is defined twice", p.Name)
+		}
+		seen[p.Name] = true
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := set.profiles[p.Name]; !exists {
+			set.order = append(set.order, p.Name)
+		}
+		set.profiles[p.Name] = p
+	}
+
+	if file.Default != "" {
+		set.defaultName = file.Default
+	}
+	if _, ok := set.profiles[set.defaultName]; !ok {
+		return nil, fmt.Errorf("default profile %q is not defined", set.defaultName)
+	}
+	return set, nil
+}
+
+// Validate checks the profile name, that every weight group is non-negative and
+// sums to 1, and that the thresholds are ordered and within 0-100
+func (p *ScoringProfile) Validate() error {
+	if !profileNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits and dashes", p.Name)
+	}
+
+	w := p.Weights
+	groups := []struct {
+		name    string
+		weights []float64
+	}{
+		{"overall", []float64{w.Overall.ESG, w.Overall.Profit, w.Overall.Opportunity}},
+		{"esg", []float64{w.ESG.EnergyEfficiency, w.ESG.EnvironmentalRisk, w.ESG.SocialLivability, w.ESG.Sustainability,
+			w.ESG.FloodRisk, w.ESG.AirQuality, w.ESG.NoiseLevel, w.ESG.GreenSpaceAccess}},
+		{"profit", []float64{w.Profit.PriceAppreciation, w.Profit.MarketDemand, w.Profit.LiquidityScore, w.Profit.CapitalGrowth,
+			w.Profit.RentalYield}},
+		{"opportunity", []float64{w.Opportunity.DevelopmentPotential, w.Opportunity.RenovationROI, w.Opportunity.EnergyUpgradeROI,
+			w.Opportunity.NeighborhoodGrowth, w.Opportunity.Accessibility, w.Opportunity.AmenitiesScore, w.Opportunity.FutureDevelopment}},
+		{"socialLivability", []float64{w.SocialLivability.Safety, w.SocialLivability.Amenities, w.SocialLivability.Education}},
+		{"sustainability", []float64{w.Sustainability.EnergyEfficiency, w.Sustainability.SolarPotential}},
+	}
+	for _, g := range groups {
+		sum := 0.0
+		for _, weight := range g.weights {
+			if weight < 0 {
+				return fmt.Errorf("profile %q: %s weights must not be negative", p.Name, g.name)
+			}
+			sum += weight
+		}
+		if math.Abs(sum-1) > weightTolerance {
+			return fmt.Errorf("profile %q: %s weights sum to %.3f, want 1", p.Name, g.name, sum)
+		}
+	}
+
+	t := p.Thresholds
+	if t.RiskMedium <= 0 || t.RiskHigh <= t.RiskMedium || t.RiskVeryHigh <= t.RiskHigh {
+		return fmt.Errorf("profile %q: risk thresholds must be positive and increasing (medium < high < very high)", p.Name)
+	}
+	for _, v := range []float64{t.LowEnergyEfficiency, t.StrongMarket, t.WeakMarket, t.HighDevelopmentPotential, t.LowAccessibility, t.HighRenovationROI} {
+		if v <= 0 || v > 100 {
+			return fmt.Errorf("profile %q: score thresholds must be within 1-100", p.Name)
+		}
+	}
+	if t.WeakMarket >= t.StrongMarket {
+		return fmt.Errorf("profile %q: weakMarket must be below strongMarket", p.Name)
+	}
+	return nil
+}
+
+// Default returns the profile used when a request does not select one
+func (s *ProfileSet) Default() *ScoringProfile {
+	return s.profiles[s.defaultName]
+}
+
+// Get returns the named profile, or the default profile for an empty name
+func (s *ProfileSet) Get(name string) (*ScoringProfile, error) {
+	if name == "" {
+		return s.Default(), nil
+	}
+	p, ok := s.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q, choose one of %v", ErrUnknownProfile, name, s.order)
+	}
+	return p, nil
+}
+
+// List returns the profiles in definition order
+func (s *ProfileSet) List() []*ScoringProfile {
+	list := make([]*ScoringProfile, 0, len(s.order))
+	for _, name := range s.order {
+		list = append(list, s.profiles[name])
+	}
+	return list
+}
@@ -0,0 +1,134 @@
+package scoring
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func TestBuiltinProfiles(t *testing.T) {
+	profiles := BuiltinProfiles()
+
+	if profiles.Default().Name != "balanced" {
+		t.Errorf("Expected balanced as the default profile, got %s", profiles.Default().Name)
+	}
+	var names []string
+	for _, p := range profiles.List() {
+		names = append(names, p.Name)
+	}
+	if got := strings.Join(names, ","); got != "balanced,investor,family,retiree,commercial" {
+		t.Errorf("Unexpected built-in profiles %s", got)
+	}
+
+	if _, err := profiles.Get("speculator"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Expected ErrUnknownProfile, got %v", err)
+	}
+	if p, err := profiles.Get(""); err != nil || p != profiles.Default() {
+		t.Errorf("Expected the default profile for an empty name, got %v, %v", p, err)
+	}
+}
+
+func TestCalculateScoresWithProfile(t *testing.T) {
+	engine := NewEnhancedScoringEngine()
+	data := &aggregator.ComprehensivePropertyData{
+		EnergyClimate: &models.EnergyClimateData{EnergyLabel: "F"},
+		FloodRisk:     &models.FloodRiskData{RiskLevel: "High"},
+		Facilities:    &models.FacilitiesData{AmenitiesScore: 90},
+	}
+
+	balanced := engine.CalculateComprehensiveScores(data)
+	if balanced.Profile != "balanced" {
+		t.Errorf("Expected the default profile to be recorded, got %q", balanced.Profile)
+	}
+	// The balanced profile keeps the original 30/40/30 weighting
+	want := balanced.ESGScore*0.3 + balanced.ProfitScore*0.4 + balanced.OpportunityScore*0.3
+	if math.Abs(balanced.OverallScore-want) > 1e-9 {
+		t.Errorf("Overall score = %f, want %f", balanced.OverallScore, want)
+	}
+
+	family, _ := engine.Profiles().Get("family")
+	familyScores := engine.CalculateScoresWithProfile(data, family)
+	if familyScores.Profile != "family" || familyScores.OverallScore == balanced.OverallScore {
+		t.Errorf("Expected family weights to change the overall score, got %+v", familyScores)
+	}
+	// The same components are reweighted, not recalculated
+	if familyScores.Breakdown != balanced.Breakdown {
+		t.Error("Expected the breakdown to be independent of the profile")
+	}
+	// Family thresholds are stricter: High flood risk alone is 3 risk points
+	if balanced.RiskLevel != "Medium" || familyScores.RiskLevel != "High" {
+		t.Errorf("Unexpected risk levels %s (balanced) and %s (family)", balanced.RiskLevel, familyScores.RiskLevel)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	balanced := BuiltinProfiles().Default()
+	profileJSON := func(name, overall string) string {
+		return `{"name": "` + name + `",
+			"weights": {
+				"overall": ` + overall + `,
+				"esg": {"energyEfficiency": 0.5, "floodRisk": 0.5},
+				"profit": {"rentalYield": 1},
+				"opportunity": {"accessibility": 0.6, "amenitiesScore": 0.4},
+				"socialLivability": {"safety": 0.5, "amenities": 0.5},
+				"sustainability": {"energyEfficiency": 1}
+			},
+			"thresholds": {"riskMedium": 1, "riskHigh": 2, "riskVeryHigh": 3, "lowEnergyEfficiency": 50, "strongMarket": 80,
+				"weakMarket": 30, "highDevelopmentPotential": 70, "lowAccessibility": 50, "highRenovationROI": 70}}`
+	}
+
+	if profiles, err := LoadProfiles(""); err != nil || profiles != BuiltinProfiles() {
+		t.Errorf("Expected the built-in profiles without a file, got %v", err)
+	}
+
+	path := write("profiles.json", `{"default": "student", "profiles": [`+
+		profileJSON("student", `{"esg": 0.2, "profit": 0.2, "opportunity": 0.6}`)+`, `+
+		profileJSON("investor", `{"esg": 0, "profit": 1, "opportunity": 0}`)+`]}`)
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadProfiles: %v", err)
+	}
+	if profiles.Default().Name != "student" || len(profiles.List()) != 6 {
+		t.Errorf("Expected student to be added as the default, got %s of %d", profiles.Default().Name, len(profiles.List()))
+	}
+	if investor, _ := profiles.Get("investor"); investor.Weights.Overall.Profit != 1 {
+		t.Errorf("Expected the file to replace the built-in investor profile, got %+v", investor.Weights.Overall)
+	}
+	if b, _ := profiles.Get("balanced"); b != balanced {
+		t.Error("Expected built-in profiles not in the file to be kept")
+	}
+
+	invalid := map[string]string{
+		"weight sum":   `{"profiles": [` + profileJSON("x", `{"esg": 0.5, "profit": 0.4, "opportunity": 0.3}`) + `]}`,
+		"negative":     `{"profiles": [` + profileJSON("x", `{"esg": -0.2, "profit": 0.6, "opportunity": 0.6}`) + `]}`,
+		"name":         `{"profiles": [` + profileJSON("Big Investor", `{"esg": 0.2, "profit": 0.2, "opportunity": 0.6}`) + `]}`,
+		"duplicate":    `{"profiles": [` + profileJSON("x", `{"esg": 1}`) + `, ` + profileJSON("x", `{"esg": 1}`) + `]}`,
+		"sub-weights":  `{"profiles": [` + strings.Replace(profileJSON("x", `{"esg": 1}`), `"energyEfficiency": 1}`, `"energyEfficiency": 0.5}`, 1) + `]}`,
+		"unknown key":  `{"profiles": [` + strings.Replace(profileJSON("x", `{"esg": 1}`), "rentalYield", "rentYield", 1) + `]}`,
+		"default":      `{"default": "nobody"}`,
+		"thresholds":   `{"profiles": [` + strings.Replace(profileJSON("x", `{"esg": 1}`), `"riskHigh": 2`, `"riskHigh": 1`, 1) + `]}`,
+		"no threshold": `{"profiles": [` + strings.Replace(profileJSON("x", `{"esg": 1}`), `"weakMarket": 30, `, ``, 1) + `]}`,
+	}
+	for name, content := range invalid {
+		if _, err := LoadProfiles(write(strings.ReplaceAll(name, " ", "-")+".json", content)); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+	if _, err := LoadProfiles(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Address parsing errors
+var (
+	ErrInvalidPostcode    = errors.New("invalid postcode, expected 4 digits and 2 letters (e.g. 3541ED)")
+	ErrInvalidHouseNumber = errors.New("invalid house number, expected a number with optional letter and addition (e.g. 12, 12A, 12-2, 12 bis)")
+)
+
+// postcodePattern matches a normalised Dutch postcode; the digits never start with 0
+var postcodePattern = regexp.MustCompile(`^[1-9][0-9]{3}[A-Z]{2}$`)
+
+// additionPattern matches a BAG huisnummertoevoeging: up to 4 letters or digits
+var additionPattern = regexp.MustCompile(`^[A-Z0-9]{1,4}$`)
+
+// DutchAddress identifies a BAG nummeraanduiding: postcode, huisnummer and the
+// optional huisletter and huisnummertoevoeging
+type DutchAddress struct {
+	Postcode    string `json:"postcode"`
+	HouseNumber int    `json:"houseNumber"`
+	HouseLetter string `json:"houseLetter,omitempty"`
+	Addition    string `json:"addition,omitempty"`
+}
+
+// ParseDutchAddress parses and validates a postcode and house number such as
+// "12", "12a", "12 A", "12-2", "12A-2", "12 bis" or "12/3". A single letter attached
+// to the number is the huisletter; anything after a separator, or a longer suffix
+// like "bis", is the toevoeging.
+func ParseDutchAddress(postcode, houseNumber string) (DutchAddress, error) {
+	var addr DutchAddress
+
+	addr.Postcode = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(postcode), " ", ""))
+	if !postcodePattern.MatchString(addr.Postcode) {
+		return DutchAddress{}, ErrInvalidPostcode
+	}
+
+	s := strings.ToUpper(strings.TrimSpace(houseNumber))
+	digits := len(s) - len(strings.TrimLeftFunc(s, unicode.IsDigit))
+	if digits == 0 || digits > 5 {
+		return DutchAddress{}, ErrInvalidHouseNumber
+	}
+	addr.HouseNumber, _ = strconv.Atoi(s[:digits])
+	if addr.HouseNumber == 0 {
+		return DutchAddress{}, ErrInvalidHouseNumber
+	}
+
+	rest := strings.TrimLeft(s[digits:], " ")
+	if rest == "" {
+		return addr, nil
+	}
+
+	// A single letter directly after the number (or one space) is the huisletter
+	if rest[0] >= 'A' && rest[0] <= 'Z' && (len(rest) == 1 || !unicode.IsLetter(rune(rest[1]))) {
+		addr.HouseLetter = rest[:1]
+		rest = rest[1:]
+	}
+
+	rest = strings.TrimLeft(rest, " -/")
+	if rest != "" {
+		if !additionPattern.MatchString(rest) {
+			return DutchAddress{}, ErrInvalidHouseNumber
+		}
+		addr.Addition = rest
+	}
+	return addr, nil
+}
+
+// HouseNumberString formats the house number as "12", "12A", "12-2" or "12A-2",
+// matching the Locatieserver huis_nlt field
+func (a DutchAddress) HouseNumberString() string {
+	s := strconv.Itoa(a.HouseNumber) + a.HouseLetter
+	if a.Addition != "" {
+		s += "-" + a.Addition
+	}
+	return s
+}
+
+// String formats the address as "3541ED 12A-2"
+func (a DutchAddress) String() string {
+	return fmt.Sprintf("%s %s", a.Postcode, a.HouseNumberString())
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDutchAddress(t *testing.T) {
+	tests := []struct {
+		houseNumber string
+		want        DutchAddress
+		canonical   string
+	}{
+		{"12", DutchAddress{Postcode: "3541ED", HouseNumber: 12}, "12"},
+		{" 12a ", DutchAddress{Postcode: "3541ED", HouseNumber: 12, HouseLetter: "A"}, "12A"},
+		{"12 A", DutchAddress{Postcode: "3541ED", HouseNumber: 12, HouseLetter: "A"}, "12A"},
+		{"12-2", DutchAddress{Postcode: "3541ED", HouseNumber: 12, Addition: "2"}, "12-2"},
+		{"12/3", DutchAddress{Postcode: "3541ED", HouseNumber: 12, Addition: "3"}, "12-3"},
+		{"12A-2", DutchAddress{Postcode: "3541ED", HouseNumber: 12, HouseLetter: "A", Addition: "2"}, "12A-2"},
+		{"12a 2", DutchAddress{Postcode: "3541ED", HouseNumber: 12, HouseLetter: "A", Addition: "2"}, "12A-2"},
+		{"12 bis", DutchAddress{Postcode: "3541ED", HouseNumber: 12, Addition: "BIS"}, "12-BIS"},
+		{"12-A", DutchAddress{Postcode: "3541ED", HouseNumber: 12, Addition: "A"}, "12-A"},
+		{"12 hs", DutchAddress{Postcode: "3541ED", HouseNumber: 12, Addition: "HS"}, "12-HS"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDutchAddress("3541 ed", tt.houseNumber)
+		if err != nil {
+			t.Errorf("ParseDutchAddress(%q) failed: %v", tt.houseNumber, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDutchAddress(%q) = %+v, want %+v", tt.houseNumber, got, tt.want)
+		}
+		if got.HouseNumberString() != tt.canonical {
+			t.Errorf("ParseDutchAddress(%q) formats as %q, want %q", tt.houseNumber, got.HouseNumberString(), tt.canonical)
+		}
+	}
+}
+
+func TestParseDutchAddress_Invalid(t *testing.T) {
+	tests := []struct {
+		postcode, houseNumber string
+		want                  error
+	}{
+		{"0541ED", "12", ErrInvalidPostcode},
+		{"3541E", "12", ErrInvalidPostcode},
+		{"ED3541", "12", ErrInvalidPostcode},
+		{"3541ED", "", ErrInvalidHouseNumber},
+		{"3541ED", "A12", ErrInvalidHouseNumber},
+		{"3541ED", "0", ErrInvalidHouseNumber},
+		{"3541ED", "123456", ErrInvalidHouseNumber},
+		{"3541ED", "12 toolong", ErrInvalidHouseNumber},
+		{"3541ED", "12 OR 1", ErrInvalidHouseNumber},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParseDutchAddress(tt.postcode, tt.houseNumber); !errors.Is(err, tt.want) {
+			t.Errorf("ParseDutchAddress(%q, %q) error = %v, want %v", tt.postcode, tt.houseNumber, err, tt.want)
+		}
+	}
+}
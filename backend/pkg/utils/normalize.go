@@ -2,9 +2,14 @@ package utils
 
 import "strings"
 
-// NormalizeAddressInput normalises postcode and house number for API lookups.
-// Postcodes are uppercased with spaces removed; house numbers are trimmed.
+// NormalizeAddressInput normalises postcode and house number for API lookups and
+// cache keys. Postcodes are uppercased with spaces removed; valid house numbers are
+// formatted canonically ("12 a" becomes "12A", "12 bis" becomes "12-BIS") and
+// anything else is trimmed.
 func NormalizeAddressInput(postcode, houseNumber string) (string, string) {
+	if addr, err := ParseDutchAddress(postcode, houseNumber); err == nil {
+		return addr.Postcode, addr.HouseNumberString()
+	}
 	return strings.ToUpper(strings.ReplaceAll(postcode, " ", "")),
 		strings.TrimSpace(houseNumber)
 }
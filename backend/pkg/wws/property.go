@@ -0,0 +1,45 @@
+package wws
+
+import (
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/apiclient"
+)
+
+// FromProperty collects the WWS inputs available in aggregated property data: floor
+// area, dwelling type and build year from BAG, the registered energy label, the WOZ
+// and market value and rijksmonument status. Facilities are not in any source and
+// are left unknown for the caller to fill in. Only trusted source values are used.
+func FromProperty(data *aggregator.ComprehensivePropertyData) Input {
+	data = data.Trusted()
+
+	var in Input
+	if b := data.BAGBuilding; b != nil {
+		in.FloorArea = b.FloorArea
+		in.ConstructionYear = b.ConstructionYear
+		in.MultiFamily = b.UnitsInBuilding > 1
+	}
+
+	switch {
+	case data.EnergyLabel != nil && data.EnergyLabel.Label != "":
+		in.EnergyLabel = data.EnergyLabel.Label
+	case data.EnergyLabel != nil && data.EnergyLabel.ProvisionalLabel != nil:
+		in.EnergyLabel = data.EnergyLabel.ProvisionalLabel.Label
+	case data.EnergyClimate != nil:
+		in.EnergyLabel = data.EnergyClimate.EnergyLabel
+	}
+
+	switch {
+	case data.WOZData != nil && data.WOZData.WOZValue > 0:
+		in.WOZValue = data.WOZData.WOZValue
+	case data.KadasterInfo != nil:
+		in.WOZValue = data.KadasterInfo.WOZValue
+	}
+	if data.MarketValuation != nil {
+		in.MarketValue = data.MarketValuation.MarketValue
+	}
+
+	if m := data.MonumentStatus; m != nil {
+		in.Monument = m.IsMonument && m.Type == apiclient.MonumentRijks
+	}
+	return in
+}
@@ -0,0 +1,320 @@
+package wws
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrMissingFloorArea is returned when no floor area is known; every other input
+// can fall back to a default but the points rest on the floor area
+var ErrMissingFloorArea = errors.New("floor area is required for a WWS valuation")
+
+// Parameters of the woningwaarderingsstelsel for zelfstandige woonruimte, as set by
+// the Wet betaalbare huur from 1 July 2024. The euro amounts are indexed every 1 July.
+const (
+	pointsPerRoomArea  = 1.0  // per m² of vertrekken
+	pointsPerOtherArea = 0.75 // per m² of overige ruimten
+
+	pointsPerHeatedRoom = 2.0
+
+	pointsPerToilet    = 3.0
+	pointsPerWashbasin = 1.0
+	pointsPerShower    = 4.0
+	pointsPerBath      = 6.0
+
+	// Private outdoor space earns a base plus a rate per m², capped; none costs points
+	outdoorBasePoints     = 2.0
+	outdoorPointsPerSqm   = 0.35
+	outdoorMaxPoints      = 15.0
+	noOutdoorSpacePenalty = -5.0
+
+	monumentPoints = 50.0 // rijksmonument
+
+	// WOZ points are the WOZ value and the WOZ value per m² each divided by a factor,
+	// with a minimum WOZ value
+	wozValueDivisor     = 14543.0
+	wozPerSqmDivisor    = 229.0
+	minimumWOZValue     = 55888.0
+	wozCapShare         = 0.33 // WOZ points may be at most a third of the total...
+	wozCapFromPoints    = 187  // ...for dwellings scoring this many points or more
+	newBuildExemptFrom  = 2024 // new builds completed from...
+	newBuildExemptUntil = 2028 // ...through this year keep their full WOZ points
+
+	// Maximum rent: the rent at the social rent limit plus a fixed amount per point
+	minimumRentPoints = 40
+	socialMaxPoints   = 143     // up to here: sociale huur
+	midRentMaxPoints  = 186     // up to here: middenhuur; above: vrije sector
+	socialRentLimit   = 879.66  // € per month at 143 points
+	midRentLimit      = 1157.95 // € per month at 186 points
+)
+
+// Regulatory segments a dwelling falls in by its points
+const (
+	SegmentSocial = "social" // sociale huur, up to 143 points
+	SegmentMid    = "mid"    // middenhuur, 144-186 points
+	SegmentFree   = "free"   // vrije sector, 187 points or more
+)
+
+// rentPerPoint is the increase in maximum rent per point in the huurprijstabel
+var rentPerPoint = (midRentLimit - socialRentLimit) / (midRentMaxPoints - socialMaxPoints)
+
+// energyLabelPoints gives the points per energy label for an eengezinswoning and
+// a meergezinswoning
+var energyLabelPoints = map[string][2]float64{
+	"A++++": {44, 40},
+	"A+++":  {40, 36},
+	"A++":   {36, 32},
+	"A+":    {32, 28},
+	"A":     {22, 15},
+	"B":     {18, 11},
+	"C":     {14, 8},
+	"D":     {8, 5},
+	"E":     {4, 1},
+	"F":     {-4, -4},
+	"G":     {-8, -8},
+}
+
+// constructionYearPoints scores a dwelling without an energy label by its
+// construction year: the points for dwellings built up to and including each
+// year, for an eengezinswoning and a meergezinswoning. Later years score as the last.
+var constructionYearPoints = []struct {
+	until  int
+	points [2]float64
+}{
+	{1975, [2]float64{0, 0}},
+	{1978, [2]float64{4, 1}},
+	{1983, [2]float64{8, 5}},
+	{1987, [2]float64{14, 8}},
+	{1991, [2]float64{18, 11}},
+	{math.MaxInt, [2]float64{22, 15}},
+}
+
+// Input holds the property characteristics the points are calculated from. Facility
+// fields are pointers so an explicit zero (no bath) is told apart from unknown.
+type Input struct {
+	FloorArea        float64 `json:"floorArea"`           // m² of vertrekken (living rooms, bedrooms, kitchen)
+	OtherArea        float64 `json:"otherArea,omitempty"` // m² of overige ruimten (storage, attic)
+	MultiFamily      bool    `json:"multiFamily"`         // meergezinswoning (apartment) rather than eengezinswoning
+	EnergyLabel      string  `json:"energyLabel,omitempty"`
+	ConstructionYear int     `json:"constructionYear,omitempty"`
+	WOZValue         float64 `json:"wozValue,omitempty"`
+	MarketValue      float64 `json:"marketValue,omitempty"` // used for the yield instead of the WOZ value when set
+	Monument         bool    `json:"monument"`              // rijksmonument
+
+	HeatedRooms   *int     `json:"heatedRooms,omitempty"`
+	KitchenLength *float64 `json:"kitchenLength,omitempty"` // m of worktop
+	Toilets       *int     `json:"toilets,omitempty"`
+	Washbasins    *int     `json:"washbasins,omitempty"`
+	Showers       *int     `json:"showers,omitempty"`
+	Baths         *int     `json:"baths,omitempty"`
+	OutdoorArea   *float64 `json:"outdoorArea,omitempty"` // m² of private outdoor space, 0 for none
+}
+
+// Category is the points scored in one WWS rubriek
+type Category struct {
+	Key    string  `json:"key"`
+	Points float64 `json:"points"`
+	Basis  string  `json:"basis"` // how the points were arrived at
+}
+
+// Valuation is the WWS outcome for a dwelling: its points, the maximum rent they
+// allow and the gross rental yield at that rent
+type Valuation struct {
+	Points      int        `json:"points"`
+	Categories  []Category `json:"categories"`
+	WOZCapped   bool       `json:"wozCapped"` // WOZ points were limited to a third of the total
+	MaxRent     float64    `json:"maxRent"`   // € per month
+	Segment     string     `json:"segment"`
+	Regulated   bool       `json:"regulated"` // false in the vrije sector, where maxRent is only indicative
+	AnnualRent  float64    `json:"annualRent"`
+	Value       float64    `json:"value,omitempty"`
+	ValueSource string     `json:"valueSource,omitempty"` // "market" or "woz"
+	GrossYield  float64    `json:"grossYield"`            // annual rent as a percentage of the value
+	// Assumptions lists the inputs that were unknown and the defaults used for them
+	Assumptions []string `json:"assumptions,omitempty"`
+	Input       Input    `json:"input"`
+}
+
+// Calculate scores a dwelling on the woningwaarderingsstelsel. Unknown facilities
+// are filled with the fittings of a typical dwelling and listed as assumptions.
+func Calculate(in Input) (*Valuation, error) {
+	if in.FloorArea <= 0 {
+		return nil, ErrMissingFloorArea
+	}
+	v := &Valuation{Input: in}
+	add := func(key string, points float64, basis string) {
+		v.Categories = append(v.Categories, Category{Key: key, Points: round2(points), Basis: basis})
+	}
+	assume := func(format string, args ...any) {
+		v.Assumptions = append(v.Assumptions, fmt.Sprintf(format, args...))
+	}
+
+	add("floorArea", in.FloorArea*pointsPerRoomArea, fmt.Sprintf("%.0f m² of rooms", in.FloorArea))
+	if in.OtherArea > 0 {
+		add("otherArea", in.OtherArea*pointsPerOtherArea, fmt.Sprintf("%.0f m² of other spaces", in.OtherArea))
+	}
+
+	heatedRooms := intOr(in.HeatedRooms, int(math.Max(1, math.Round(in.FloorArea/25))))
+	if in.HeatedRooms == nil {
+		assume("%d heated rooms estimated from the floor area", heatedRooms)
+	}
+	add("heating", float64(heatedRooms)*pointsPerHeatedRoom, fmt.Sprintf("%d heated rooms", heatedRooms))
+
+	label := strings.ToUpper(strings.TrimSpace(in.EnergyLabel))
+	labelPoints, ok := energyLabelPoints[label]
+	basis := "energy label " + label
+	if !ok {
+		missing := "no registered energy label"
+		if label != "" {
+			missing = fmt.Sprintf("energy label %q not recognised", in.EnergyLabel)
+		}
+		if in.ConstructionYear > 0 {
+			labelPoints = pointsForConstructionYear(in.ConstructionYear)
+			basis = fmt.Sprintf("construction year %d", in.ConstructionYear)
+			assume("%s: scored by the construction year", missing)
+		} else {
+			labelPoints, basis = energyLabelPoints["G"], "energy label G"
+			assume("%s and no construction year: scored as G", missing)
+		}
+	}
+	energy := labelPoints[0]
+	if in.MultiFamily {
+		energy = labelPoints[1]
+	}
+	add("energyPerformance", energy, basis)
+
+	kitchen := floatOr(in.KitchenLength, 2)
+	if in.KitchenLength == nil {
+		assume("kitchen worktop of 2 m")
+	}
+	add("kitchen", kitchenPoints(kitchen), fmt.Sprintf("%.1f m worktop", kitchen))
+
+	toilets, washbasins, showers, baths := intOr(in.Toilets, 1), intOr(in.Washbasins, 1), intOr(in.Showers, 1), intOr(in.Baths, 0)
+	if in.Toilets == nil || in.Washbasins == nil || in.Showers == nil || in.Baths == nil {
+		assume("sanitary facilities: %d toilet(s), %d washbasin(s), %d shower(s), %d bath(s)", toilets, washbasins, showers, baths)
+	}
+	add("sanitary",
+		float64(toilets)*pointsPerToilet+float64(washbasins)*pointsPerWashbasin+float64(showers)*pointsPerShower+float64(baths)*pointsPerBath,
+		fmt.Sprintf("%d toilet(s), %d washbasin(s), %d shower(s), %d bath(s)", toilets, washbasins, showers, baths))
+
+	switch {
+	case in.OutdoorArea == nil:
+		assume("private outdoor space unknown: not scored")
+	case *in.OutdoorArea > 0:
+		add("outdoorSpace", math.Min(outdoorMaxPoints, outdoorBasePoints+*in.OutdoorArea*outdoorPointsPerSqm),
+			fmt.Sprintf("%.0f m² private outdoor space", *in.OutdoorArea))
+	default:
+		add("outdoorSpace", noOutdoorSpacePenalty, "no private outdoor space")
+	}
+
+	if in.Monument {
+		add("monument", monumentPoints, "rijksmonument")
+	}
+
+	// WOZ points are added last: the cap depends on the other points
+	woz := in.WOZValue
+	if woz <= 0 {
+		assume("no WOZ value: the minimum WOZ value of €%.0f was used", minimumWOZValue)
+	}
+	woz = math.Max(woz, minimumWOZValue)
+	other := 0.0
+	for _, c := range v.Categories {
+		other += c.Points
+	}
+	wozPoints := woz/wozValueDivisor + woz/(in.FloorArea+in.OtherArea)/wozPerSqmDivisor
+	exempt := in.ConstructionYear >= newBuildExemptFrom && in.ConstructionYear <= newBuildExemptUntil
+	if total := other + wozPoints; !exempt && total >= wozCapFromPoints && wozPoints > wozCapShare*total {
+		// Cap the WOZ points at a third of the total, but not below the free sector threshold
+		capped := math.Max(other*wozCapShare/(1-wozCapShare), wozCapFromPoints-other)
+		if capped < wozPoints {
+			wozPoints = capped
+			v.WOZCapped = true
+		}
+	}
+	add("woz", wozPoints, fmt.Sprintf("WOZ value €%.0f", woz))
+
+	total := 0.0
+	for _, c := range v.Categories {
+		total += c.Points
+	}
+	v.Points = int(math.Round(total))
+
+	v.MaxRent = MaxRent(v.Points)
+	v.Segment = SegmentFor(v.Points)
+	v.Regulated = v.Segment != SegmentFree
+	v.AnnualRent = round2(v.MaxRent * 12)
+
+	switch {
+	case in.MarketValue > 0:
+		v.Value, v.ValueSource = in.MarketValue, "market"
+	case in.WOZValue > 0:
+		v.Value, v.ValueSource = in.WOZValue, "woz"
+	}
+	if v.Value > 0 {
+		v.GrossYield = round2(v.AnnualRent / v.Value * 100)
+	}
+	return v, nil
+}
+
+// MaxRent returns the maximum monthly rent in euros for a number of points
+func MaxRent(points int) float64 {
+	if points < minimumRentPoints {
+		points = minimumRentPoints
+	}
+	return round2(socialRentLimit + float64(points-socialMaxPoints)*rentPerPoint)
+}
+
+// SegmentFor returns the regulatory segment of a dwelling with the given points
+func SegmentFor(points int) string {
+	switch {
+	case points <= socialMaxPoints:
+		return SegmentSocial
+	case points <= midRentMaxPoints:
+		return SegmentMid
+	default:
+		return SegmentFree
+	}
+}
+
+// kitchenPoints scores a kitchen by the length of its worktop in metres
+func kitchenPoints(length float64) float64 {
+	switch {
+	case length >= 2:
+		return 7
+	case length >= 1:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// pointsForConstructionYear looks up the energy performance points of a dwelling
+// without an energy label
+func pointsForConstructionYear(year int) [2]float64 {
+	for _, row := range constructionYearPoints {
+		if year <= row.until {
+			return row.points
+		}
+	}
+	return constructionYearPoints[len(constructionYearPoints)-1].points
+}
+
+func intOr(p *int, def int) int {
+	if p != nil {
+		return *p
+	}
+	return def
+}
+
+func floatOr(p *float64, def float64) float64 {
+	if p != nil {
+		return *p
+	}
+	return def
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,168 @@
+package wws
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/iman-hussain/nethaddress/backend/pkg/aggregator"
+	"github.com/iman-hussain/nethaddress/backend/pkg/models"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func categoryPoints(v *Valuation, key string) (float64, bool) {
+	for _, c := range v.Categories {
+		if c.Key == key {
+			return c.Points, true
+		}
+	}
+	return 0, false
+}
+
+func TestCalculate(t *testing.T) {
+	v, err := Calculate(Input{
+		FloorArea:     70,
+		MultiFamily:   true,
+		EnergyLabel:   "c",
+		WOZValue:      300000,
+		HeatedRooms:   ptr(3),
+		KitchenLength: ptr(2.5),
+		Toilets:       ptr(1),
+		Washbasins:    ptr(1),
+		Showers:       ptr(1),
+		Baths:         ptr(0),
+		OutdoorArea:   ptr(6.0),
+	})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+
+	want := map[string]float64{
+		"floorArea":         70,
+		"heating":           6,
+		"energyPerformance": 8, // label C in a meergezinswoning
+		"kitchen":           7,
+		"sanitary":          8,
+		"outdoorSpace":      4.1,
+		"woz":               39.34, // 300000/14543 + 300000/70/229
+	}
+	for key, points := range want {
+		if got, ok := categoryPoints(v, key); !ok || got != points {
+			t.Errorf("%s = %.2f (found %v), want %.2f", key, got, ok, points)
+		}
+	}
+	if v.Points != 142 || v.Segment != SegmentSocial || !v.Regulated {
+		t.Errorf("Expected 142 points in the social segment, got %d %s", v.Points, v.Segment)
+	}
+	if v.MaxRent != MaxRent(142) || v.AnnualRent != math.Round(v.MaxRent*12*100)/100 {
+		t.Errorf("Unexpected rent %.2f / %.2f", v.MaxRent, v.AnnualRent)
+	}
+	if v.ValueSource != "woz" || v.GrossYield != math.Round(v.AnnualRent/300000*100*100)/100 {
+		t.Errorf("Unexpected yield %.2f%% on %s value", v.GrossYield, v.ValueSource)
+	}
+	if len(v.Assumptions) != 0 {
+		t.Errorf("Expected no assumptions with every input given, got %v", v.Assumptions)
+	}
+}
+
+func TestCalculateDefaults(t *testing.T) {
+	v, err := Calculate(Input{FloorArea: 50})
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	if len(v.Assumptions) != 6 {
+		t.Errorf("Expected assumptions for heating, energy label, kitchen, sanitary, outdoor space and WOZ, got %v", v.Assumptions)
+	}
+	if got, _ := categoryPoints(v, "energyPerformance"); got != -8 {
+		t.Errorf("Expected a missing label without a construction year to score as G, got %.2f", got)
+	}
+	// Without a label the construction year sets the energy performance points
+	for year, want := range map[int]float64{1930: 0, 1985: 14, 2005: 22} {
+		v, _ := Calculate(Input{FloorArea: 50, ConstructionYear: year})
+		if got, _ := categoryPoints(v, "energyPerformance"); got != want {
+			t.Errorf("Expected %.0f points for an unlabelled dwelling from %d, got %.2f", want, year, got)
+		}
+	}
+	apartment, _ := Calculate(Input{FloorArea: 50, ConstructionYear: 1985, MultiFamily: true, EnergyLabel: "X"})
+	if got, _ := categoryPoints(apartment, "energyPerformance"); got != 8 {
+		t.Errorf("Expected an unrecognised label to be scored by construction year, got %.2f", got)
+	}
+	if _, ok := categoryPoints(v, "outdoorSpace"); ok {
+		t.Error("Expected unknown outdoor space not to be scored")
+	}
+	if got, _ := categoryPoints(v, "woz"); got != math.Round((minimumWOZValue/wozValueDivisor+minimumWOZValue/50/wozPerSqmDivisor)*100)/100 {
+		t.Errorf("Expected the minimum WOZ value to be used, got %.2f points", got)
+	}
+	if v.GrossYield != 0 || v.Value != 0 {
+		t.Errorf("Expected no yield without a value, got %.2f%%", v.GrossYield)
+	}
+
+	if _, err := Calculate(Input{WOZValue: 300000}); !errors.Is(err, ErrMissingFloorArea) {
+		t.Errorf("Expected ErrMissingFloorArea, got %v", err)
+	}
+}
+
+func TestCalculateWOZCap(t *testing.T) {
+	in := Input{FloorArea: 100, EnergyLabel: "A", WOZValue: 1500000, MarketValue: 1600000, OutdoorArea: ptr(0.0)}
+	v, err := Calculate(in)
+	if err != nil {
+		t.Fatalf("Calculate: %v", err)
+	}
+	woz, _ := categoryPoints(v, "woz")
+	if !v.WOZCapped || woz > wozCapShare*float64(v.Points)+0.5 || v.Points < wozCapFromPoints {
+		t.Errorf("Expected WOZ points capped at a third of %d, got %.2f (capped %v)", v.Points, woz, v.WOZCapped)
+	}
+	if v.Segment != SegmentFree || v.Regulated {
+		t.Errorf("Expected the free sector, got %s", v.Segment)
+	}
+	if v.ValueSource != "market" {
+		t.Errorf("Expected the market value to be preferred, got %s", v.ValueSource)
+	}
+	if got, _ := categoryPoints(v, "outdoorSpace"); got != noOutdoorSpacePenalty {
+		t.Errorf("Expected the penalty for no outdoor space, got %.2f", got)
+	}
+
+	// New builds keep their full WOZ points
+	in.ConstructionYear = 2025
+	if v, _ := Calculate(in); v.WOZCapped {
+		t.Error("Expected no WOZ cap for a 2025 new build")
+	}
+}
+
+func TestSegmentsAndRent(t *testing.T) {
+	for points, want := range map[int]string{100: SegmentSocial, 143: SegmentSocial, 144: SegmentMid, 186: SegmentMid, 187: SegmentFree} {
+		if got := SegmentFor(points); got != want {
+			t.Errorf("SegmentFor(%d) = %s, want %s", points, got, want)
+		}
+	}
+	if MaxRent(socialMaxPoints) != socialRentLimit || MaxRent(midRentMaxPoints) != midRentLimit {
+		t.Errorf("Expected the rent limits at 143 and 186 points, got %.2f and %.2f", MaxRent(socialMaxPoints), MaxRent(midRentMaxPoints))
+	}
+	if MaxRent(10) != MaxRent(minimumRentPoints) {
+		t.Error("Expected the rent below 40 points to equal the rent at 40 points")
+	}
+}
+
+func TestFromProperty(t *testing.T) {
+	in := FromProperty(&aggregator.ComprehensivePropertyData{
+		BAGBuilding:    &models.BAGBuildingData{FloorArea: 85, ConstructionYear: 1932, UnitsInBuilding: 4},
+		EnergyLabel:    &models.EnergyLabelData{Label: "B"},
+		EnergyClimate:  &models.EnergyClimateData{EnergyLabel: "E"},
+		WOZData:        &models.AltumWOZData{WOZValue: 410000},
+		KadasterInfo:   &models.KadasterObjectInfo{WOZValue: 1},
+		MonumentStatus: &models.MonumentData{IsMonument: true, Type: "Rijksmonument"},
+	})
+	if in.FloorArea != 85 || in.ConstructionYear != 1932 || !in.MultiFamily {
+		t.Errorf("Unexpected BAG inputs %+v", in)
+	}
+	if in.EnergyLabel != "B" {
+		t.Errorf("Expected the EP-Online label to be preferred, got %q", in.EnergyLabel)
+	}
+	if in.WOZValue != 410000 || !in.Monument {
+		t.Errorf("Unexpected WOZ or monument input %+v", in)
+	}
+	if in.Toilets != nil || in.OutdoorArea != nil {
+		t.Error("Expected facilities to be left unknown")
+	}
+}
@@ -0,0 +1,96 @@
+// Package yearfiles loads data versioned by year: one JSON file per year, each
+// with a top-level "year", where a year without a file uses the latest before it
+package yearfiles
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+)
+
+// Validator is a pointer to a year's data that checks itself after decoding
+type Validator[T any] interface {
+	*T
+	Validate() error
+}
+
+// Set is a validated, read-only set of data by year
+type Set[T any] struct {
+	years  map[int]*T
+	order  []int // ascending
+	noYear error
+}
+
+// Load parses and validates every *.json file in dir of fsys. ForYear wraps
+// noYear for a year before the earliest file.
+func Load[T any, P Validator[T]](fsys fs.FS, dir string, noYear error) (*Set[T], error) {
+	return Merge[T, P](&Set[T]{noYear: noYear}, fsys, dir)
+}
+
+// Merge returns base with the *.json files in dir of fsys added. A file for a
+// year that is in base replaces it; base itself is not changed.
+func Merge[T any, P Validator[T]](base *Set[T], fsys fs.FS, dir string) (*Set[T], error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no *.json files found")
+	}
+
+	s := &Set[T]{years: make(map[int]*T, len(base.years)+len(files)), noYear: base.noYear}
+	for year, v := range base.years {
+		s.years[year] = v
+	}
+	seen := make(map[int]string)
+	for _, name := range files {
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		var header struct {
+			Year int `json:"year"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		v := new(T)
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(v); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := P(v).Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if other, dup := seen[header.Year]; dup {
+			return nil, fmt.Errorf("%s: year %d is also defined in %s", name, header.Year, other)
+		}
+		seen[header.Year] = name
+		s.years[header.Year] = v
+	}
+
+	for year := range s.years {
+		s.order = append(s.order, year)
+	}
+	sort.Ints(s.order)
+	return s, nil
+}
+
+// ForYear returns the data for a year, or the current year for 0. A year past the
+// latest file uses the latest, so a new year works until its file is added.
+func (s *Set[T]) ForYear(year int) (*T, error) {
+	if year == 0 {
+		year = time.Now().Year()
+	}
+	for i := len(s.order) - 1; i >= 0; i-- {
+		if s.order[i] <= year {
+			return s.years[s.order[i]], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %d, the earliest is %d", s.noYear, year, s.order[0])
+}
@@ -0,0 +1,83 @@
+package yearfiles
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var errNoLimit = errors.New("no limit for year")
+
+type limit struct {
+	Year  int     `json:"year"`
+	Value float64 `json:"value"`
+}
+
+func (l *limit) Validate() error {
+	if l.Value <= 0 {
+		return fmt.Errorf("%d: value must be positive", l.Year)
+	}
+	return nil
+}
+
+func file(year int, value float64) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"year": %d, "value": %g}`, year, value))}
+}
+
+func TestLoad(t *testing.T) {
+	set, err := Load[limit](fstest.MapFS{
+		"limits/2024.json": file(2024, 1),
+		"limits/2026.json": file(2026, 3),
+		"limits/notes.txt": {Data: []byte("ignored")},
+	}, "limits", errNoLimit)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for year, want := range map[int]float64{2024: 1, 2025: 1, 2026: 3, 2099: 3} {
+		if l, err := set.ForYear(year); err != nil || l.Value != want {
+			t.Errorf("ForYear(%d) = %+v, %v, want value %g", year, l, err, want)
+		}
+	}
+	if l, _ := set.ForYear(0); l.Year > time.Now().Year() {
+		t.Errorf("Expected the current year's data, got %d", l.Year)
+	}
+	if _, err := set.ForYear(2010); !errors.Is(err, errNoLimit) {
+		t.Errorf("Expected errNoLimit for 2010, got %v", err)
+	}
+
+	invalid := map[string]fstest.MapFS{
+		"unknown field":  {"a.json": {Data: []byte(`{"year": 2024, "value": 1, "extra": true}`)}},
+		"validation":     {"a.json": file(2024, -1)},
+		"duplicate year": {"a.json": file(2024, 1), "b.json": file(2024, 2)},
+		"no files":       {"a.txt": {Data: []byte("{}")}},
+	}
+	for name, fsys := range invalid {
+		if _, err := Load[limit](fsys, ".", errNoLimit); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base, err := Load[limit](fstest.MapFS{"2024.json": file(2024, 1), "2025.json": file(2025, 2)}, ".", errNoLimit)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	merged, err := Merge(base, fstest.MapFS{"2025.json": file(2025, 5), "2026.json": file(2026, 6)}, ".")
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	for year, want := range map[int]float64{2024: 1, 2025: 5, 2026: 6} {
+		if l, _ := merged.ForYear(year); l.Value != want {
+			t.Errorf("merged ForYear(%d) = %+v, want value %g", year, l, want)
+		}
+	}
+	if l, _ := base.ForYear(2026); l.Value != 2 {
+		t.Errorf("Expected the base set to be unchanged, got %+v", l)
+	}
+	if _, err := merged.ForYear(2010); !errors.Is(err, errNoLimit) {
+		t.Errorf("Expected the merged set to keep errNoLimit, got %v", err)
+	}
+}
@@ -4,7 +4,7 @@
  */
 
 import { getRenderer, initializeRegistry } from './renderers/index.js';
-import { formatUnknownData } from './utils.js';
+import { escapeHTML, formatUnknownData } from './utils.js';
 import { setPropertyLocation, showPOIsOnMap, removePOILayer, clearAllPOILayers, isLayerActive, initSolarDrawingTool } from './map-visualisations.js';
 import { AVAILABLE_APIS, DEFAULT_ENABLED_APIS, getTierConfig } from './state.js';
 
@@ -510,7 +510,7 @@ document.addEventListener('DOMContentLoaded', function () {
 
 				// 2. Process apiResults (the actual structure from backend)
 				// Backend sends: { apiResults: { free: [...], freemium: [...], premium: [...] } }
-				// Each item has: { name: "API Name", status: "success|error|not_configured", data: {...} }
+				// Each item has: { name: "API Name", status: "success|error|not_configured|circuit_open", data: {...} }
 				if (response.apiResults) {
 					const allResults = [
 						...(response.apiResults.free || []),
@@ -521,7 +521,7 @@ document.addEventListener('DOMContentLoaded', function () {
 					allResults.forEach(result => {
 						if (result.status === 'success' && result.data) {
 							updateResultCard(result.name, result.data);
-						} else if (result.status === 'error') {
+						} else if (result.status === 'error' || result.status === 'circuit_open') {
 							markResultCardError(result.name);
 						} else if (result.status === 'not_configured') {
 							markResultCardNotConfigured(result.name);
@@ -593,6 +593,18 @@ document.addEventListener('DOMContentLoaded', function () {
 			}
 		});
 
+		// Several units share the house number: let the user pick one
+		evtSource.addEventListener('ambiguous', function (event) {
+			evtSource.close();
+			window.currentEventSource = null;
+			try {
+				const { address, candidates } = JSON.parse(event.data);
+				if (targetContainer) renderAddressCandidates(targetContainer, address, candidates || []);
+			} catch (e) {
+				console.error('Error processing ambiguous event:', e);
+			}
+		});
+
 		// Handle errors... (existing code)
 		evtSource.addEventListener('error', function (event) {
 			if (event.data) {
@@ -634,6 +646,25 @@ document.addEventListener('DOMContentLoaded', function () {
 		}
 	}
 
+	// Render the units to choose from when a house number is ambiguous
+	function renderAddressCandidates(container, address, candidates) {
+		container.innerHTML = `<div class="box">
+			<h5 class="title is-5">Which address do you mean?</h5>
+			<p class="is-size-6 mb-3">${escapeHTML(address)} matches ${candidates.length} addresses.</p>
+			<div class="buttons">
+				${candidates.map(c => `<button class="button is-small glass-liquid" data-postcode="${escapeHTML(c.postcode)}" data-house-number="${escapeHTML(c.houseNumber)}">${escapeHTML(c.label || `${c.postcode} ${c.houseNumber}`)}</button>`).join('')}
+			</div>
+		</div>`;
+
+		container.querySelectorAll('button[data-postcode]').forEach(btn => {
+			btn.addEventListener('click', () => {
+				const houseNumberInput = document.querySelector('#search-form [name="houseNumber"]');
+				if (houseNumberInput) houseNumberInput.value = btn.dataset.houseNumber;
+				startSearchStream(btn.dataset.postcode, btn.dataset.houseNumber, false);
+			});
+		});
+	}
+
 	// Render the initial skeleton grid
 	function renderSkeletonGrid(container) {
 		const tiers = [
@@ -1108,11 +1139,11 @@ document.addEventListener('DOMContentLoaded', function () {
 			'BAG Address', 'Kadaster Object Info', 'Altum WOZ', 'Matrixian Property Value+',
 			'Altum Transactions', 'KNMI Weather', 'KNMI Solar', 'Luchtmeetnet Air Quality',
 			'Noise Pollution', 'CBS Population', 'CBS Square Statistics', 'WUR Soil Physicals',
-			'SkyGeo Subsidence', 'Soil Quality', 'BRO Soil Map', 'Altum Energy & Climate',
+			'SkyGeo Subsidence', 'Soil Quality', 'BRO Soil Map', 'EP-Online Energy Label', 'Altum Energy & Climate',
 			'Altum Sustainability', 'NDW Traffic', 'openOV Public Transport', 'Parking Availability',
 			'Flood Risk', 'Digital Delta Water Quality', 'CBS Safety Experience', 'Schiphol Flight Noise',
 			'Green Spaces', 'Education Facilities', 'Building Permits', 'Facilities & Amenities',
-			'AHN Height Model', 'Monument Status', 'PDOK Platform', 'Stratopo Environment', 'Land Use & Zoning'
+			'AHN Height Model', 'Monument Status', 'PDOK Platform', 'BAG Building', 'Stratopo Environment', 'Land Use & Zoning'
 		]);
 	}
 
@@ -1439,7 +1470,7 @@ function renderApiResults() {
 		if (sectionResults.length === 0) return '';
 
 		// Sort results: success first, then error, then not_configured
-		const statusPriority = { 'success': 0, 'error': 1, 'not_configured': 2 };
+		const statusPriority = { 'success': 0, 'error': 1, 'circuit_open': 1, 'not_configured': 2 };
 		sectionResults.sort((a, b) => {
 			const priorityA = statusPriority[a.status] ?? 3;
 			const priorityB = statusPriority[b.status] ?? 3;
@@ -230,7 +230,15 @@ export function renderWaterQuality(data) {
 
 export function renderHeightModel(data) {
     if (!data) return '';
-    
+    if (data.available === false) {
+        return `<div class="metric-display">
+        <div class="metric-label">⛰️ Ground Elevation</div>
+        <div class="metric-secondary">
+            <span class="status-badge moderate">Unavailable</span> ${data.unavailableReason || 'No AHN height data for this location'}
+        </div>
+    </div>`;
+    }
+
     const elevation = data.elevation || data.height || data.hoogte || 0;
     const terrainSlope = data.slope || data.terrainSlope || 0;
     const aspect = data.aspect || '';
@@ -245,7 +253,8 @@ export function renderHeightModel(data) {
     const ahnFloodClass = elevation < 0 ? 'poor' : elevation < 2 ? 'moderate' : 'good';
 
     // View potential based on relative elevation
-    const relativeHeight = elevation - surroundingAvg;
+    const relativeHeight = data.relativeHeight ?? (elevation - surroundingAvg);
+    const buildingHeight = data.buildingHeight || 0;
     const viewPotential = relativeHeight > 5 ? 'Excellent' : relativeHeight > 2 ? 'Good' : relativeHeight > 0 ? 'Moderate' : 'Limited';
     const viewClass = relativeHeight > 5 ? 'good' : relativeHeight > 0 ? 'moderate' : 'poor';
 
@@ -265,12 +274,15 @@ export function renderHeightModel(data) {
         ${surroundingAvg !== 0 || surroundingMin !== 0 || surroundingMax !== 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
             🏔️ Surroundings: <strong>${surroundingMin.toFixed(1)}</strong>m – <strong>${surroundingMax.toFixed(1)}</strong>m (avg: ${surroundingAvg.toFixed(1)}m)
         </div>` : ''}
+        ${buildingHeight > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            🏢 Building height: <strong>${buildingHeight.toFixed(1)}m</strong> above ground
+        </div>` : ''}
         <div class="metric-secondary" style="margin-top: 0.25rem;">
             👁️ View potential: <span class="status-badge ${viewClass}">${viewPotential}</span>
             ${relativeHeight !== 0 ? ` (<strong>${relativeHeight > 0 ? '+' : ''}${relativeHeight.toFixed(1)}m</strong> vs avg)` : ''}
         </div>
         <div class="metric-secondary timestamp" style="margin-top: 0.25rem; font-size: 0.7rem;">
-            📍 Relative to Amsterdam Ordnance Datum (NAP)
+            📍 Relative to Amsterdam Ordnance Datum (NAP)${data.source ? ` · ${data.source}` : ''}
         </div>
     </div>`;
 }
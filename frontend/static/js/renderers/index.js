@@ -24,6 +24,7 @@ import {
 	renderTransactions,
 	renderLandUseZoning,
 	renderPDOKPlatform,
+	renderBAGBuilding,
 	renderMonumentStatus,
 	renderBuildingPermits
 } from './property.js';
@@ -36,6 +37,7 @@ import {
 	renderGreenSpaces
 } from './infrastructure.js';
 import {
+	renderEnergyLabel,
 	renderEnergyClimate,
 	renderSustainability,
 	renderStratopoEnvironment
@@ -86,6 +88,7 @@ export function initializeRegistry(getCurrentResponse) {
 		'Altum Transactions': renderTransactions,
 		'Land Use & Zoning': renderLandUseZoning,
 		'PDOK Platform': renderPDOKPlatform,
+		'BAG Building': renderBAGBuilding,
 		'Monument Status': renderMonumentStatus,
 		'Building Permits': renderBuildingPermits,
 
@@ -98,6 +101,7 @@ export function initializeRegistry(getCurrentResponse) {
 		'Green Spaces': renderGreenSpaces,
 
 		// Sustainability & Energy
+		'EP-Online Energy Label': renderEnergyLabel,
 		'Altum Energy & Climate': renderEnergyClimate,
 		'Altum Sustainability': renderSustainability,
 		'Stratopo Environment': renderStratopoEnvironment,
@@ -3,7 +3,7 @@
  * Handles BAG, WOZ, Kadaster, Matrixian, Zoning, Monuments, and Permits data
  */
 
-import { formatTimestamp } from '../utils.js';
+import { escapeHTML, formatTimestamp } from '../utils.js';
 
 export function renderBAGAddress(data) {
     if (!data) return '';
@@ -162,73 +162,109 @@ export function renderLandUseZoning(data) {
     if (!data) return '';
     
     const primaryUse = data.primaryUse || data.landUseType || 'Unknown';
-    const zoningCode = data.zoningCode || '';
     const zoningDetails = data.zoningDetails || '';
-    const allowedUses = data.allowedUses || [];
-    const maxHeight = data.maxBuildingHeight || 0;
-    const maxCoverage = data.maxCoverage || 0;
+    const restrictions = data.restrictions || [];
+    const futurePlans = data.futurePlans || [];
+    const rights = data.buildingRights || {};
+    const maxHeight = rights.maxHeight || 0;
+    const maxGutter = rights.maxGutterHeight || 0;
+    const maxCoverage = rights.groundCoverage || 0;
+    const plan = (data.plans || []).find(p => p.id === data.zoningCode);
 
     return `<div class="metric-display">
-        <div class="metric-value" style="font-size: 1.1rem;">${primaryUse}</div>
+        <div class="metric-value" style="font-size: 1.1rem;">${escapeHTML(primaryUse)}</div>
         <div class="metric-label">Land Use Classification</div>
-        ${zoningCode ? `<div class="metric-secondary">Zone: <strong>${zoningCode}</strong></div>` : ''}
-        ${zoningDetails ? `<div class="metric-secondary" style="margin-top: 0.25rem;">${zoningDetails}</div>` : ''}
-        ${maxHeight > 0 || maxCoverage > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+        ${zoningDetails ? `<div class="metric-secondary">Bestemming: <strong>${escapeHTML(zoningDetails)}</strong></div>` : ''}
+        ${plan ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            📋 <a href="${escapeHTML(plan.url)}" target="_blank" rel="noopener">${escapeHTML(plan.name)}</a> (${escapeHTML(plan.status)})
+        </div>` : ''}
+        ${maxHeight > 0 || maxGutter > 0 || maxCoverage > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
             ${maxHeight > 0 ? `📏 Max height: <strong>${maxHeight}m</strong>` : ''}
+            ${maxGutter > 0 ? ` &nbsp;|&nbsp; Gutter: <strong>${maxGutter}m</strong>` : ''}
             ${maxCoverage > 0 ? ` &nbsp;|&nbsp; 📐 Max coverage: <strong>${maxCoverage}%</strong>` : ''}
         </div>` : ''}
-        ${allowedUses.length > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
-            ✓ Allowed: ${allowedUses.slice(0, 3).join(', ')}${allowedUses.length > 3 ? '...' : ''}
+        ${restrictions.length > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            ⚠️ ${restrictions.slice(0, 3).map(escapeHTML).join(', ')}${restrictions.length > 3 ? '...' : ''}
+        </div>` : ''}
+        ${futurePlans.length > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            🚧 Proposed: ${futurePlans.map(p => escapeHTML(p.planName)).slice(0, 2).join(', ')}
         </div>` : ''}
     </div>`;
 }
 
 export function renderPDOKPlatform(data) {
     if (!data) return '';
-    
-    const pdokZoning = data.zoningInfo || 'Data Available';
-    const pdokRestrictions = data.restrictions || [];
-    const pdokPlanType = data.planType || '';
-    const pdokPlanStatus = data.planStatus || '';
+
+    const parcel = data.cadastralData;
+    if (!parcel) {
+        return `<div class="metric-display">
+        <div class="metric-value" style="font-size: 1rem;">No parcel found</div>
+        <div class="metric-label">Cadastral Parcel</div>
+    </div>`;
+    }
+
+    const designation = [parcel.municipalityCode, parcel.section, parcel.parcelNumber].filter(Boolean).join(' ');
 
     return `<div class="metric-display">
-        <div class="metric-value" style="font-size: 1rem;">${pdokZoning}</div>
-        <div class="metric-label">Zoning Information</div>
-        ${pdokPlanType || pdokPlanStatus ? `<div class="metric-secondary">
-            ${pdokPlanType ? `📋 Type: <strong>${pdokPlanType}</strong>` : ''}
-            ${pdokPlanStatus ? ` &nbsp;|&nbsp; 📊 Status: <strong>${pdokPlanStatus}</strong>` : ''}
+        <div class="metric-value" style="font-size: 1rem;">${designation || parcel.parcelId}</div>
+        <div class="metric-label">Cadastral Parcel</div>
+        ${parcel.municipality ? `<div class="metric-secondary">Kadastrale gemeente: <strong>${parcel.municipality}</strong></div>` : ''}
+        ${parcel.area ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            📐 Plot size: <strong>${parcel.area} m²</strong>
+            ${parcel.registeredArea ? ` &nbsp;|&nbsp; Registered: <strong>${parcel.registeredArea} m²</strong>` : ''}
         </div>` : ''}
-        ${pdokRestrictions.length > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
-            ⚠️ <strong>${pdokRestrictions.length}</strong> restriction${pdokRestrictions.length > 1 ? 's' : ''}: ${pdokRestrictions.slice(0, 2).join(', ')}${pdokRestrictions.length > 2 ? '...' : ''}
+    </div>`;
+}
+
+export function renderBAGBuilding(data) {
+    if (!data) return '';
+
+    const usage = (data.usageFunctions || []).join(', ');
+
+    return `<div class="metric-display">
+        <div class="metric-value">${data.constructionYear || 'Unknown'}</div>
+        <div class="metric-label">Construction Year</div>
+        ${data.status ? `<div class="metric-secondary">${data.status}</div>` : ''}
+        ${usage ? `<div class="metric-secondary" style="margin-top: 0.25rem;">🏠 Use: <strong>${usage}</strong></div>` : ''}
+        ${data.floorArea ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            📐 Floor area: <strong>${data.floorArea} m²</strong>
+            ${data.footprintArea ? ` &nbsp;|&nbsp; Footprint: <strong>${data.footprintArea} m²</strong>` : ''}
         </div>` : ''}
+        ${data.unitsInBuilding > 1 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">🏢 ${data.unitsInBuilding} units in building</div>` : ''}
     </div>`;
 }
 
 export function renderMonumentStatus(data) {
     if (!data) return '';
-    
-    const hasStatus = data.status && data.status !== 'Not protected';
-    const monumentType = data.type || '';
-    const monumentDescription = data.description || '';
-    const registrationDate = data.registrationDate || '';
-    const monumentNumber = data.monumentNumber || '';
+
+    const area = data.protectedArea;
+    const hasStatus = data.isMonument || !!area;
+    // Only rijksmonumenten are checked, so without one the local status stays unknown
+    const localUnknown = !hasStatus && data.localStatus === 'unknown';
+    const status = data.isMonument ? data.type : (area ? 'Beschermd gezicht' : (localUnknown ? 'No rijksmonument' : 'Not Protected'));
 
     return `<div class="metric-display">
         <div style="margin-bottom: 0.5rem;">
-            <span class="status-badge ${hasStatus ? 'moderate' : 'good'}">${data.status || 'Not Protected'}</span>
-            ${monumentType ? `<span class="status-badge" style="margin-left: 4px; background: var(--bg-tertiary);">${monumentType}</span>` : ''}
+            <span class="status-badge ${hasStatus || localUnknown ? 'moderate' : 'good'}">${escapeHTML(status)}</span>
+            ${data.isMonument && area ? `<span class="status-badge" style="margin-left: 4px; background: var(--bg-tertiary);">Beschermd gezicht</span>` : ''}
         </div>
         <div class="metric-label">Heritage Protection Status</div>
-        ${monumentDescription ? `<div class="metric-secondary">${monumentDescription}</div>` : ''}
-        ${monumentNumber ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
-            📋 Register #: <strong>${monumentNumber}</strong>
+        ${data.name ? `<div class="metric-secondary">${escapeHTML(data.name)}</div>` : ''}
+        ${data.number ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            📋 Register #: ${data.url ? `<a href="${escapeHTML(data.url)}" target="_blank" rel="noopener"><strong>${escapeHTML(data.number)}</strong></a>` : `<strong>${escapeHTML(data.number)}</strong>`}
         </div>` : ''}
-        ${registrationDate ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
-            📅 Registered: <strong>${registrationDate}</strong>
+        ${data.date ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            📅 Protected since: <strong>${escapeHTML(data.date)}</strong>
+        </div>` : ''}
+        ${area ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            🏘️ In ${area.url ? `<a href="${escapeHTML(area.url)}" target="_blank" rel="noopener">${escapeHTML(area.name)}</a>` : escapeHTML(area.name)}${area.date ? ` (since ${escapeHTML(area.date)})` : ''}
         </div>` : ''}
         ${hasStatus ? `<div class="metric-secondary" style="margin-top: 0.25rem; font-size: 0.8rem; opacity: 0.8;">
             ⚠️ Renovation requires heritage permit
         </div>` : ''}
+        ${localUnknown ? `<div class="metric-secondary" style="margin-top: 0.25rem; font-size: 0.8rem; opacity: 0.8;">
+            Gemeentelijk or provinciaal monument status unknown: only rijksmonumenten are checked
+        </div>` : ''}
     </div>`;
 }
 
@@ -3,6 +3,32 @@
  * Handles Energy Labels, Climate, and Sustainability data
  */
 
+export function renderEnergyLabel(data) {
+    if (!data) return '';
+
+    const label = data.label || (data.provisionalLabel ? data.provisionalLabel.label : 'Unknown');
+    const labelClass = ['A++++', 'A+++', 'A++', 'A+', 'A', 'B'].includes(label) ? 'good' : ['C', 'D'].includes(label) ? 'moderate' : 'poor';
+
+    return `<div class="metric-display">
+        <div style="margin-bottom: 0.5rem;">
+            <span class="status-badge ${labelClass}" style="font-size: 1.1rem; padding: 8px 16px;">⚡ ${label}</span>
+            ${data.expired ? `<span class="status-badge poor" style="margin-left: 8px;">Expired</span>` : ''}
+            ${!data.label && data.provisionalLabel ? `<span class="status-badge moderate" style="margin-left: 8px;">Voorlopig</span>` : ''}
+        </div>
+        <div class="metric-label">Registered Energy Label</div>
+        ${data.registrationDate ? `<div class="metric-secondary" style="margin-top: 0.5rem;">
+            📅 Registered <strong>${data.registrationDate}</strong>${data.validUntil ? ` &nbsp;|&nbsp; valid until <strong>${data.validUntil}</strong>` : ''}
+        </div>` : ''}
+        ${data.ep2 > 0 ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            🔥 EP2: <strong>${data.ep2}</strong> kWh/m²/yr
+        </div>` : ''}
+        ${data.calculationMethod ? `<div class="metric-secondary" style="margin-top: 0.25rem;">📐 ${data.calculationMethod}</div>` : ''}
+        ${data.label && data.provisionalLabel ? `<div class="metric-secondary" style="margin-top: 0.25rem;">
+            Earlier voorlopig label: <strong>${data.provisionalLabel.label}</strong>
+        </div>` : ''}
+    </div>`;
+}
+
 export function renderEnergyClimate(data) {
     if (!data) return '';
     
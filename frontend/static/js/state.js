@@ -29,7 +29,8 @@ export const AVAILABLE_APIS = {
 		{ name: 'AHN Height Model' },
 		{ name: 'Monument Status' },
 		{ name: 'PDOK Platform' },
-		{ name: 'Land Use & Zoning' }
+		{ name: 'BAG Building' },
+		{ name: 'EP-Online Energy Label' }
 	],
 	freemium: [
 		{ name: 'Noise Pollution' },
@@ -38,7 +39,8 @@ export const AVAILABLE_APIS = {
 		{ name: 'Parking Availability' },
 		{ name: 'Digital Delta Water Quality' },
 		{ name: 'CBS Safety Experience' },
-		{ name: 'Building Permits' }
+		{ name: 'Building Permits' },
+		{ name: 'Land Use & Zoning' }
 	],
 	premium: [
 		{ name: 'Kadaster Object Info' },
@@ -3,6 +3,15 @@
  * Shared helper functions used across renderers
  */
 
+/**
+ * Escape text for interpolation into HTML, including attribute values
+ * @param {*} s - Value to escape; null and undefined become empty
+ * @returns {string} - Escaped string
+ */
+export function escapeHTML(s) {
+    return String(s ?? '').replace(/[&<>"']/g, c => `&#${c.charCodeAt(0)};`);
+}
+
 /**
  * Format timestamp for display
  * @param {string} ts - ISO timestamp string